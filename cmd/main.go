@@ -2,38 +2,36 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	auth_v1 "github.com/olezhek28/auth-service/pkg/auth/v1"
+	auth_v2 "github.com/olezhek28/auth-service/pkg/auth/v2"
 	"github.com/olezhek28/auth-service/pkg/config"
 	"github.com/olezhek28/auth-service/pkg/database"
 	"github.com/olezhek28/auth-service/pkg/handler"
 	"github.com/olezhek28/auth-service/pkg/interceptor"
+	"github.com/olezhek28/auth-service/pkg/keys"
 	"github.com/olezhek28/auth-service/pkg/logger"
 	"github.com/olezhek28/auth-service/pkg/migrations"
+	"github.com/olezhek28/auth-service/pkg/notifier"
+	"github.com/olezhek28/auth-service/pkg/password"
 	"github.com/olezhek28/auth-service/pkg/redis"
 	"github.com/olezhek28/auth-service/pkg/repository"
 	"github.com/olezhek28/auth-service/pkg/service"
+	"github.com/olezhek28/auth-service/pkg/token"
 )
 
 func main() {
-	ch := make(chan struct{}, 1)
-	ch <- struct{}{}
-	val, ok := <-ch
-	fmt.Println(val, ok)
-	close(ch)
-	val, ok = <-ch
-	fmt.Println(val, ok)
-
-	return
 	// Загружаем конфигурацию
 	cfg, err := config.Load()
 	if err != nil {
@@ -94,13 +92,136 @@ func main() {
 
 	// Создаем репозитории
 	userRepo := repository.NewUserRepository(dbPool)
+	organizationRepo := repository.NewOrganizationRepository(dbPool)
+	membershipRepo := repository.NewMembershipRepository(dbPool)
+	apiKeyRepo := repository.NewAPIKeyRepository(dbPool)
+	serviceAccountRepo := repository.NewServiceAccountRepository(dbPool)
+	oauthRepo := repository.NewOAuthRepository(dbPool)
+	oauthCodeRepo := repository.NewOAuthCodeRepository(redisPool)
 	sessionRepo := repository.NewSessionRepository(redisPool)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(redisPool)
+	passwordResetRepo := repository.NewPasswordResetRepository(dbPool)
+	emailVerificationRepo := repository.NewEmailVerificationRepository(dbPool)
+	mfaRepo := repository.NewMFARepository(dbPool)
+	mfaChallengeRepo := repository.NewMFAChallengeRepository(redisPool)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(redisPool)
+	roleRepo := repository.NewRoleRepository(dbPool)
+	accessCacheRepo := repository.NewUserAccessCacheRepository(redisPool)
+	signingKeyRepo := repository.NewSigningKeyRepository(dbPool)
+
+	// Загружаем ключи подписи access токенов. Тем же шифром защищены секреты TOTP
+	keyCipher, err := keys.NewAESCipher(string(cfg.Keys.EncryptionKey))
+	if err != nil {
+		log.Error("failed to create key cipher", "error", err)
+		os.Exit(1)
+	}
+	keyManager := keys.NewManager(signingKeyRepo, keyCipher, log, keys.Config{
+		RotationPeriod:  cfg.Keys.RotationPeriod,
+		Overlap:         cfg.Keys.RotationOverlap,
+		RefreshInterval: cfg.Keys.RefreshInterval,
+	})
+	if err := keyManager.Load(ctx); err != nil {
+		log.Error("failed to load signing keys", "error", err)
+		os.Exit(1)
+	}
+	go keyManager.Run(ctx)
+	log.Info("signing keys loaded")
+
+	tokenManager := token.NewEd25519Manager(keyManager, cfg.Auth.TokenIssuer, cfg.Auth.AccessTokenTTL)
+
+	// Создаем канал доставки сообщений пользователям
+	var userNotifier notifier.Notifier
+	switch cfg.Notifier.Type {
+	case config.NotifierTypeFile:
+		userNotifier = notifier.NewFileNotifier(cfg.Notifier.FilePath)
+	case config.NotifierTypeSMTP:
+		userNotifier = notifier.NewSMTPNotifier(notifier.SMTPConfig{
+			Host:     cfg.Notifier.SMTPHost,
+			Port:     cfg.Notifier.SMTPPort,
+			Username: cfg.Notifier.SMTPUsername,
+			Password: string(cfg.Notifier.SMTPPassword),
+			From:     cfg.Notifier.SMTPFrom,
+		})
+	default:
+		userNotifier = notifier.NewLogNotifier(log)
+	}
+	log.Info("notifier configured", "type", cfg.Notifier.Type)
+
+	// Создаем хешер паролей. Диапазоны параметров проверены при загрузке конфигурации
+	passwordHasher, err := password.NewHasher(password.Config{
+		Algorithm:  cfg.Password.Algorithm,
+		BcryptCost: cfg.Password.BcryptCost,
+		Argon2: password.Argon2Params{
+			Memory:      uint32(cfg.Password.Argon2Memory),     //nolint:gosec
+			Iterations:  uint32(cfg.Password.Argon2Iterations), //nolint:gosec
+			Parallelism: uint8(cfg.Password.Argon2Parallelism), //nolint:gosec
+			SaltLength:  uint32(cfg.Password.Argon2SaltLength), //nolint:gosec
+			KeyLength:   uint32(cfg.Password.Argon2KeyLength),  //nolint:gosec
+		},
+	})
+	if err != nil {
+		log.Error("failed to create password hasher", "error", err)
+		os.Exit(1)
+	}
+	log.Info("password hasher configured", "algorithm", cfg.Password.Algorithm)
+
+	// Загружаем список утекших паролей и создаем политику паролей
+	var breachedPasswords *password.BreachedList
+	if cfg.Policy.BreachedListPath != "" {
+		breachedPasswords, err = password.LoadBreachedList(cfg.Policy.BreachedListPath)
+		if err != nil {
+			log.Error("failed to load breached password list", "error", err)
+			os.Exit(1)
+		}
+		log.Info("breached password list loaded", "hashes", breachedPasswords.Size())
+	}
+	passwordPolicy := password.NewPolicy(password.PolicyConfig{
+		MinLength:          cfg.Policy.MinLength,
+		MaxLength:          cfg.Policy.MaxLength,
+		RequireLower:       cfg.Policy.RequireLower,
+		RequireUpper:       cfg.Policy.RequireUpper,
+		RequireDigit:       cfg.Policy.RequireDigit,
+		RequireSymbol:      cfg.Policy.RequireSymbol,
+		MaxRepeated:        cfg.Policy.MaxRepeated,
+		ForbidPersonalInfo: cfg.Policy.ForbidPersonalInfo,
+	}, breachedPasswords)
 
 	// Создаем сервисы
-	authService := service.NewAuthService(userRepo, sessionRepo, log, cfg.Auth.SessionTTL)
+	authService := service.NewAuthService(
+		userRepo,
+		organizationRepo,
+		membershipRepo,
+		sessionRepo,
+		refreshTokenRepo,
+		passwordResetRepo,
+		emailVerificationRepo,
+		mfaRepo,
+		mfaChallengeRepo,
+		loginAttemptRepo,
+		roleRepo,
+		accessCacheRepo,
+		apiKeyRepo,
+		serviceAccountRepo,
+		oauthRepo,
+		oauthCodeRepo,
+		tokenManager,
+		passwordHasher,
+		passwordPolicy,
+		keyCipher,
+		userNotifier,
+		log,
+		cfg.Auth,
+	)
 
 	// Создаем handlers
-	authHandler := handler.NewAuthHandler(authService, log)
+	authV2Handler := handler.NewAuthV2Handler(
+		authService,
+		keyManager,
+		string(cfg.Server.AdminToken),
+		cfg.Server.TrustedProxies,
+		log,
+	)
+	authHandler := handler.NewAuthHandler(authV2Handler, log)
 
 	// Создаем TCP listener
 	lis, err := net.Listen("tcp", cfg.Server.Port)
@@ -112,18 +233,55 @@ func main() {
 	// Создаем gRPC сервер с interceptors
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
+			interceptor.RequestIDInterceptor(),
 			interceptor.RecoveryInterceptor(log),
 			interceptor.LoggingInterceptor(log),
+			interceptor.DeadlineInterceptor(cfg.Server.RequestTimeout),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamRequestIDInterceptor(),
+			interceptor.StreamRecoveryInterceptor(log),
+			interceptor.StreamLoggingInterceptor(log),
+			interceptor.StreamDeadlineInterceptor(cfg.Server.RequestTimeout),
 		),
 	)
 
 	// Регистрируем сервисы
 	auth_v1.RegisterAuthServiceServer(grpcServer, authHandler)
+	auth_v2.RegisterAuthServiceServer(grpcServer, authV2Handler)
 
 	// Включаем reflection для отладки
 	reflection.Register(grpcServer)
 
-	// Запускаем сервер в горутине
+	// Создаем HTTP сервер для публичных эндпоинтов
+	mux := http.NewServeMux()
+	mux.Handle("GET /.well-known/jwks.json", handler.NewJWKSHandler(keyManager, log))
+	oauthHandler := handler.NewOAuthHandler(authService, cfg.OAuth, cfg.Server.TrustedProxies, log)
+	mux.HandleFunc("GET /oauth2/authorize", oauthHandler.Authorize)
+	mux.HandleFunc("POST /oauth2/login", oauthHandler.Login)
+	mux.HandleFunc("POST /oauth2/token", oauthHandler.Token)
+	mux.HandleFunc("POST /oauth2/revoke", oauthHandler.Revoke)
+	switch {
+	case cfg.OAuth.PublicURL == "":
+		log.Warn("OAUTH_PUBLIC_URL is not set, OpenID Connect discovery is built from the Host header and not cached")
+	case cfg.OAuth.PublicURL != cfg.Auth.TokenIssuer:
+		log.Warn("TOKEN_ISSUER differs from OAUTH_PUBLIC_URL, OpenID Connect clients will reject ID tokens",
+			"issuer", cfg.Auth.TokenIssuer,
+			"public_url", cfg.OAuth.PublicURL,
+		)
+	}
+	oidcHandler := handler.NewOIDCHandler(authService, cfg.Auth.TokenIssuer, cfg.OAuth, log)
+	mux.HandleFunc("GET /.well-known/openid-configuration", oidcHandler.Discovery)
+	mux.HandleFunc("GET /userinfo", oidcHandler.UserInfo)
+	mux.HandleFunc("POST /userinfo", oidcHandler.UserInfo)
+
+	httpServer := &http.Server{
+		Addr:              cfg.Server.HTTPPort,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	// Запускаем серверы в горутинах
 	go func() {
 		log.Info("starting gRPC server", "port", cfg.Server.Port)
 		if err := grpcServer.Serve(lis); err != nil {
@@ -132,6 +290,14 @@ func main() {
 		}
 	}()
 
+	go func() {
+		log.Info("starting HTTP server", "port", cfg.Server.HTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("HTTP server failed", "error", err)
+			cancel()
+		}
+	}()
+
 	// Ожидаем сигнал завершения
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -150,6 +316,11 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer shutdownCancel()
 
+	// Останавливаем HTTP сервер
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Warn("HTTP server shutdown failed", "error", err)
+	}
+
 	// Останавливаем gRPC сервер
 	done := make(chan struct{})
 	go func() {
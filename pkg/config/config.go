@@ -2,9 +2,14 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/olezhek28/auth-service/pkg/password"
 )
 
 // Config содержит всю конфигурацию приложения
@@ -13,12 +18,26 @@ type Config struct {
 	Database DatabaseConfig
 	Redis    RedisConfig
 	Auth     AuthConfig
+	Keys     KeysConfig
+	Notifier NotifierConfig
+	Password PasswordHashConfig
+	Policy   PasswordPolicyConfig
+	OAuth    OAuthConfig
 }
 
-// ServerConfig конфигурация gRPC сервера
+// ServerConfig конфигурация gRPC и HTTP серверов
 type ServerConfig struct {
 	Port            string
+	HTTPPort        string
 	ShutdownTimeout time.Duration
+	RequestTimeout  time.Duration
+	// AdminToken токен для административных RPC в метаданных x-admin-token.
+	// Если не задан, административные RPC недоступны
+	AdminToken Secret
+	// TrustedProxies адреса и подсети прокси, от которых принимается X-Forwarded-For.
+	// Адрес клиента из заголовка нужен для ограничения попыток входа по IP, поэтому
+	// без доверенных прокси заголовок игнорируется и используется адрес соединения
+	TrustedProxies []netip.Prefix
 }
 
 // DatabaseConfig конфигурация PostgreSQL
@@ -41,7 +60,153 @@ type RedisConfig struct {
 
 // AuthConfig конфигурация аутентификации
 type AuthConfig struct {
-	SessionTTL time.Duration
+	// SessionIdleTimeout время жизни сессии без активности, продлевается при каждом WhoAmI
+	SessionIdleTimeout time.Duration
+	// SessionAbsoluteTimeout максимальное время жизни сессии независимо от активности
+	SessionAbsoluteTimeout time.Duration
+	// AccessTokenTTL время жизни подписанного access токена
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL время жизни refresh токена, отсчитывается заново при каждой ротации
+	RefreshTokenTTL time.Duration
+	// RefreshTokenMaxLifetime максимальное время жизни семейства refresh токенов от входа,
+	// после которого ротация отклоняется и нужен новый вход
+	RefreshTokenMaxLifetime time.Duration
+	// TokenIssuer значение claim iss в access и ID токенах и issuer в документе discovery OpenID Connect.
+	// Для OpenID Connect это должен быть внешний адрес сервера, совпадающий с OAUTH_PUBLIC_URL
+	TokenIssuer string
+	// PasswordResetTTL время жизни токена сброса пароля
+	PasswordResetTTL time.Duration
+	// PasswordHistorySize сколько прежних паролей, помимо текущего, нельзя использовать снова
+	PasswordHistorySize int
+	// EmailVerificationTTL время жизни токена подтверждения email
+	EmailVerificationTTL time.Duration
+	// InvitationTTL время, за которое нужно принять приглашение в организацию
+	InvitationTTL time.Duration
+	// RequireVerifiedEmail запрещает вход, пока пользователь не подтвердил email
+	RequireVerifiedEmail bool
+	// TOTPIssuer название сервиса, которое показывает приложение-аутентификатор
+	TOTPIssuer string
+	// MFAChallengeTTL время, за которое нужно ввести второй фактор после пароля
+	MFAChallengeTTL time.Duration
+	// MFAMaxAttempts количество неверных кодов, после которого вход нужно начинать заново
+	MFAMaxAttempts int
+	// AccountLoginThrottle ограничение неудачных попыток входа в один аккаунт
+	AccountLoginThrottle LoginThrottleConfig
+	// IPLoginThrottle ограничение неудачных попыток входа с одного IP во все аккаунты
+	IPLoginThrottle LoginThrottleConfig
+	// PermissionCacheTTL время жизни закешированных ролей и разрешений пользователя.
+	// Кеш сбрасывается при изменении ролей, TTL ограничивает устаревание при сбое сброса
+	PermissionCacheTTL time.Duration
+	// OAuthCodeTTL время, за которое OAuth клиент должен обменять код авторизации на токены
+	OAuthCodeTTL time.Duration
+}
+
+// LoginThrottleConfig ограничение неудачных попыток входа
+type LoginThrottleConfig struct {
+	// Window окно, в котором считаются неудачные попытки
+	Window time.Duration
+	// BackoffAfter количество неудач, после которого каждая следующая попытка откладывается
+	// на BackoffBase, удваивающийся с каждой неудачей, но не больше BackoffMax
+	BackoffAfter int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	// LockoutAfter количество неудач, после которого вход блокируется на LockoutDuration
+	LockoutAfter    int
+	LockoutDuration time.Duration
+}
+
+// KeysConfig конфигурация хранения и ротации ключей подписи
+type KeysConfig struct {
+	// EncryptionKey мастер-ключ AES-256 в base64, которым шифруются приватные ключи в базе
+	EncryptionKey   Secret
+	RotationPeriod  time.Duration
+	RotationOverlap time.Duration
+	RefreshInterval time.Duration
+}
+
+// PasswordHashConfig конфигурация хеширования паролей. Хеши с другим алгоритмом
+// или параметрами пересчитываются при успешном входе пользователя
+type PasswordHashConfig struct {
+	// Algorithm алгоритм новых хешей: bcrypt или argon2id
+	Algorithm  string
+	BcryptCost int
+	// Argon2Memory объем памяти argon2id в KiB
+	Argon2Memory      int
+	Argon2Iterations  int
+	Argon2Parallelism int
+	Argon2SaltLength  int
+	Argon2KeyLength   int
+}
+
+// PasswordPolicyConfig требования к новым паролям при регистрации, смене и сбросе
+type PasswordPolicyConfig struct {
+	// MinLength минимальная длина в символах
+	MinLength int
+	// MaxLength максимальная длина в байтах, 0 - без ограничения
+	MaxLength     int
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MaxRepeated максимальное количество одинаковых символов подряд, 0 - без ограничения
+	MaxRepeated int
+	// ForbidPersonalInfo запрещает email и имя пользователя внутри пароля
+	ForbidPersonalInfo bool
+	// BreachedListPath файл с SHA-1 хешами утекших паролей, пустой путь отключает проверку
+	BreachedListPath string
+}
+
+// NotifierConfig конфигурация доставки сообщений пользователям
+type NotifierConfig struct {
+	// Type способ доставки: log - в лог, file - в файл FilePath, smtp - письмом через SMTP
+	Type         string
+	FilePath     string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword Secret
+	SMTPFrom     string
+}
+
+// Способы доставки сообщений
+const (
+	NotifierTypeLog  = "log"
+	NotifierTypeFile = "file"
+	NotifierTypeSMTP = "smtp"
+)
+
+// OAuthConfig конфигурация HTTP эндпоинтов OAuth 2.0
+type OAuthConfig struct {
+	// PublicURL внешний адрес HTTP сервера, от которого строятся return_to и адреса эндпоинтов
+	// в документе discovery. Если не задан, return_to передается относительным, а адреса строятся
+	// от Host запроса, и такой документ discovery не кешируется
+	PublicURL string
+	// SessionCookie cookie с UUID сессии, по которой /oauth2/authorize определяет пользователя.
+	// Ее устанавливает /oauth2/login
+	SessionCookie string
+	// LoginURL страница входа, куда /oauth2/authorize отправляет пользователя без сессии.
+	// Адрес возврата передается в параметре return_to, страница отправляет его вместе с формой
+	// входа на /oauth2/login. Если не задана, клиент получает access_denied
+	LoginURL string
+	// ConsentURL страница согласия, куда /oauth2/authorize отправляет пользователя, еще не
+	// согласившегося на доступ клиента. Если не задана, клиент получает access_denied
+	ConsentURL string
+}
+
+// Secret строковое значение, которое не попадает в логи при выводе конфигурации
+type Secret string
+
+// String скрывает значение секрета
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// MarshalText скрывает значение секрета при сериализации
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
 }
 
 // Load загружает конфигурацию из environment variables
@@ -49,7 +214,10 @@ func Load() (*Config, error) {
 	cfg := &Config{
 		Server: ServerConfig{
 			Port:            getEnv("GRPC_PORT", ":50051"),
+			HTTPPort:        getEnv("HTTP_PORT", ":8080"),
 			ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+			RequestTimeout:  getDurationEnv("REQUEST_TIMEOUT", 10*time.Second),
+			AdminToken:      Secret(getEnv("ADMIN_TOKEN", "")),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("POSTGRES_HOST", "localhost"),
@@ -66,9 +234,87 @@ func Load() (*Config, error) {
 			DB:       getIntEnv("REDIS_DB", 0),
 		},
 		Auth: AuthConfig{
-			SessionTTL: getDurationEnv("SESSION_TTL", 24*time.Hour),
+			SessionIdleTimeout:      getDurationEnv("SESSION_IDLE_TIMEOUT", 24*time.Hour),
+			SessionAbsoluteTimeout:  getDurationEnv("SESSION_ABSOLUTE_TIMEOUT", 7*24*time.Hour),
+			AccessTokenTTL:          getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:         getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			RefreshTokenMaxLifetime: getDurationEnv("REFRESH_TOKEN_MAX_LIFETIME", 90*24*time.Hour),
+			TokenIssuer:             getEnv("TOKEN_ISSUER", "auth-service"),
+			PasswordResetTTL:        getDurationEnv("PASSWORD_RESET_TTL", time.Hour),
+			PasswordHistorySize:     getIntEnv("PASSWORD_HISTORY_SIZE", 5),
+			EmailVerificationTTL:    getDurationEnv("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+			InvitationTTL:           getDurationEnv("INVITATION_TTL", 7*24*time.Hour),
+			RequireVerifiedEmail:    getBoolEnv("REQUIRE_VERIFIED_EMAIL", false),
+			TOTPIssuer:              getEnv("TOTP_ISSUER", "auth-service"),
+			MFAChallengeTTL:         getDurationEnv("MFA_CHALLENGE_TTL", 5*time.Minute),
+			MFAMaxAttempts:          getIntEnv("MFA_MAX_ATTEMPTS", 5),
+			AccountLoginThrottle: LoginThrottleConfig{
+				Window:          getDurationEnv("LOGIN_ACCOUNT_FAILURE_WINDOW", 15*time.Minute),
+				BackoffAfter:    getIntEnv("LOGIN_ACCOUNT_BACKOFF_AFTER", 3),
+				BackoffBase:     getDurationEnv("LOGIN_ACCOUNT_BACKOFF_BASE", time.Second),
+				BackoffMax:      getDurationEnv("LOGIN_ACCOUNT_BACKOFF_MAX", time.Minute),
+				LockoutAfter:    getIntEnv("LOGIN_ACCOUNT_LOCKOUT_AFTER", 10),
+				LockoutDuration: getDurationEnv("LOGIN_ACCOUNT_LOCKOUT_DURATION", 15*time.Minute),
+			},
+			IPLoginThrottle: LoginThrottleConfig{
+				Window:          getDurationEnv("LOGIN_IP_FAILURE_WINDOW", 15*time.Minute),
+				BackoffAfter:    getIntEnv("LOGIN_IP_BACKOFF_AFTER", 20),
+				BackoffBase:     getDurationEnv("LOGIN_IP_BACKOFF_BASE", time.Second),
+				BackoffMax:      getDurationEnv("LOGIN_IP_BACKOFF_MAX", time.Minute),
+				LockoutAfter:    getIntEnv("LOGIN_IP_LOCKOUT_AFTER", 100),
+				LockoutDuration: getDurationEnv("LOGIN_IP_LOCKOUT_DURATION", 15*time.Minute),
+			},
+			PermissionCacheTTL: getDurationEnv("PERMISSION_CACHE_TTL", 5*time.Minute),
+			OAuthCodeTTL:       getDurationEnv("OAUTH_CODE_TTL", time.Minute),
+		},
+		Keys: KeysConfig{
+			EncryptionKey:   Secret(getEnv("KEY_ENCRYPTION_KEY", "")),
+			RotationPeriod:  getDurationEnv("KEY_ROTATION_PERIOD", 30*24*time.Hour),
+			RotationOverlap: getDurationEnv("KEY_ROTATION_OVERLAP", 24*time.Hour),
+			RefreshInterval: getDurationEnv("KEY_REFRESH_INTERVAL", time.Minute),
 		},
+		Notifier: NotifierConfig{
+			Type:         getEnv("NOTIFIER_TYPE", NotifierTypeLog),
+			FilePath:     getEnv("NOTIFIER_FILE_PATH", "notifications.log"),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: Secret(getEnv("SMTP_PASSWORD", "")),
+			SMTPFrom:     getEnv("SMTP_FROM", ""),
+		},
+		Password: PasswordHashConfig{
+			Algorithm:         getEnv("PASSWORD_HASH_ALGORITHM", password.AlgorithmArgon2id),
+			BcryptCost:        getIntEnv("PASSWORD_BCRYPT_COST", 10),
+			Argon2Memory:      getIntEnv("PASSWORD_ARGON2_MEMORY", 19*1024),
+			Argon2Iterations:  getIntEnv("PASSWORD_ARGON2_ITERATIONS", 2),
+			Argon2Parallelism: getIntEnv("PASSWORD_ARGON2_PARALLELISM", 1),
+			Argon2SaltLength:  getIntEnv("PASSWORD_ARGON2_SALT_LENGTH", 16),
+			Argon2KeyLength:   getIntEnv("PASSWORD_ARGON2_KEY_LENGTH", 32),
+		},
+		Policy: PasswordPolicyConfig{
+			MinLength:          getIntEnv("PASSWORD_MIN_LENGTH", 8),
+			MaxLength:          getIntEnv("PASSWORD_MAX_LENGTH", 72),
+			RequireLower:       getBoolEnv("PASSWORD_REQUIRE_LOWER", false),
+			RequireUpper:       getBoolEnv("PASSWORD_REQUIRE_UPPER", false),
+			RequireDigit:       getBoolEnv("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSymbol:      getBoolEnv("PASSWORD_REQUIRE_SYMBOL", false),
+			MaxRepeated:        getIntEnv("PASSWORD_MAX_REPEATED", 3),
+			ForbidPersonalInfo: getBoolEnv("PASSWORD_FORBID_PERSONAL_INFO", true),
+			BreachedListPath:   getEnv("PASSWORD_BREACHED_LIST_PATH", ""),
+		},
+		OAuth: OAuthConfig{
+			PublicURL:     strings.TrimSuffix(getEnv("OAUTH_PUBLIC_URL", ""), "/"),
+			SessionCookie: getEnv("OAUTH_SESSION_COOKIE", "session_uuid"),
+			LoginURL:      getEnv("OAUTH_LOGIN_URL", ""),
+			ConsentURL:    getEnv("OAUTH_CONSENT_URL", ""),
+		},
+	}
+
+	trustedProxies, err := parsePrefixList(getEnv("TRUSTED_PROXIES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: TRUSTED_PROXIES: %w", err)
 	}
+	cfg.Server.TrustedProxies = trustedProxies
 
 	if err := cfg.validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -91,6 +337,135 @@ func (c *Config) validate() error {
 	if c.Redis.Host == "" {
 		return fmt.Errorf("REDIS_HOST is required")
 	}
+	if c.Auth.SessionIdleTimeout < time.Second {
+		return fmt.Errorf("SESSION_IDLE_TIMEOUT must be at least 1s")
+	}
+	if c.Auth.SessionAbsoluteTimeout < c.Auth.SessionIdleTimeout {
+		return fmt.Errorf("SESSION_ABSOLUTE_TIMEOUT must not be less than SESSION_IDLE_TIMEOUT")
+	}
+	if c.Auth.AccessTokenTTL <= 0 {
+		return fmt.Errorf("ACCESS_TOKEN_TTL must be positive")
+	}
+	if c.Auth.RefreshTokenTTL < time.Second {
+		return fmt.Errorf("REFRESH_TOKEN_TTL must be at least 1s")
+	}
+	if c.Auth.RefreshTokenMaxLifetime < c.Auth.RefreshTokenTTL {
+		return fmt.Errorf("REFRESH_TOKEN_MAX_LIFETIME must not be less than REFRESH_TOKEN_TTL")
+	}
+	if c.Auth.PasswordResetTTL < time.Minute {
+		return fmt.Errorf("PASSWORD_RESET_TTL must be at least 1m")
+	}
+	if c.Auth.PasswordHistorySize < 0 {
+		return fmt.Errorf("PASSWORD_HISTORY_SIZE must not be negative")
+	}
+	if c.Auth.EmailVerificationTTL < time.Minute {
+		return fmt.Errorf("EMAIL_VERIFICATION_TTL must be at least 1m")
+	}
+	if c.Auth.InvitationTTL < time.Minute {
+		return fmt.Errorf("INVITATION_TTL must be at least 1m")
+	}
+	if c.Auth.MFAChallengeTTL < time.Second {
+		return fmt.Errorf("MFA_CHALLENGE_TTL must be at least 1s")
+	}
+	if c.Auth.MFAMaxAttempts < 1 {
+		return fmt.Errorf("MFA_MAX_ATTEMPTS must be positive")
+	}
+	if err := c.Auth.AccountLoginThrottle.validate("LOGIN_ACCOUNT"); err != nil {
+		return err
+	}
+	if err := c.Auth.IPLoginThrottle.validate("LOGIN_IP"); err != nil {
+		return err
+	}
+	if c.Auth.PermissionCacheTTL < time.Second || c.Auth.PermissionCacheTTL > time.Hour {
+		return fmt.Errorf("PERMISSION_CACHE_TTL must be between 1s and 1h")
+	}
+	if c.Auth.OAuthCodeTTL < time.Second || c.Auth.OAuthCodeTTL > 10*time.Minute {
+		return fmt.Errorf("OAUTH_CODE_TTL must be between 1s and 10m")
+	}
+	if c.OAuth.SessionCookie == "" {
+		return fmt.Errorf("OAUTH_SESSION_COOKIE is required")
+	}
+	if c.Keys.EncryptionKey == "" {
+		return fmt.Errorf("KEY_ENCRYPTION_KEY is required")
+	}
+	if c.Keys.RefreshInterval <= 0 {
+		return fmt.Errorf("KEY_REFRESH_INTERVAL must be positive")
+	}
+	if c.Keys.RotationOverlap < c.Auth.AccessTokenTTL {
+		return fmt.Errorf("KEY_ROTATION_OVERLAP must not be less than ACCESS_TOKEN_TTL")
+	}
+	if c.Keys.RotationPeriod <= c.Keys.RotationOverlap {
+		return fmt.Errorf("KEY_ROTATION_PERIOD must be greater than KEY_ROTATION_OVERLAP")
+	}
+	if err := c.Password.validate(); err != nil {
+		return err
+	}
+	if c.Policy.MinLength < 1 {
+		return fmt.Errorf("PASSWORD_MIN_LENGTH must be positive")
+	}
+	if c.Policy.MaxLength < 0 || (c.Policy.MaxLength > 0 && c.Policy.MaxLength < c.Policy.MinLength) {
+		return fmt.Errorf("PASSWORD_MAX_LENGTH must be 0 or not less than PASSWORD_MIN_LENGTH")
+	}
+	if c.Password.Algorithm == password.AlgorithmBcrypt && (c.Policy.MaxLength == 0 || c.Policy.MaxLength > 72) {
+		return fmt.Errorf("PASSWORD_MAX_LENGTH must be between 1 and 72 for PASSWORD_HASH_ALGORITHM=bcrypt")
+	}
+	if c.Policy.MaxRepeated < 0 {
+		return fmt.Errorf("PASSWORD_MAX_REPEATED must not be negative")
+	}
+	switch c.Notifier.Type {
+	case NotifierTypeLog:
+	case NotifierTypeFile:
+		if c.Notifier.FilePath == "" {
+			return fmt.Errorf("NOTIFIER_FILE_PATH is required for NOTIFIER_TYPE=%s", NotifierTypeFile)
+		}
+	case NotifierTypeSMTP:
+		if c.Notifier.SMTPHost == "" {
+			return fmt.Errorf("SMTP_HOST is required for NOTIFIER_TYPE=%s", NotifierTypeSMTP)
+		}
+		if c.Notifier.SMTPFrom == "" {
+			return fmt.Errorf("SMTP_FROM is required for NOTIFIER_TYPE=%s", NotifierTypeSMTP)
+		}
+	default:
+		return fmt.Errorf("unknown NOTIFIER_TYPE %q", c.Notifier.Type)
+	}
+	return nil
+}
+
+// validate проверяет корректность ограничения попыток входа. prefix - префикс переменных окружения
+func (c *LoginThrottleConfig) validate(prefix string) error {
+	if c.Window < time.Second {
+		return fmt.Errorf("%s_FAILURE_WINDOW must be at least 1s", prefix)
+	}
+	if c.BackoffAfter < 0 || c.LockoutAfter < 0 {
+		return fmt.Errorf("%s_BACKOFF_AFTER and %s_LOCKOUT_AFTER must not be negative", prefix, prefix)
+	}
+	if c.BackoffAfter > 0 && (c.BackoffBase <= 0 || c.BackoffMax < c.BackoffBase) {
+		return fmt.Errorf("%s_BACKOFF_BASE must be positive and not greater than %s_BACKOFF_MAX", prefix, prefix)
+	}
+	if c.LockoutAfter > 0 && c.LockoutDuration <= 0 {
+		return fmt.Errorf("%s_LOCKOUT_DURATION must be positive", prefix)
+	}
+	return nil
+}
+
+// validate проверяет, что параметры хеширования помещаются в типы argon2id.
+// Допустимые значения проверяет сам хешер при создании
+func (c *PasswordHashConfig) validate() error {
+	if c.Argon2Memory < 0 || c.Argon2Memory > math.MaxUint32 {
+		return fmt.Errorf("PASSWORD_ARGON2_MEMORY is out of range")
+	}
+	if c.Argon2Iterations < 0 || c.Argon2Iterations > math.MaxUint32 {
+		return fmt.Errorf("PASSWORD_ARGON2_ITERATIONS is out of range")
+	}
+	if c.Argon2Parallelism < 0 || c.Argon2Parallelism > math.MaxUint8 {
+		return fmt.Errorf("PASSWORD_ARGON2_PARALLELISM is out of range")
+	}
+	if c.Argon2SaltLength < 0 || c.Argon2SaltLength > math.MaxUint32 {
+		return fmt.Errorf("PASSWORD_ARGON2_SALT_LENGTH is out of range")
+	}
+	if c.Argon2KeyLength < 0 || c.Argon2KeyLength > math.MaxUint32 {
+		return fmt.Errorf("PASSWORD_ARGON2_KEY_LENGTH is out of range")
+	}
 	return nil
 }
 
@@ -107,6 +482,33 @@ func (c *DatabaseConfig) DSN() string {
 	)
 }
 
+// parsePrefixList разбирает список подсетей через запятую. Отдельный адрес считается подсетью из одного адреса
+func parsePrefixList(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
 // Helper functions
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -124,6 +526,15 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -3,19 +3,65 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Предопределенные ошибки
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrSessionNotFound    = errors.New("session not found")
-	ErrInvalidInput       = errors.New("invalid input")
-	ErrInternal           = errors.New("internal error")
+	ErrUserNotFound                  = errors.New("user not found")
+	ErrUserAlreadyExists             = errors.New("user already exists")
+	ErrInvalidCredentials            = errors.New("invalid credentials")
+	ErrSessionNotFound               = errors.New("session not found")
+	ErrTargetSessionNotFound         = errors.New("target session not found")
+	ErrInvalidAccessToken            = errors.New("invalid access token")
+	ErrInvalidRefreshToken           = errors.New("invalid refresh token")
+	ErrRefreshTokenReused            = errors.New("refresh token reused")
+	ErrInvalidPasswordResetToken     = errors.New("invalid password reset token")
+	ErrInvalidEmailVerificationToken = errors.New("invalid email verification token")
+	ErrEmailNotVerified              = errors.New("email not verified")
+	ErrMFARequired                   = errors.New("mfa required")
+	ErrMFAChallengeNotFound          = errors.New("mfa challenge not found")
+	ErrInvalidMFACode                = errors.New("invalid mfa code")
+	ErrTOTPAlreadyEnabled            = errors.New("totp already enabled")
+	ErrTOTPNotEnabled                = errors.New("totp not enabled")
+	ErrPasswordReused                = errors.New("password reused")
+	ErrTooManyLoginAttempts          = errors.New("too many login attempts")
+	ErrAccountLocked                 = errors.New("account locked")
+	ErrAdminRequired                 = errors.New("admin required")
+	ErrRoleNotFound                  = errors.New("role not found")
+	ErrRoleAlreadyExists             = errors.New("role already exists")
+	ErrOrganizationNotFound          = errors.New("organization not found")
+	ErrOrganizationAlreadyExists     = errors.New("organization already exists")
+	ErrNotOrganizationMember         = errors.New("not an organization member")
+	ErrMemberNotFound                = errors.New("member not found")
+	ErrCannotRemoveHomeMember        = errors.New("cannot remove member from home organization")
+	ErrInvalidInvitation             = errors.New("invalid invitation")
+	ErrInvitationEmailMismatch       = errors.New("invitation email mismatch")
+	ErrPermissionDenied              = errors.New("permission denied")
+	ErrInvalidAPIKey                 = errors.New("invalid api key")
+	ErrServiceAccountNotFound        = errors.New("service account not found")
+	ErrServiceAccountAlreadyExists   = errors.New("service account already exists")
+	ErrOAuthClientNotFound           = errors.New("oauth client not found")
+	ErrOAuthConsentNotFound          = errors.New("oauth consent not found")
+	ErrInvalidClient                 = errors.New("invalid client")
+	ErrInvalidGrant                  = errors.New("invalid grant")
+	ErrUnauthorizedClient            = errors.New("unauthorized client")
+	ErrInvalidScope                  = errors.New("invalid scope")
+	ErrInvalidRedirectURI            = errors.New("invalid redirect uri")
+	ErrUnsupportedGrantType          = errors.New("unsupported grant type")
+	ErrUnsupportedResponseType       = errors.New("unsupported response type")
+	ErrUnsupportedTokenType          = errors.New("unsupported token type")
+	ErrConsentRequired               = errors.New("consent required")
+	ErrInsufficientScope             = errors.New("insufficient scope")
+	ErrInvalidInput                  = errors.New("invalid input")
+	ErrInternal                      = errors.New("internal error")
 )
 
 // AppError представляет ошибку приложения с дополнительным контекстом
@@ -23,6 +69,8 @@ type AppError struct {
 	Code    codes.Code
 	Message string
 	Err     error
+	// Details машиночитаемые подробности, передаются клиенту в деталях gRPC статуса
+	Details []protoadapt.MessageV1
 }
 
 func (e *AppError) Error() string {
@@ -38,7 +86,68 @@ func (e *AppError) Unwrap() error {
 
 // ToGRPCError конвертирует ошибку в gRPC статус
 func (e *AppError) ToGRPCError() error {
-	return status.Error(e.Code, e.Message)
+	st := status.New(e.Code, e.Message)
+	if len(e.Details) == 0 {
+		return st.Err()
+	}
+
+	withDetails, err := st.WithDetails(e.Details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// RetryAfterError ошибка, после которой запрос можно повторить не раньше чем через RetryAfter
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", e.Err, e.RetryAfter)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetryAfter дополняет ошибку временем, через которое запрос можно повторить
+func WithRetryAfter(err error, retryAfter time.Duration) error {
+	return &RetryAfterError{
+		Err:        err,
+		RetryAfter: retryAfter,
+	}
+}
+
+// FieldViolation нарушение требования к полю запроса
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError ошибка входных данных со списком нарушений по полям
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", violation.Field, violation.Description))
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidInput, strings.Join(descriptions, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// NewValidationError создает ошибку входных данных с нарушениями по полям
+func NewValidationError(violations ...FieldViolation) error {
+	return &ValidationError{
+		Violations: violations,
+	}
 }
 
 // New создает новую ошибку приложения
@@ -69,6 +178,32 @@ func FromError(err error) *AppError {
 		return appErr
 	}
 
+	// Время до повтора передается клиенту в google.rpc.RetryInfo
+	var retryErr *RetryAfterError
+	if errors.As(err, &retryErr) {
+		appErr := FromError(retryErr.Err)
+		appErr.Details = append(appErr.Details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryErr.RetryAfter),
+		})
+		return appErr
+	}
+
+	// Нарушения по полям передаются клиенту в google.rpc.BadRequest
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range validationErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		appErr := New(codes.InvalidArgument, "Invalid input")
+		appErr.Details = append(appErr.Details, badRequest)
+		return appErr
+	}
+
 	// Маппинг известных ошибок
 	switch {
 	case errors.Is(err, ErrUserNotFound):
@@ -79,6 +214,88 @@ func FromError(err error) *AppError {
 		return New(codes.Unauthenticated, "Invalid credentials")
 	case errors.Is(err, ErrSessionNotFound):
 		return New(codes.Unauthenticated, "Session not found")
+	case errors.Is(err, ErrTargetSessionNotFound):
+		return New(codes.NotFound, "Session not found")
+	case errors.Is(err, ErrInvalidAccessToken):
+		return New(codes.Unauthenticated, "Invalid access token")
+	case errors.Is(err, ErrInvalidRefreshToken):
+		return New(codes.Unauthenticated, "Invalid refresh token")
+	case errors.Is(err, ErrRefreshTokenReused):
+		return New(codes.Unauthenticated, "Refresh token reuse detected, all tokens of the family are revoked")
+	case errors.Is(err, ErrInvalidPasswordResetToken):
+		return New(codes.InvalidArgument, "Invalid or expired password reset token")
+	case errors.Is(err, ErrInvalidEmailVerificationToken):
+		return New(codes.InvalidArgument, "Invalid or expired email verification token")
+	case errors.Is(err, ErrEmailNotVerified):
+		return New(codes.FailedPrecondition, "Email is not verified")
+	case errors.Is(err, ErrMFARequired):
+		return New(codes.FailedPrecondition, "Second factor is required, use auth.v2 CompleteMFA")
+	case errors.Is(err, ErrMFAChallengeNotFound):
+		return New(codes.Unauthenticated, "MFA challenge not found or expired")
+	case errors.Is(err, ErrInvalidMFACode):
+		return New(codes.Unauthenticated, "Invalid MFA code")
+	case errors.Is(err, ErrTOTPAlreadyEnabled):
+		return New(codes.FailedPrecondition, "TOTP is already enabled")
+	case errors.Is(err, ErrTOTPNotEnabled):
+		return New(codes.FailedPrecondition, "TOTP is not enabled")
+	case errors.Is(err, ErrPasswordReused):
+		return New(codes.InvalidArgument, "New password must differ from the recently used passwords")
+	case errors.Is(err, ErrTooManyLoginAttempts):
+		return New(codes.ResourceExhausted, "Too many login attempts, try again later")
+	case errors.Is(err, ErrAccountLocked):
+		return New(codes.PermissionDenied, "Account is temporarily locked due to too many failed login attempts")
+	case errors.Is(err, ErrAdminRequired):
+		return New(codes.PermissionDenied, "Admin credentials required")
+	case errors.Is(err, ErrRoleNotFound):
+		return New(codes.NotFound, "Role not found")
+	case errors.Is(err, ErrRoleAlreadyExists):
+		return New(codes.AlreadyExists, "Role already exists")
+	case errors.Is(err, ErrOrganizationNotFound):
+		return New(codes.NotFound, "Organization not found")
+	case errors.Is(err, ErrOrganizationAlreadyExists):
+		return New(codes.AlreadyExists, "Organization already exists")
+	case errors.Is(err, ErrNotOrganizationMember):
+		return New(codes.PermissionDenied, "Not a member of the organization")
+	case errors.Is(err, ErrMemberNotFound):
+		return New(codes.NotFound, "Member not found")
+	case errors.Is(err, ErrCannotRemoveHomeMember):
+		return New(codes.FailedPrecondition, "User cannot be removed from the organization they are registered in")
+	case errors.Is(err, ErrInvalidInvitation):
+		return New(codes.InvalidArgument, "Invalid or expired invitation")
+	case errors.Is(err, ErrInvitationEmailMismatch):
+		return New(codes.PermissionDenied, "Invitation was sent to a different email")
+	case errors.Is(err, ErrPermissionDenied):
+		return New(codes.PermissionDenied, "Permission denied")
+	case errors.Is(err, ErrInvalidAPIKey):
+		return New(codes.Unauthenticated, "Invalid API key")
+	case errors.Is(err, ErrServiceAccountNotFound):
+		return New(codes.NotFound, "Service account not found")
+	case errors.Is(err, ErrServiceAccountAlreadyExists):
+		return New(codes.AlreadyExists, "Service account already exists")
+	case errors.Is(err, ErrOAuthClientNotFound):
+		return New(codes.NotFound, "OAuth client not found")
+	case errors.Is(err, ErrOAuthConsentNotFound):
+		return New(codes.NotFound, "OAuth consent not found")
+	case errors.Is(err, ErrInvalidClient):
+		return New(codes.Unauthenticated, "Invalid OAuth client credentials")
+	case errors.Is(err, ErrInvalidGrant):
+		return New(codes.InvalidArgument, "Invalid or expired grant")
+	case errors.Is(err, ErrUnauthorizedClient):
+		return New(codes.PermissionDenied, "Client is not allowed to use this grant type")
+	case errors.Is(err, ErrInvalidScope):
+		return New(codes.InvalidArgument, "Requested scope is not allowed for the client")
+	case errors.Is(err, ErrInvalidRedirectURI):
+		return New(codes.InvalidArgument, "Redirect URI is not registered for the client")
+	case errors.Is(err, ErrUnsupportedGrantType):
+		return New(codes.InvalidArgument, "Unsupported grant type")
+	case errors.Is(err, ErrUnsupportedResponseType):
+		return New(codes.InvalidArgument, "Unsupported response type")
+	case errors.Is(err, ErrUnsupportedTokenType):
+		return New(codes.InvalidArgument, "Unsupported token type")
+	case errors.Is(err, ErrConsentRequired):
+		return New(codes.FailedPrecondition, "User consent is required")
+	case errors.Is(err, ErrInsufficientScope):
+		return New(codes.PermissionDenied, "Access token does not grant the required scope")
 	case errors.Is(err, ErrInvalidInput):
 		return New(codes.InvalidArgument, "Invalid input")
 	default:
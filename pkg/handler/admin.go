@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc/metadata"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+)
+
+// adminTokenHeader metadata ключ с токеном администратора
+const adminTokenHeader = "x-admin-token"
+
+// requireAdmin проверяет токен администратора в metadata запроса.
+// Если токен в конфигурации не задан, административные RPC недоступны.
+// Сервис не проверяет права на административные операции (организации, роли, OAuth клиенты,
+// снятие блокировки входа), поэтому каждый такой RPC должен начинаться с этой проверки
+func (h *AuthV2Handler) requireAdmin(ctx context.Context) error {
+	if h.adminToken == "" {
+		return apperrors.ErrAdminRequired
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	provided := firstMetadataValue(md, adminTokenHeader)
+
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(h.adminToken)) != 1 {
+		return apperrors.ErrAdminRequired
+	}
+
+	return nil
+}
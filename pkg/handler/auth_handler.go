@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+
+	auth_v1 "github.com/olezhek28/auth-service/pkg/auth/v1"
+	auth_v2 "github.com/olezhek28/auth-service/pkg/auth/v2"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/logger"
+)
+
+// AuthHandler gRPC обработчик сервиса аутентификации auth.v1.
+// Транслирует запросы в auth.v2, чтобы старые клиенты работали поверх актуального контракта.
+// В запросах auth.v1 нет организации, поэтому Login и Register берут ее из metadata x-tenant
+type AuthHandler struct {
+	auth_v1.UnimplementedAuthServiceServer
+
+	next   auth_v2.AuthServiceServer
+	logger logger.Logger
+}
+
+// NewAuthHandler создает новый gRPC обработчик сервиса аутентификации auth.v1
+func NewAuthHandler(next auth_v2.AuthServiceServer, logger logger.Logger) *AuthHandler {
+	return &AuthHandler{
+		next:   next,
+		logger: logger,
+	}
+}
+
+// Login выполняет вход пользователя в систему
+func (h *AuthHandler) Login(ctx context.Context, req *auth_v1.LoginRequest) (*auth_v1.LoginResponse, error) {
+	resp, err := h.next.Login(ctx, &auth_v2.LoginRequest{
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// auth.v1 не умеет завершать вход вторым фактором
+	if resp.GetMfaChallenge() != "" {
+		return nil, apperrors.FromError(apperrors.ErrMFARequired).ToGRPCError()
+	}
+
+	return &auth_v1.LoginResponse{
+		SessionUuid: resp.GetSessionUuid(),
+	}, nil
+}
+
+// Register регистрирует нового пользователя
+func (h *AuthHandler) Register(ctx context.Context, req *auth_v1.RegisterRequest) (*auth_v1.RegisterResponse, error) {
+	_, err := h.next.Register(ctx, &auth_v2.RegisterRequest{
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Поле user_uuid в auth.v1 объявлено как int64 и не может вместить UUID,
+	// поэтому оно остается пустым. Клиентам, которым нужен UUID, следует перейти на auth.v2
+	return &auth_v1.RegisterResponse{}, nil
+}
+
+// WhoAmI возвращает информацию о текущем пользователе
+func (h *AuthHandler) WhoAmI(ctx context.Context, req *auth_v1.WhoAmIRequest) (*auth_v1.WhoAmIResponse, error) {
+	resp, err := h.next.WhoAmI(ctx, &auth_v2.WhoAmIRequest{
+		Credential: &auth_v2.WhoAmIRequest_SessionUuid{
+			SessionUuid: req.GetSessionUuid(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Поле user_uuid в auth.v1 объявлено как int64 и не может вместить UUID,
+	// поэтому оно остается пустым. Клиентам, которым нужен UUID, следует перейти на auth.v2
+	return &auth_v1.WhoAmIResponse{
+		Email:     resp.GetUser().GetEmail(),
+		Username:  resp.GetUser().GetUsername(),
+		CreatedAt: resp.GetUser().GetCreatedAt(),
+	}, nil
+}
+
+// Logout завершает текущую сессию пользователя
+func (h *AuthHandler) Logout(ctx context.Context, req *auth_v1.LogoutRequest) (*auth_v1.LogoutResponse, error) {
+	_, err := h.next.Logout(ctx, &auth_v2.LogoutRequest{
+		SessionUuid: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth_v1.LogoutResponse{}, nil
+}
+
+// LogoutAll завершает все сессии пользователя
+func (h *AuthHandler) LogoutAll(ctx context.Context, req *auth_v1.LogoutAllRequest) (*auth_v1.LogoutAllResponse, error) {
+	resp, err := h.next.LogoutAll(ctx, &auth_v2.LogoutAllRequest{
+		SessionUuid: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth_v1.LogoutAllResponse{
+		RevokedSessions: resp.GetRevokedSessions(),
+	}, nil
+}
+
+// ListSessions возвращает активные сессии пользователя
+func (h *AuthHandler) ListSessions(ctx context.Context, req *auth_v1.ListSessionsRequest) (*auth_v1.ListSessionsResponse, error) {
+	resp, err := h.next.ListSessions(ctx, &auth_v2.ListSessionsRequest{
+		SessionUuid: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*auth_v1.SessionInfo, 0, len(resp.GetSessions()))
+	for _, session := range resp.GetSessions() {
+		sessions = append(sessions, &auth_v1.SessionInfo{
+			SessionUuid: session.GetSessionUuid(),
+			Ip:          session.GetIp(),
+			UserAgent:   session.GetUserAgent(),
+			ClientName:  session.GetClientName(),
+			CreatedAt:   session.GetCreatedAt(),
+			LastSeenAt:  session.GetLastSeenAt(),
+			Current:     session.GetCurrent(),
+		})
+	}
+
+	return &auth_v1.ListSessionsResponse{
+		Sessions: sessions,
+	}, nil
+}
+
+// RevokeSession завершает другую сессию пользователя
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *auth_v1.RevokeSessionRequest) (*auth_v1.RevokeSessionResponse, error) {
+	_, err := h.next.RevokeSession(ctx, &auth_v2.RevokeSessionRequest{
+		SessionUuid:       req.GetSessionUuid(),
+		TargetSessionUuid: req.GetTargetSessionUuid(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth_v1.RevokeSessionResponse{}, nil
+}
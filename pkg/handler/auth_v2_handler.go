@@ -0,0 +1,1095 @@
+package handler
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auth_v2 "github.com/olezhek28/auth-service/pkg/auth/v2"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/logger"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/service"
+)
+
+// AuthV2Handler gRPC обработчик сервиса аутентификации auth.v2
+type AuthV2Handler struct {
+	auth_v2.UnimplementedAuthServiceServer
+
+	authService service.AuthService
+	jwks        JWKSProvider
+	adminToken  string
+	// trustedProxies адреса прокси, от которых принимается X-Forwarded-For
+	trustedProxies []netip.Prefix
+	logger         logger.Logger
+}
+
+// NewAuthV2Handler создает новый gRPC обработчик сервиса аутентификации auth.v2.
+// adminToken открывает доступ к административным RPC, пустой токен их отключает.
+// trustedProxies адреса прокси, которым разрешено передавать адрес клиента в X-Forwarded-For
+func NewAuthV2Handler(
+	authService service.AuthService,
+	jwks JWKSProvider,
+	adminToken string,
+	trustedProxies []netip.Prefix,
+	logger logger.Logger,
+) *AuthV2Handler {
+	return &AuthV2Handler{
+		authService:    authService,
+		jwks:           jwks,
+		adminToken:     adminToken,
+		trustedProxies: trustedProxies,
+		logger:         logger,
+	}
+}
+
+// Login выполняет вход пользователя в систему
+func (h *AuthV2Handler) Login(ctx context.Context, req *auth_v2.LoginRequest) (*auth_v2.LoginResponse, error) {
+	resp, err := h.authService.Login(ctx, service.LoginRequest{
+		Tenant:      tenantFromRequest(ctx, req.GetTenant()),
+		Email:       req.GetEmail(),
+		Password:    req.GetPassword(),
+		Client:      clientInfoFromContext(ctx, h.trustedProxies),
+		IssueTokens: req.GetIssueTokens(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	switch {
+	case resp.MFAChallengeID != "":
+		return &auth_v2.LoginResponse{
+			UserUuid:     resp.UserUUID.String(),
+			ExpiresAt:    timestamppb.New(resp.ExpiresAt),
+			MfaChallenge: resp.MFAChallengeID,
+		}, nil
+	case resp.Tokens != nil:
+		return &auth_v2.LoginResponse{
+			UserUuid: resp.UserUUID.String(),
+			Tokens:   tokenPairToV2(*resp.Tokens),
+		}, nil
+	default:
+		return &auth_v2.LoginResponse{
+			SessionUuid: resp.SessionUUID,
+			UserUuid:    resp.UserUUID.String(),
+			ExpiresAt:   timestamppb.New(resp.ExpiresAt),
+		}, nil
+	}
+}
+
+// Register регистрирует нового пользователя
+func (h *AuthV2Handler) Register(ctx context.Context, req *auth_v2.RegisterRequest) (*auth_v2.RegisterResponse, error) {
+	resp, err := h.authService.Register(ctx, service.RegisterRequest{
+		Tenant:   tenantFromRequest(ctx, req.GetTenant()),
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RegisterResponse{
+		User: &auth_v2.User{
+			UserUuid:        resp.UserUUID.String(),
+			TenantId:        resp.TenantID.String(),
+			Email:           resp.Email,
+			Username:        resp.Username,
+			CreatedAt:       timestamppb.New(resp.CreatedAt),
+			UpdatedAt:       timestamppb.New(resp.UpdatedAt),
+			EmailVerifiedAt: optionalTimestamp(resp.EmailVerifiedAt),
+		},
+	}, nil
+}
+
+// WhoAmI возвращает информацию о текущем пользователе и сессии
+func (h *AuthV2Handler) WhoAmI(ctx context.Context, req *auth_v2.WhoAmIRequest) (*auth_v2.WhoAmIResponse, error) {
+	resp, err := h.authService.WhoAmI(ctx, service.WhoAmIRequest{
+		SessionUUID: req.GetSessionUuid(),
+		AccessToken: req.GetAccessToken(),
+		APIKey:      req.GetApiKey(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	whoAmI := &auth_v2.WhoAmIResponse{
+		PrincipalType: principalTypeToV2(resp.PrincipalType),
+	}
+	if resp.ServiceAccount != nil {
+		whoAmI.ServiceAccount = serviceAccountToV2(resp.ServiceAccount)
+	} else {
+		whoAmI.User = &auth_v2.User{
+			UserUuid:        resp.UserUUID.String(),
+			TenantId:        resp.TenantID.String(),
+			Email:           resp.Email,
+			Username:        resp.Username,
+			CreatedAt:       timestamppb.New(resp.CreatedAt),
+			UpdatedAt:       timestamppb.New(resp.UpdatedAt),
+			EmailVerifiedAt: optionalTimestamp(resp.EmailVerifiedAt),
+		}
+	}
+	if resp.Session.SessionUUID != "" {
+		whoAmI.Session = sessionToV2(resp.Session)
+	}
+	whoAmI.Roles = resp.Roles
+	whoAmI.Permissions = resp.Permissions
+	whoAmI.Organization = organizationToV2(resp.Organization)
+	whoAmI.OrganizationRole = resp.OrganizationRole
+	if resp.APIKey != nil {
+		whoAmI.ApiKey = apiKeyToV2(resp.APIKey)
+	}
+
+	return whoAmI, nil
+}
+
+// Logout завершает текущую сессию пользователя
+func (h *AuthV2Handler) Logout(ctx context.Context, req *auth_v2.LogoutRequest) (*auth_v2.LogoutResponse, error) {
+	_, err := h.authService.Logout(ctx, service.LogoutRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.LogoutResponse{}, nil
+}
+
+// LogoutAll завершает все сессии пользователя
+func (h *AuthV2Handler) LogoutAll(ctx context.Context, req *auth_v2.LogoutAllRequest) (*auth_v2.LogoutAllResponse, error) {
+	resp, err := h.authService.LogoutAll(ctx, service.LogoutAllRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.LogoutAllResponse{
+		RevokedSessions: int64(resp.RevokedSessions),
+	}, nil
+}
+
+// ListSessions возвращает активные сессии пользователя
+func (h *AuthV2Handler) ListSessions(ctx context.Context, req *auth_v2.ListSessionsRequest) (*auth_v2.ListSessionsResponse, error) {
+	resp, err := h.authService.ListSessions(ctx, service.ListSessionsRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	sessions := make([]*auth_v2.Session, 0, len(resp.Sessions))
+	for _, session := range resp.Sessions {
+		sessions = append(sessions, sessionToV2(session))
+	}
+
+	return &auth_v2.ListSessionsResponse{
+		Sessions: sessions,
+	}, nil
+}
+
+// RevokeSession завершает другую сессию пользователя
+func (h *AuthV2Handler) RevokeSession(ctx context.Context, req *auth_v2.RevokeSessionRequest) (*auth_v2.RevokeSessionResponse, error) {
+	_, err := h.authService.RevokeSession(ctx, service.RevokeSessionRequest{
+		SessionUUID:       req.GetSessionUuid(),
+		TargetSessionUUID: req.GetTargetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RevokeSessionResponse{}, nil
+}
+
+// Refresh обменивает refresh токен на новую пару токенов
+func (h *AuthV2Handler) Refresh(ctx context.Context, req *auth_v2.RefreshRequest) (*auth_v2.RefreshResponse, error) {
+	resp, err := h.authService.Refresh(ctx, service.RefreshRequest{
+		RefreshToken: req.GetRefreshToken(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RefreshResponse{
+		Tokens: tokenPairToV2(resp.Tokens),
+	}, nil
+}
+
+// GetJWKS возвращает публичные ключи для офлайн проверки access токенов
+func (h *AuthV2Handler) GetJWKS(_ context.Context, _ *auth_v2.GetJWKSRequest) (*auth_v2.GetJWKSResponse, error) {
+	jwks := h.jwks.JWKS()
+
+	result := make([]*auth_v2.JsonWebKey, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		result = append(result, &auth_v2.JsonWebKey{
+			Kty: key.KeyType,
+			Crv: key.Curve,
+			X:   key.X,
+			Kid: key.KeyID,
+			Alg: key.Algorithm,
+			Use: key.Use,
+		})
+	}
+
+	return &auth_v2.GetJWKSResponse{
+		Keys: result,
+	}, nil
+}
+
+// ChangePassword меняет пароль пользователя и завершает остальные его сессии
+func (h *AuthV2Handler) ChangePassword(ctx context.Context, req *auth_v2.ChangePasswordRequest) (*auth_v2.ChangePasswordResponse, error) {
+	resp, err := h.authService.ChangePassword(ctx, service.ChangePasswordRequest{
+		SessionUUID: req.GetSessionUuid(),
+		OldPassword: req.GetOldPassword(),
+		NewPassword: req.GetNewPassword(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.ChangePasswordResponse{
+		RevokedSessions: int64(resp.RevokedSessions),
+	}, nil
+}
+
+// RequestPasswordReset отправляет пользователю токен сброса пароля
+func (h *AuthV2Handler) RequestPasswordReset(
+	ctx context.Context,
+	req *auth_v2.RequestPasswordResetRequest,
+) (*auth_v2.RequestPasswordResetResponse, error) {
+	_, err := h.authService.RequestPasswordReset(ctx, service.RequestPasswordResetRequest{
+		Tenant: tenantFromRequest(ctx, req.GetTenant()),
+		Email:  req.GetEmail(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RequestPasswordResetResponse{}, nil
+}
+
+// ConfirmPasswordReset устанавливает новый пароль по токену сброса
+func (h *AuthV2Handler) ConfirmPasswordReset(
+	ctx context.Context,
+	req *auth_v2.ConfirmPasswordResetRequest,
+) (*auth_v2.ConfirmPasswordResetResponse, error) {
+	_, err := h.authService.ConfirmPasswordReset(ctx, service.ConfirmPasswordResetRequest{
+		Token:       req.GetToken(),
+		NewPassword: req.GetNewPassword(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.ConfirmPasswordResetResponse{}, nil
+}
+
+// VerifyEmail подтверждает email пользователя по токену
+func (h *AuthV2Handler) VerifyEmail(ctx context.Context, req *auth_v2.VerifyEmailRequest) (*auth_v2.VerifyEmailResponse, error) {
+	_, err := h.authService.VerifyEmail(ctx, service.VerifyEmailRequest{
+		Token: req.GetToken(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.VerifyEmailResponse{}, nil
+}
+
+// ResendVerification повторно отправляет токен подтверждения email
+func (h *AuthV2Handler) ResendVerification(
+	ctx context.Context,
+	req *auth_v2.ResendVerificationRequest,
+) (*auth_v2.ResendVerificationResponse, error) {
+	_, err := h.authService.ResendVerification(ctx, service.ResendVerificationRequest{
+		Tenant: tenantFromRequest(ctx, req.GetTenant()),
+		Email:  req.GetEmail(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.ResendVerificationResponse{}, nil
+}
+
+// EnrollTOTP начинает подключение TOTP
+func (h *AuthV2Handler) EnrollTOTP(ctx context.Context, req *auth_v2.EnrollTOTPRequest) (*auth_v2.EnrollTOTPResponse, error) {
+	resp, err := h.authService.EnrollTOTP(ctx, service.EnrollTOTPRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.EnrollTOTPResponse{
+		Secret:     resp.Secret,
+		OtpauthUri: resp.URI,
+	}, nil
+}
+
+// ConfirmTOTP подтверждает подключение TOTP и выдает коды восстановления
+func (h *AuthV2Handler) ConfirmTOTP(ctx context.Context, req *auth_v2.ConfirmTOTPRequest) (*auth_v2.ConfirmTOTPResponse, error) {
+	resp, err := h.authService.ConfirmTOTP(ctx, service.ConfirmTOTPRequest{
+		SessionUUID: req.GetSessionUuid(),
+		Code:        req.GetCode(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.ConfirmTOTPResponse{
+		RecoveryCodes: resp.RecoveryCodes,
+	}, nil
+}
+
+// DisableTOTP отключает TOTP
+func (h *AuthV2Handler) DisableTOTP(ctx context.Context, req *auth_v2.DisableTOTPRequest) (*auth_v2.DisableTOTPResponse, error) {
+	_, err := h.authService.DisableTOTP(ctx, service.DisableTOTPRequest{
+		SessionUUID:  req.GetSessionUuid(),
+		Code:         req.GetCode(),
+		RecoveryCode: req.GetRecoveryCode(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.DisableTOTPResponse{}, nil
+}
+
+// RegenerateRecoveryCodes выпускает новые коды восстановления
+func (h *AuthV2Handler) RegenerateRecoveryCodes(
+	ctx context.Context,
+	req *auth_v2.RegenerateRecoveryCodesRequest,
+) (*auth_v2.RegenerateRecoveryCodesResponse, error) {
+	resp, err := h.authService.RegenerateRecoveryCodes(ctx, service.RegenerateRecoveryCodesRequest{
+		SessionUUID: req.GetSessionUuid(),
+		Code:        req.GetCode(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RegenerateRecoveryCodesResponse{
+		RecoveryCodes: resp.RecoveryCodes,
+	}, nil
+}
+
+// CompleteMFA завершает вход вторым фактором
+func (h *AuthV2Handler) CompleteMFA(ctx context.Context, req *auth_v2.CompleteMFARequest) (*auth_v2.CompleteMFAResponse, error) {
+	resp, err := h.authService.CompleteMFA(ctx, service.CompleteMFARequest{
+		ChallengeID:  req.GetMfaChallenge(),
+		Code:         req.GetCode(),
+		RecoveryCode: req.GetRecoveryCode(),
+		Client:       clientInfoFromContext(ctx, h.trustedProxies),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	if resp.Tokens != nil {
+		return &auth_v2.CompleteMFAResponse{
+			UserUuid: resp.UserUUID.String(),
+			Tokens:   tokenPairToV2(*resp.Tokens),
+		}, nil
+	}
+
+	return &auth_v2.CompleteMFAResponse{
+		SessionUuid: resp.SessionUUID,
+		UserUuid:    resp.UserUUID.String(),
+		ExpiresAt:   timestamppb.New(resp.ExpiresAt),
+	}, nil
+}
+
+// UnlockUser снимает блокировку входа в аккаунт. Требует токен администратора
+func (h *AuthV2Handler) UnlockUser(ctx context.Context, req *auth_v2.UnlockUserRequest) (*auth_v2.UnlockUserResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.UnlockUser(ctx, service.UnlockUserRequest{
+		Tenant: tenantFromRequest(ctx, req.GetTenant()),
+		Email:  req.GetEmail(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.UnlockUserResponse{
+		Unlocked: resp.Unlocked,
+	}, nil
+}
+
+// CreateRole создает роль с набором разрешений. Требует токен администратора
+func (h *AuthV2Handler) CreateRole(ctx context.Context, req *auth_v2.CreateRoleRequest) (*auth_v2.CreateRoleResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.CreateRole(ctx, service.CreateRoleRequest{
+		Tenant:      tenantFromRequest(ctx, req.GetTenant()),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Permissions: req.GetPermissions(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CreateRoleResponse{
+		Role: &auth_v2.Role{
+			Name:        resp.Name,
+			Description: resp.Description,
+			Permissions: resp.Permissions,
+			CreatedAt:   timestamppb.New(resp.CreatedAt),
+		},
+	}, nil
+}
+
+// GrantRole выдает роль пользователю. Требует токен администратора
+func (h *AuthV2Handler) GrantRole(ctx context.Context, req *auth_v2.GrantRoleRequest) (*auth_v2.GrantRoleResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.GrantRole(ctx, service.GrantRoleRequest{
+		Tenant:   tenantFromRequest(ctx, req.GetTenant()),
+		UserUUID: req.GetUserUuid(),
+		Role:     req.GetRole(),
+		Resource: req.GetResource(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.GrantRoleResponse{
+		Granted: resp.Granted,
+	}, nil
+}
+
+// RevokeRole отзывает роль у пользователя. Требует токен администратора
+func (h *AuthV2Handler) RevokeRole(ctx context.Context, req *auth_v2.RevokeRoleRequest) (*auth_v2.RevokeRoleResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.RevokeRole(ctx, service.RevokeRoleRequest{
+		Tenant:   tenantFromRequest(ctx, req.GetTenant()),
+		UserUUID: req.GetUserUuid(),
+		Role:     req.GetRole(),
+		Resource: req.GetResource(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RevokeRoleResponse{
+		Revoked: resp.Revoked,
+	}, nil
+}
+
+// ListUserPermissions возвращает роли и разрешения пользователя. Требует токен администратора
+func (h *AuthV2Handler) ListUserPermissions(
+	ctx context.Context,
+	req *auth_v2.ListUserPermissionsRequest,
+) (*auth_v2.ListUserPermissionsResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.ListUserPermissions(ctx, service.ListUserPermissionsRequest{
+		Tenant:   tenantFromRequest(ctx, req.GetTenant()),
+		UserUUID: req.GetUserUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	grants := make([]*auth_v2.RoleGrant, 0, len(resp.Grants))
+	for _, grant := range resp.Grants {
+		grants = append(grants, &auth_v2.RoleGrant{
+			Role:        grant.Role,
+			Resource:    grant.Resource,
+			Permissions: grant.Permissions,
+		})
+	}
+
+	return &auth_v2.ListUserPermissionsResponse{
+		Roles:       resp.Roles,
+		Permissions: resp.Permissions,
+		Grants:      grants,
+	}, nil
+}
+
+// CheckPermission проверяет разрешение пользователя на ресурс
+func (h *AuthV2Handler) CheckPermission(
+	ctx context.Context,
+	req *auth_v2.CheckPermissionRequest,
+) (*auth_v2.CheckPermissionResponse, error) {
+	resp, err := h.authService.CheckPermission(ctx, service.CheckPermissionRequest{
+		SessionUUID: req.GetSessionUuid(),
+		AccessToken: req.GetAccessToken(),
+		APIKey:      req.GetApiKey(),
+		PermissionCheck: service.PermissionCheck{
+			Permission: req.GetPermission(),
+			Resource:   req.GetResource(),
+		},
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CheckPermissionResponse{
+		Allowed: resp.Allowed,
+		Reason:  resp.Reason,
+	}, nil
+}
+
+// CheckPermissions проверяет несколько разрешений пользователя за один вызов
+func (h *AuthV2Handler) CheckPermissions(
+	ctx context.Context,
+	req *auth_v2.CheckPermissionsRequest,
+) (*auth_v2.CheckPermissionsResponse, error) {
+	checks := make([]service.PermissionCheck, 0, len(req.GetChecks()))
+	for _, check := range req.GetChecks() {
+		checks = append(checks, service.PermissionCheck{
+			Permission: check.GetPermission(),
+			Resource:   check.GetResource(),
+		})
+	}
+
+	resp, err := h.authService.CheckPermissions(ctx, service.CheckPermissionsRequest{
+		SessionUUID: req.GetSessionUuid(),
+		AccessToken: req.GetAccessToken(),
+		APIKey:      req.GetApiKey(),
+		Checks:      checks,
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	decisions := make([]*auth_v2.PermissionDecision, 0, len(resp.Decisions))
+	for _, decision := range resp.Decisions {
+		decisions = append(decisions, &auth_v2.PermissionDecision{
+			Permission: decision.Permission,
+			Resource:   decision.Resource,
+			Allowed:    decision.Allowed,
+			Reason:     decision.Reason,
+		})
+	}
+
+	return &auth_v2.CheckPermissionsResponse{
+		Decisions: decisions,
+	}, nil
+}
+
+// CreateOrganization создает организацию. Требует токен администратора
+func (h *AuthV2Handler) CreateOrganization(
+	ctx context.Context,
+	req *auth_v2.CreateOrganizationRequest,
+) (*auth_v2.CreateOrganizationResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.CreateOrganization(ctx, service.CreateOrganizationRequest{
+		Slug: req.GetSlug(),
+		Name: req.GetName(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CreateOrganizationResponse{
+		Organization: &auth_v2.Organization{
+			Id:        resp.ID.String(),
+			Slug:      resp.Slug,
+			Name:      resp.Name,
+			CreatedAt: timestamppb.New(resp.CreatedAt),
+		},
+	}, nil
+}
+
+// CreateInvitation приглашает пользователя в активную организацию сессии
+func (h *AuthV2Handler) CreateInvitation(
+	ctx context.Context,
+	req *auth_v2.CreateInvitationRequest,
+) (*auth_v2.CreateInvitationResponse, error) {
+	resp, err := h.authService.CreateInvitation(ctx, service.CreateInvitationRequest{
+		SessionUUID: req.GetSessionUuid(),
+		Email:       req.GetEmail(),
+		Role:        req.GetRole(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CreateInvitationResponse{
+		InvitationId: resp.InvitationID.String(),
+		Email:        resp.Email,
+		Role:         resp.Role,
+		ExpiresAt:    timestamppb.New(resp.ExpiresAt),
+	}, nil
+}
+
+// AcceptInvitation принимает приглашение в организацию
+func (h *AuthV2Handler) AcceptInvitation(
+	ctx context.Context,
+	req *auth_v2.AcceptInvitationRequest,
+) (*auth_v2.AcceptInvitationResponse, error) {
+	resp, err := h.authService.AcceptInvitation(ctx, service.AcceptInvitationRequest{
+		InvitationToken: req.GetInvitationToken(),
+		SessionUUID:     req.GetSessionUuid(),
+		Username:        req.GetRegistration().GetUsername(),
+		Password:        req.GetRegistration().GetPassword(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.AcceptInvitationResponse{
+		Organization: organizationToV2(resp.Organization),
+		UserUuid:     resp.UserUUID.String(),
+		Role:         resp.Role,
+		Registered:   resp.Registered,
+	}, nil
+}
+
+// ListMembers возвращает участников активной организации сессии
+func (h *AuthV2Handler) ListMembers(ctx context.Context, req *auth_v2.ListMembersRequest) (*auth_v2.ListMembersResponse, error) {
+	resp, err := h.authService.ListMembers(ctx, service.ListMembersRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	members := make([]*auth_v2.Member, 0, len(resp.Members))
+	for _, member := range resp.Members {
+		members = append(members, &auth_v2.Member{
+			UserUuid: member.UserUUID.String(),
+			Email:    member.Email,
+			Username: member.Username,
+			TenantId: member.TenantID.String(),
+			Role:     member.Role,
+			JoinedAt: timestamppb.New(member.JoinedAt),
+		})
+	}
+
+	return &auth_v2.ListMembersResponse{
+		Members: members,
+	}, nil
+}
+
+// RemoveMember исключает участника из активной организации сессии
+func (h *AuthV2Handler) RemoveMember(ctx context.Context, req *auth_v2.RemoveMemberRequest) (*auth_v2.RemoveMemberResponse, error) {
+	resp, err := h.authService.RemoveMember(ctx, service.RemoveMemberRequest{
+		SessionUUID: req.GetSessionUuid(),
+		UserUUID:    req.GetUserUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RemoveMemberResponse{
+		Removed: resp.Removed,
+	}, nil
+}
+
+// SwitchOrganization меняет активную организацию сессии
+func (h *AuthV2Handler) SwitchOrganization(
+	ctx context.Context,
+	req *auth_v2.SwitchOrganizationRequest,
+) (*auth_v2.SwitchOrganizationResponse, error) {
+	resp, err := h.authService.SwitchOrganization(ctx, service.SwitchOrganizationRequest{
+		SessionUUID:  req.GetSessionUuid(),
+		Organization: req.GetOrganization(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.SwitchOrganizationResponse{
+		Organization: organizationToV2(resp.Organization),
+		Role:         resp.Role,
+	}, nil
+}
+
+// CreateAPIKey создает API ключ пользователя сессии или его сервисного аккаунта
+func (h *AuthV2Handler) CreateAPIKey(ctx context.Context, req *auth_v2.CreateAPIKeyRequest) (*auth_v2.CreateAPIKeyResponse, error) {
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t := req.GetExpiresAt().AsTime()
+		expiresAt = &t
+	}
+
+	resp, err := h.authService.CreateAPIKey(ctx, service.CreateAPIKeyRequest{
+		SessionUUID:      req.GetSessionUuid(),
+		ServiceAccountID: req.GetServiceAccountId(),
+		Name:             req.GetName(),
+		Scopes:           req.GetScopes(),
+		ExpiresAt:        expiresAt,
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CreateAPIKeyResponse{
+		Key:    resp.Key,
+		ApiKey: apiKeyToV2(resp.APIKey),
+	}, nil
+}
+
+// ListAPIKeys возвращает API ключи пользователя сессии или его сервисного аккаунта
+func (h *AuthV2Handler) ListAPIKeys(ctx context.Context, req *auth_v2.ListAPIKeysRequest) (*auth_v2.ListAPIKeysResponse, error) {
+	resp, err := h.authService.ListAPIKeys(ctx, service.ListAPIKeysRequest{
+		SessionUUID:      req.GetSessionUuid(),
+		ServiceAccountID: req.GetServiceAccountId(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	apiKeys := make([]*auth_v2.APIKey, 0, len(resp.APIKeys))
+	for _, apiKey := range resp.APIKeys {
+		apiKeys = append(apiKeys, apiKeyToV2(apiKey))
+	}
+
+	return &auth_v2.ListAPIKeysResponse{
+		ApiKeys: apiKeys,
+	}, nil
+}
+
+// RevokeAPIKey отзывает API ключ пользователя сессии или его сервисного аккаунта
+func (h *AuthV2Handler) RevokeAPIKey(ctx context.Context, req *auth_v2.RevokeAPIKeyRequest) (*auth_v2.RevokeAPIKeyResponse, error) {
+	resp, err := h.authService.RevokeAPIKey(ctx, service.RevokeAPIKeyRequest{
+		SessionUUID:      req.GetSessionUuid(),
+		ServiceAccountID: req.GetServiceAccountId(),
+		KeyID:            req.GetKeyId(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RevokeAPIKeyResponse{
+		Revoked: resp.Revoked,
+	}, nil
+}
+
+// AuthenticateAPIKey проверяет API ключ и возвращает его владельца
+func (h *AuthV2Handler) AuthenticateAPIKey(
+	ctx context.Context,
+	req *auth_v2.AuthenticateAPIKeyRequest,
+) (*auth_v2.AuthenticateAPIKeyResponse, error) {
+	resp, err := h.authService.AuthenticateAPIKey(ctx, service.AuthenticateAPIKeyRequest{
+		APIKey: req.GetApiKey(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	authenticated := &auth_v2.AuthenticateAPIKeyResponse{
+		PrincipalType: principalTypeToV2(resp.PrincipalType),
+		ApiKey:        apiKeyToV2(resp.APIKey),
+	}
+	if resp.ServiceAccount != nil {
+		authenticated.ServiceAccount = serviceAccountToV2(resp.ServiceAccount)
+	}
+	if resp.User != nil {
+		authenticated.User = &auth_v2.User{
+			UserUuid:        resp.User.UUID.String(),
+			TenantId:        resp.User.TenantID.String(),
+			Email:           resp.User.Email,
+			Username:        resp.User.Username,
+			CreatedAt:       timestamppb.New(resp.User.CreatedAt),
+			UpdatedAt:       timestamppb.New(resp.User.UpdatedAt),
+			EmailVerifiedAt: optionalTimestamp(resp.User.EmailVerifiedAt),
+		}
+	}
+
+	return authenticated, nil
+}
+
+// CreateServiceAccount создает сервисный аккаунт в активной организации сессии
+func (h *AuthV2Handler) CreateServiceAccount(
+	ctx context.Context,
+	req *auth_v2.CreateServiceAccountRequest,
+) (*auth_v2.CreateServiceAccountResponse, error) {
+	resp, err := h.authService.CreateServiceAccount(ctx, service.CreateServiceAccountRequest{
+		SessionUUID:       req.GetSessionUuid(),
+		Name:              req.GetName(),
+		Description:       req.GetDescription(),
+		OrganizationOwned: req.GetOrganizationOwned(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CreateServiceAccountResponse{
+		ServiceAccount: serviceAccountToV2(resp.ServiceAccount),
+	}, nil
+}
+
+// ListServiceAccounts возвращает сервисные аккаунты, которыми может управлять пользователь сессии
+func (h *AuthV2Handler) ListServiceAccounts(
+	ctx context.Context,
+	req *auth_v2.ListServiceAccountsRequest,
+) (*auth_v2.ListServiceAccountsResponse, error) {
+	resp, err := h.authService.ListServiceAccounts(ctx, service.ListServiceAccountsRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	serviceAccounts := make([]*auth_v2.ServiceAccount, 0, len(resp.ServiceAccounts))
+	for _, serviceAccount := range resp.ServiceAccounts {
+		serviceAccounts = append(serviceAccounts, serviceAccountToV2(serviceAccount))
+	}
+
+	return &auth_v2.ListServiceAccountsResponse{
+		ServiceAccounts: serviceAccounts,
+	}, nil
+}
+
+// DeleteServiceAccount удаляет сервисный аккаунт вместе с его API ключами
+func (h *AuthV2Handler) DeleteServiceAccount(
+	ctx context.Context,
+	req *auth_v2.DeleteServiceAccountRequest,
+) (*auth_v2.DeleteServiceAccountResponse, error) {
+	resp, err := h.authService.DeleteServiceAccount(ctx, service.DeleteServiceAccountRequest{
+		SessionUUID:      req.GetSessionUuid(),
+		ServiceAccountID: req.GetServiceAccountId(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.DeleteServiceAccountResponse{
+		Deleted: resp.Deleted,
+	}, nil
+}
+
+// CreateOAuthClient регистрирует OAuth клиента. Требует токен администратора
+func (h *AuthV2Handler) CreateOAuthClient(
+	ctx context.Context,
+	req *auth_v2.CreateOAuthClientRequest,
+) (*auth_v2.CreateOAuthClientResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.CreateOAuthClient(ctx, service.CreateOAuthClientRequest{
+		Tenant:           tenantFromRequest(ctx, req.GetTenant()),
+		Name:             req.GetName(),
+		RedirectURIs:     req.GetRedirectUris(),
+		GrantTypes:       req.GetGrantTypes(),
+		Scopes:           req.GetScopes(),
+		Confidential:     req.GetConfidential(),
+		FirstParty:       req.GetFirstParty(),
+		ServiceAccountID: req.GetServiceAccountId(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.CreateOAuthClientResponse{
+		Client:       oauthClientToV2(resp.Client),
+		ClientSecret: resp.ClientSecret,
+	}, nil
+}
+
+// DeleteOAuthClient удаляет OAuth клиента. Требует токен администратора
+func (h *AuthV2Handler) DeleteOAuthClient(
+	ctx context.Context,
+	req *auth_v2.DeleteOAuthClientRequest,
+) (*auth_v2.DeleteOAuthClientResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	resp, err := h.authService.DeleteOAuthClient(ctx, service.DeleteOAuthClientRequest{
+		Tenant:   tenantFromRequest(ctx, req.GetTenant()),
+		ClientID: req.GetClientId(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.DeleteOAuthClientResponse{
+		Deleted: resp.Deleted,
+	}, nil
+}
+
+// GrantOAuthConsent сохраняет согласие пользователя сессии на доступ OAuth клиента
+func (h *AuthV2Handler) GrantOAuthConsent(
+	ctx context.Context,
+	req *auth_v2.GrantOAuthConsentRequest,
+) (*auth_v2.GrantOAuthConsentResponse, error) {
+	resp, err := h.authService.GrantOAuthConsent(ctx, service.GrantOAuthConsentRequest{
+		SessionUUID: req.GetSessionUuid(),
+		ClientID:    req.GetClientId(),
+		Scopes:      req.GetScopes(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.GrantOAuthConsentResponse{
+		Consent: oauthConsentToV2(resp.Consent),
+	}, nil
+}
+
+// ListOAuthConsents возвращает согласия пользователя сессии
+func (h *AuthV2Handler) ListOAuthConsents(
+	ctx context.Context,
+	req *auth_v2.ListOAuthConsentsRequest,
+) (*auth_v2.ListOAuthConsentsResponse, error) {
+	resp, err := h.authService.ListOAuthConsents(ctx, service.ListOAuthConsentsRequest{
+		SessionUUID: req.GetSessionUuid(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	consents := make([]*auth_v2.OAuthConsent, 0, len(resp.Consents))
+	for _, consent := range resp.Consents {
+		consents = append(consents, oauthConsentToV2(consent))
+	}
+
+	return &auth_v2.ListOAuthConsentsResponse{
+		Consents: consents,
+	}, nil
+}
+
+// RevokeOAuthConsent отзывает согласие пользователя сессии
+func (h *AuthV2Handler) RevokeOAuthConsent(
+	ctx context.Context,
+	req *auth_v2.RevokeOAuthConsentRequest,
+) (*auth_v2.RevokeOAuthConsentResponse, error) {
+	resp, err := h.authService.RevokeOAuthConsent(ctx, service.RevokeOAuthConsentRequest{
+		SessionUUID: req.GetSessionUuid(),
+		ClientID:    req.GetClientId(),
+	})
+	if err != nil {
+		return nil, apperrors.FromError(err).ToGRPCError()
+	}
+
+	return &auth_v2.RevokeOAuthConsentResponse{
+		Revoked: resp.Revoked,
+	}, nil
+}
+
+// serviceAccountToV2 конвертирует сервисный аккаунт в сообщение auth.v2
+func serviceAccountToV2(serviceAccount *models.ServiceAccount) *auth_v2.ServiceAccount {
+	converted := &auth_v2.ServiceAccount{
+		Id:          serviceAccount.ID.String(),
+		TenantId:    serviceAccount.TenantID.String(),
+		Name:        serviceAccount.Name,
+		Description: serviceAccount.Description,
+		CreatedAt:   timestamppb.New(serviceAccount.CreatedAt),
+	}
+	if serviceAccount.OwnerUserUUID != nil {
+		converted.OwnerUserUuid = serviceAccount.OwnerUserUUID.String()
+	}
+
+	return converted
+}
+
+// principalTypeToV2 конвертирует тип субъекта в перечисление auth.v2
+func principalTypeToV2(principalType models.PrincipalType) auth_v2.PrincipalType {
+	switch principalType {
+	case models.PrincipalTypeUser:
+		return auth_v2.PrincipalType_PRINCIPAL_TYPE_USER
+	case models.PrincipalTypeServiceAccount:
+		return auth_v2.PrincipalType_PRINCIPAL_TYPE_SERVICE_ACCOUNT
+	default:
+		return auth_v2.PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+	}
+}
+
+// apiKeyToV2 конвертирует API ключ в сообщение auth.v2
+func apiKeyToV2(apiKey *models.APIKey) *auth_v2.APIKey {
+	return &auth_v2.APIKey{
+		Id:         apiKey.ID.String(),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		CreatedAt:  timestamppb.New(apiKey.CreatedAt),
+		ExpiresAt:  optionalTimestamp(apiKey.ExpiresAt),
+		LastUsedAt: optionalTimestamp(apiKey.LastUsedAt),
+	}
+}
+
+// organizationToV2 конвертирует организацию в сообщение auth.v2
+func organizationToV2(organization models.Organization) *auth_v2.Organization {
+	return &auth_v2.Organization{
+		Id:        organization.ID.String(),
+		Slug:      organization.Slug,
+		Name:      organization.Name,
+		CreatedAt: timestamppb.New(organization.CreatedAt),
+	}
+}
+
+// tokenPairToV2 конвертирует пару токенов в сообщение auth.v2
+func tokenPairToV2(tokens service.TokenPair) *auth_v2.TokenPair {
+	return &auth_v2.TokenPair{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(tokens.AccessTokenExpiresAt),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(tokens.RefreshTokenExpiresAt),
+	}
+}
+
+// sessionToV2 конвертирует информацию о сессии в сообщение auth.v2
+func sessionToV2(session service.SessionInfo) *auth_v2.Session {
+	return &auth_v2.Session{
+		SessionUuid: session.SessionUUID,
+		Ip:          session.Client.IP,
+		UserAgent:   session.Client.UserAgent,
+		ClientName:  session.Client.ClientName,
+		CreatedAt:   timestamppb.New(session.CreatedAt),
+		LastSeenAt:  timestamppb.New(session.LastSeenAt),
+		Current:     session.Current,
+		ExpiresAt:   timestamppb.New(session.ExpiresAt),
+	}
+}
+
+// optionalTimestamp конвертирует необязательное время, nil остается nil
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// oauthClientToV2 конвертирует OAuth клиента в сообщение auth.v2
+func oauthClientToV2(client *models.OAuthClient) *auth_v2.OAuthClient {
+	converted := &auth_v2.OAuthClient{
+		Id:           client.ID.String(),
+		TenantId:     client.TenantID.String(),
+		Name:         client.Name,
+		RedirectUris: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		Confidential: client.Confidential(),
+		FirstParty:   client.FirstParty,
+		CreatedAt:    timestamppb.New(client.CreatedAt),
+	}
+	if client.ServiceAccountID != nil {
+		converted.ServiceAccountId = client.ServiceAccountID.String()
+	}
+
+	return converted
+}
+
+// oauthConsentToV2 конвертирует согласие пользователя в сообщение auth.v2
+func oauthConsentToV2(consent *models.OAuthConsent) *auth_v2.OAuthConsent {
+	return &auth_v2.OAuthConsent{
+		ClientId:  consent.ClientID.String(),
+		Scopes:    consent.Scopes,
+		CreatedAt: timestamppb.New(consent.CreatedAt),
+		UpdatedAt: timestamppb.New(consent.UpdatedAt),
+	}
+}
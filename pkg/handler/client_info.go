@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/olezhek28/auth-service/pkg/service"
+)
+
+// Metadata ключи, из которых берется информация об устройстве клиента
+const (
+	forwardedForHeader = "x-forwarded-for"
+	userAgentHeader    = "user-agent"
+	clientNameHeader   = "x-client-name"
+)
+
+// clientInfoFromContext собирает информацию об устройстве клиента из gRPC peer и metadata.
+// trustedProxies адреса прокси, которым разрешено передавать адрес клиента в X-Forwarded-For
+func clientInfoFromContext(ctx context.Context, trustedProxies []netip.Prefix) service.ClientInfo {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return service.ClientInfo{
+		IP:         clientIP(ctx, md, trustedProxies),
+		UserAgent:  firstMetadataValue(md, userAgentHeader),
+		ClientName: firstMetadataValue(md, clientNameHeader),
+	}
+}
+
+// clientIP возвращает адрес клиента. X-Forwarded-For учитывается, только если соединение пришло
+// от доверенного прокси: иначе клиент подставил бы любой адрес и обошел ограничение попыток входа по IP.
+// Цепочка проходится справа налево, адресом клиента считается первый адрес не из доверенных прокси
+func clientIP(ctx context.Context, md metadata.MD, trustedProxies []netip.Prefix) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return forwardedClientIP(p.Addr.String(), md.Get(forwardedForHeader), trustedProxies)
+}
+
+// httpClientIP возвращает адрес клиента HTTP запроса по тем же правилам, что и clientIP
+func httpClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	return forwardedClientIP(r.RemoteAddr, r.Header.Values(forwardedForHeader), trustedProxies)
+}
+
+// forwardedClientIP возвращает адрес клиента по адресу соединения и значениям X-Forwarded-For
+func forwardedClientIP(remoteAddr string, forwardedFor []string, trustedProxies []netip.Prefix) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !isTrustedProxy(addr, trustedProxies) {
+		return ip
+	}
+
+	hops := strings.Split(strings.Join(forwardedFor, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// Левее некорректного адреса цепочке доверять нельзя
+			break
+		}
+		addr = hop.Unmap()
+		if !isTrustedProxy(addr, trustedProxies) {
+			break
+		}
+	}
+
+	return addr.String()
+}
+
+// isTrustedProxy проверяет, входит ли адрес в список доверенных прокси
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstMetadataValue возвращает первое значение metadata ключа
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
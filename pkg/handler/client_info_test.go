@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+	}
+
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{
+			name: "без X-Forwarded-For используется адрес соединения",
+			peer: "203.0.113.7:5000",
+			want: "203.0.113.7",
+		},
+		{
+			name:      "X-Forwarded-For от недоверенного адреса игнорируется",
+			peer:      "203.0.113.7:5000",
+			forwarded: []string{"198.51.100.1"},
+			want:      "203.0.113.7",
+		},
+		{
+			name:      "от доверенного прокси берется правый адрес",
+			peer:      "10.0.0.5:5000",
+			forwarded: []string{"198.51.100.1"},
+			want:      "198.51.100.1",
+		},
+		{
+			name:      "подставленный клиентом левый адрес не учитывается",
+			peer:      "10.0.0.5:5000",
+			forwarded: []string{"1.2.3.4, 198.51.100.1"},
+			want:      "198.51.100.1",
+		},
+		{
+			name:      "доверенные прокси в цепочке пропускаются",
+			peer:      "10.0.0.5:5000",
+			forwarded: []string{"1.2.3.4, 198.51.100.1, 192.168.1.1", "10.1.2.3"},
+			want:      "198.51.100.1",
+		},
+		{
+			name:      "левее некорректного адреса цепочка не читается",
+			peer:      "10.0.0.5:5000",
+			forwarded: []string{"198.51.100.1, garbage, 10.1.2.3"},
+			want:      "10.1.2.3",
+		},
+		{
+			name:      "IPv6 адрес соединения",
+			peer:      "[2001:db8::1]:5000",
+			forwarded: []string{"198.51.100.1"},
+			want:      "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			if err != nil {
+				t.Fatalf("failed to resolve peer address: %v", err)
+			}
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			md := metadata.MD{}
+			for _, value := range tt.forwarded {
+				md.Append(forwardedForHeader, value)
+			}
+
+			if got := clientIP(ctx, md, trustedProxies); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/olezhek28/auth-service/pkg/keys"
+	"github.com/olezhek28/auth-service/pkg/logger"
+)
+
+// jwksCacheControl позволяет проверяющим кешировать ключи, не пропуская ротацию
+const jwksCacheControl = "public, max-age=300"
+
+// JWKSProvider источник опубликованных публичных ключей
+type JWKSProvider interface {
+	JWKS() keys.JSONWebKeySet
+}
+
+// JWKSHandler HTTP обработчик /.well-known/jwks.json
+type JWKSHandler struct {
+	keys   JWKSProvider
+	logger logger.Logger
+}
+
+// NewJWKSHandler создает новый HTTP обработчик JWKS
+func NewJWKSHandler(keys JWKSProvider, logger logger.Logger) *JWKSHandler {
+	return &JWKSHandler{
+		keys:   keys,
+		logger: logger,
+	}
+}
+
+// ServeHTTP отдает набор публичных ключей в формате JWKS
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := json.Marshal(h.keys.JWKS())
+	if err != nil {
+		h.logger.Error("failed to marshal JWKS", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", jwksCacheControl)
+	if _, err := w.Write(body); err != nil {
+		h.logger.Warn("failed to write JWKS response", "error", err)
+	}
+}
@@ -0,0 +1,487 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/olezhek28/auth-service/pkg/config"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/logger"
+	"github.com/olezhek28/auth-service/pkg/service"
+)
+
+// maxOAuthFormSize ограничивает размер тела запросов к /oauth2/token, /oauth2/revoke и /oauth2/login
+const maxOAuthFormSize = 64 << 10
+
+// oauthAuthorizePath путь /oauth2/authorize, на который /oauth2/login возвращает пользователя
+const oauthAuthorizePath = "/oauth2/authorize"
+
+// Коды ошибок OAuth 2.0 из RFC 6749 и RFC 7009
+const (
+	oauthErrorInvalidRequest          = "invalid_request"
+	oauthErrorInvalidClient           = "invalid_client"
+	oauthErrorInvalidGrant            = "invalid_grant"
+	oauthErrorUnauthorizedClient      = "unauthorized_client"
+	oauthErrorInvalidScope            = "invalid_scope"
+	oauthErrorUnsupportedGrantType    = "unsupported_grant_type"
+	oauthErrorUnsupportedResponseType = "unsupported_response_type"
+	oauthErrorUnsupportedTokenType    = "unsupported_token_type"
+	oauthErrorAccessDenied            = "access_denied"
+	oauthErrorServerError             = "server_error"
+	oauthErrorTemporarilyUnavailable  = "temporarily_unavailable"
+)
+
+// oauthTokenResponse тело успешного ответа /oauth2/token
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// oauthLoginResponse тело ответа /oauth2/login, если для входа нужен второй фактор
+type oauthLoginResponse struct {
+	MFAChallengeID string `json:"mfa_challenge_id"`
+	ExpiresIn      int64  `json:"expires_in"`
+}
+
+// oauthErrorResponse тело ответа с ошибкой OAuth 2.0
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OAuthHandler HTTP обработчик эндпоинтов сервера авторизации OAuth 2.0
+type OAuthHandler struct {
+	authService service.AuthService
+	cfg         config.OAuthConfig
+	// trustedProxies адреса прокси, которым разрешено передавать адрес клиента в X-Forwarded-For
+	trustedProxies []netip.Prefix
+	logger         logger.Logger
+}
+
+// NewOAuthHandler создает новый HTTP обработчик OAuth 2.0
+func NewOAuthHandler(
+	authService service.AuthService,
+	cfg config.OAuthConfig,
+	trustedProxies []netip.Prefix,
+	logger logger.Logger,
+) *OAuthHandler {
+	return &OAuthHandler{
+		authService:    authService,
+		cfg:            cfg,
+		trustedProxies: trustedProxies,
+		logger:         logger,
+	}
+}
+
+// Authorize обрабатывает GET /oauth2/authorize. Пользователь определяется по cookie сессии,
+// которую устанавливает /oauth2/login. Без сессии он отправляется на страницу входа, без согласия -
+// на страницу согласия, откуда возвращается сюда же по return_to
+func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state := query.Get("state")
+
+	redirect, err := h.authService.ResolveOAuthRedirect(r.Context(), service.ResolveOAuthRedirectRequest{
+		ClientID:    query.Get("client_id"),
+		RedirectURI: query.Get("redirect_uri"),
+	})
+	if err != nil {
+		// Клиенту, которого не удалось проверить, пользователя не возвращаем: ошибка показывается на месте
+		code, status, description := oauthErrorFromError(err)
+		if status == http.StatusInternalServerError {
+			h.logger.Error("failed to resolve oauth redirect", "error", err)
+		}
+		http.Error(w, fmt.Sprintf("%s: %s", code, description), status)
+		return
+	}
+
+	var sessionUUID string
+	if cookie, err := r.Cookie(h.cfg.SessionCookie); err == nil {
+		sessionUUID = cookie.Value
+	}
+
+	resp, err := h.authService.Authorize(r.Context(), service.AuthorizeRequest{
+		SessionUUID:         sessionUUID,
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		ResponseType:        query.Get("response_type"),
+		Scope:               query.Get("scope"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+		Nonce:               query.Get("nonce"),
+	})
+	switch {
+	case err == nil:
+		h.redirect(w, r, urlWithParams(resp.RedirectURI, url.Values{
+			"code":  {resp.Code},
+			"state": {state},
+		}))
+	case errors.Is(err, apperrors.ErrSessionNotFound) && h.cfg.LoginURL != "":
+		h.redirect(w, r, urlWithParams(h.cfg.LoginURL, url.Values{
+			"return_to": {h.returnTo(r)},
+		}))
+	case errors.Is(err, apperrors.ErrConsentRequired) && h.cfg.ConsentURL != "":
+		h.redirect(w, r, urlWithParams(h.cfg.ConsentURL, url.Values{
+			"client_id": {query.Get("client_id")},
+			"scope":     {query.Get("scope")},
+			"return_to": {h.returnTo(r)},
+		}))
+	case errors.Is(err, apperrors.ErrSessionNotFound), errors.Is(err, apperrors.ErrConsentRequired):
+		h.redirect(w, r, urlWithParams(redirect.RedirectURI, url.Values{
+			"error":             {oauthErrorAccessDenied},
+			"error_description": {apperrors.FromError(err).Message},
+			"state":             {state},
+		}))
+	default:
+		code, status, description := oauthErrorFromError(err)
+		if status == http.StatusInternalServerError {
+			h.logger.Error("failed to authorize oauth client", "error", err)
+		}
+		h.redirect(w, r, urlWithParams(redirect.RedirectURI, url.Values{
+			"error":             {code},
+			"error_description": {description},
+			"state":             {state},
+		}))
+	}
+}
+
+// Login обрабатывает POST /oauth2/login со страницы входа. Форма содержит tenant, email и password,
+// а если у пользователя подключен TOTP, второй запрос - mfa_challenge_id из первого ответа и code
+// или recovery_code. После входа устанавливает cookie сессии и возвращает пользователя по return_to.
+// Cookie доступна только серверу и только по HTTPS и не отправляется с запросами других сайтов,
+// кроме переходов по ссылкам, которыми и приходит /oauth2/authorize
+func (h *OAuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxOAuthFormSize)
+	if err := r.ParseForm(); err != nil {
+		h.writeLoginError(w, r, fmt.Errorf("%w: malformed form body", apperrors.ErrInvalidInput))
+		return
+	}
+
+	// Возвращать пользователя можно только на свой /oauth2/authorize: иначе страница входа
+	// стала бы открытым редиректом на любой сайт
+	returnTo := r.PostForm.Get("return_to")
+	if returnTo != "" && !h.isAuthorizeURL(returnTo) {
+		h.writeLoginError(w, r, fmt.Errorf("%w: return_to must point to %s", apperrors.ErrInvalidInput, oauthAuthorizePath))
+		return
+	}
+
+	client := service.ClientInfo{
+		IP:        httpClientIP(r, h.trustedProxies),
+		UserAgent: r.UserAgent(),
+	}
+
+	var (
+		resp *service.LoginResponse
+		err  error
+	)
+	if challengeID := r.PostForm.Get("mfa_challenge_id"); challengeID != "" {
+		resp, err = h.authService.CompleteMFA(r.Context(), service.CompleteMFARequest{
+			ChallengeID:  challengeID,
+			Code:         r.PostForm.Get("code"),
+			RecoveryCode: r.PostForm.Get("recovery_code"),
+			Client:       client,
+		})
+	} else {
+		resp, err = h.authService.Login(r.Context(), service.LoginRequest{
+			Tenant:   r.PostForm.Get("tenant"),
+			Email:    r.PostForm.Get("email"),
+			Password: r.PostForm.Get("password"),
+			Client:   client,
+		})
+	}
+	if err != nil {
+		h.writeLoginError(w, r, err)
+		return
+	}
+
+	if resp.MFAChallengeID != "" {
+		writeNoStoreJSON(w, h.logger, http.StatusOK, oauthLoginResponse{
+			MFAChallengeID: resp.MFAChallengeID,
+			ExpiresIn:      int64(time.Until(resp.ExpiresAt).Round(time.Second) / time.Second),
+		})
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.cfg.SessionCookie,
+		Value:    resp.SessionUUID,
+		Path:     "/",
+		Expires:  resp.ExpiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	w.Header().Set("Cache-Control", "no-store")
+	if returnTo == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, returnTo, http.StatusSeeOther)
+}
+
+// Token обрабатывает POST /oauth2/token
+func (h *OAuthHandler) Token(w http.ResponseWriter, r *http.Request) {
+	client, err := h.parseClientRequest(w, r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resp, err := h.authService.ExchangeOAuthToken(r.Context(), service.OAuthTokenRequest{
+		Client:       client,
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	writeNoStoreJSON(w, h.logger, http.StatusOK, oauthTokenResponse{
+		AccessToken:  resp.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(resp.AccessTokenExpiresAt).Round(time.Second) / time.Second),
+		RefreshToken: resp.RefreshToken,
+		IDToken:      resp.IDToken,
+		Scope:        strings.Join(resp.Scopes, " "),
+	})
+}
+
+// Revoke обрабатывает POST /oauth2/revoke по RFC 7009. Неизвестный токен тоже дает 200
+func (h *OAuthHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	client, err := h.parseClientRequest(w, r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	_, err = h.authService.RevokeOAuthToken(r.Context(), service.RevokeOAuthTokenRequest{
+		Client:        client,
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+	})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseClientRequest разбирает form тело запроса и учетные данные клиента.
+// Клиент аутентифицируется либо через HTTP Basic, либо параметрами client_id и client_secret
+func (h *OAuthHandler) parseClientRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+) (service.OAuthClientCredentials, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxOAuthFormSize)
+	if err := r.ParseForm(); err != nil {
+		return service.OAuthClientCredentials{}, fmt.Errorf("%w: malformed form body", apperrors.ErrInvalidInput)
+	}
+
+	formClientID := r.PostForm.Get("client_id")
+	formClientSecret := r.PostForm.Get("client_secret")
+
+	basicClientID, basicClientSecret, ok := r.BasicAuth()
+	if !ok {
+		return service.OAuthClientCredentials{
+			ClientID:     formClientID,
+			ClientSecret: formClientSecret,
+		}, nil
+	}
+
+	// RFC 6749 2.3.1: в HTTP Basic client_id и секрет закодированы как application/x-www-form-urlencoded
+	clientID, err := url.QueryUnescape(basicClientID)
+	if err != nil {
+		return service.OAuthClientCredentials{}, apperrors.ErrInvalidClient
+	}
+	clientSecret, err := url.QueryUnescape(basicClientSecret)
+	if err != nil {
+		return service.OAuthClientCredentials{}, apperrors.ErrInvalidClient
+	}
+	if formClientSecret != "" || (formClientID != "" && formClientID != clientID) {
+		return service.OAuthClientCredentials{}, fmt.Errorf(
+			"%w: client must use only one authentication method", apperrors.ErrInvalidInput)
+	}
+
+	return service.OAuthClientCredentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// returnTo возвращает адрес текущего запроса /oauth2/authorize для возврата после входа или согласия
+func (h *OAuthHandler) returnTo(r *http.Request) string {
+	return h.cfg.PublicURL + r.URL.RequestURI()
+}
+
+// isAuthorizeURL проверяет, что адрес ведет на /oauth2/authorize этого сервера.
+// Принимается адрес от OAUTH_PUBLIC_URL, как его строит returnTo, или путь без схемы и хоста.
+// Адреса вида //host/path и /\host разбираются с хостом или другим путем и отклоняются
+func (h *OAuthHandler) isAuthorizeURL(rawURL string) bool {
+	if h.cfg.PublicURL != "" {
+		if rest, ok := strings.CutPrefix(rawURL, h.cfg.PublicURL); ok && strings.HasPrefix(rest, "/") {
+			rawURL = rest
+		}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "" && u.Host == "" && u.Path == oauthAuthorizePath
+}
+
+// writeLoginError отвечает ошибкой входа в JSON. Время до повтора передается в Retry-After
+func (h *OAuthHandler) writeLoginError(w http.ResponseWriter, r *http.Request, err error) {
+	code, status, description := loginErrorFromError(err)
+	if status == http.StatusInternalServerError {
+		h.logger.Error("oauth login failed", "error", err, "path", r.URL.Path)
+	}
+
+	var retryErr *apperrors.RetryAfterError
+	if errors.As(err, &retryErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+	}
+
+	writeNoStoreJSON(w, h.logger, status, oauthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
+// redirect перенаправляет браузер пользователя, запрещая кеширование ответа с кодом авторизации
+func (h *OAuthHandler) redirect(w http.ResponseWriter, r *http.Request, location string) {
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, location, http.StatusFound)
+}
+
+// writeError отвечает ошибкой OAuth 2.0 в JSON
+func (h *OAuthHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	code, status, description := oauthErrorFromError(err)
+	if status == http.StatusInternalServerError {
+		h.logger.Error("oauth request failed", "error", err, "path", r.URL.Path)
+	}
+
+	// RFC 6749 5.2: если клиент аутентифицировался через HTTP Basic, 401 сопровождается WWW-Authenticate
+	if _, _, ok := r.BasicAuth(); ok && status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+	}
+
+	writeNoStoreJSON(w, h.logger, status, oauthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
+// writeNoStoreJSON отвечает JSON телом. Ответы с токенами и данными пользователя не должны кешироваться
+func writeNoStoreJSON(w http.ResponseWriter, logger logger.Logger, status int, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		logger.Error("failed to marshal response", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(status)
+	if _, err := w.Write(data); err != nil {
+		logger.Warn("failed to write response", "error", err)
+	}
+}
+
+// oauthErrorFromError возвращает код ошибки OAuth 2.0, HTTP статус и описание для ошибки сервиса
+func oauthErrorFromError(err error) (string, int, string) {
+	description := apperrors.FromError(err).Message
+
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidClient):
+		return oauthErrorInvalidClient, http.StatusUnauthorized, description
+	case errors.Is(err, apperrors.ErrInvalidGrant),
+		errors.Is(err, apperrors.ErrInvalidRefreshToken),
+		errors.Is(err, apperrors.ErrRefreshTokenReused):
+		return oauthErrorInvalidGrant, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrUnauthorizedClient):
+		return oauthErrorUnauthorizedClient, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrInvalidScope):
+		return oauthErrorInvalidScope, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrUnsupportedGrantType):
+		return oauthErrorUnsupportedGrantType, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrUnsupportedResponseType):
+		return oauthErrorUnsupportedResponseType, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrUnsupportedTokenType):
+		return oauthErrorUnsupportedTokenType, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrInvalidRedirectURI):
+		return oauthErrorInvalidRequest, http.StatusBadRequest, description
+	case errors.Is(err, apperrors.ErrInvalidInput):
+		// Сообщения валидации описывают только сам запрос, их можно вернуть клиенту целиком
+		return oauthErrorInvalidRequest, http.StatusBadRequest, err.Error()
+	case errors.Is(err, apperrors.ErrSessionNotFound),
+		errors.Is(err, apperrors.ErrConsentRequired),
+		errors.Is(err, apperrors.ErrNotOrganizationMember):
+		return oauthErrorAccessDenied, http.StatusForbidden, description
+	default:
+		return oauthErrorServerError, http.StatusInternalServerError, description
+	}
+}
+
+// loginErrorFromError возвращает код ошибки, HTTP статус и описание для ошибки входа
+func loginErrorFromError(err error) (string, int, string) {
+	appErr := apperrors.FromError(err)
+
+	switch appErr.Code {
+	case codes.InvalidArgument:
+		if errors.Is(err, apperrors.ErrInvalidInput) {
+			return oauthErrorInvalidRequest, http.StatusBadRequest, err.Error()
+		}
+		return oauthErrorInvalidRequest, http.StatusBadRequest, appErr.Message
+	case codes.Unauthenticated:
+		return oauthErrorAccessDenied, http.StatusUnauthorized, appErr.Message
+	case codes.PermissionDenied, codes.FailedPrecondition:
+		return oauthErrorAccessDenied, http.StatusForbidden, appErr.Message
+	case codes.ResourceExhausted:
+		return oauthErrorTemporarilyUnavailable, http.StatusTooManyRequests, appErr.Message
+	default:
+		return oauthErrorServerError, http.StatusInternalServerError, appErr.Message
+	}
+}
+
+// urlWithParams добавляет параметры к URL, сохраняя его собственные. Пустые параметры пропускаются
+func urlWithParams(rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		if len(values) > 0 && values[0] != "" {
+			query.Set(key, values[0])
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/olezhek28/auth-service/pkg/config"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/logger"
+	"github.com/olezhek28/auth-service/pkg/service"
+)
+
+// Данные клиента и пользователя в stubOAuthAuthService
+const (
+	testOAuthRedirectURI  = "https://client.example.com/callback"
+	testOAuthPassword     = "correct-password"
+	testOAuthCode         = "authorization-code"
+	testOAuthAccessToken  = "access-token"
+	testOAuthCodeVerifier = "code-verifier"
+)
+
+// stubOAuthAuthService сервис с одним OAuth клиентом и одним пользователем без второго фактора.
+// Остальные методы не реализованы
+type stubOAuthAuthService struct {
+	service.AuthService
+
+	clientID    string
+	sessionUUID string
+	// loginClient информация об устройстве из последнего входа
+	loginClient service.ClientInfo
+}
+
+func (s *stubOAuthAuthService) ResolveOAuthRedirect(
+	_ context.Context,
+	req service.ResolveOAuthRedirectRequest,
+) (*service.ResolveOAuthRedirectResponse, error) {
+	if req.ClientID != s.clientID {
+		return nil, apperrors.ErrInvalidClient
+	}
+	if req.RedirectURI != testOAuthRedirectURI {
+		return nil, apperrors.ErrInvalidRedirectURI
+	}
+	return &service.ResolveOAuthRedirectResponse{RedirectURI: req.RedirectURI}, nil
+}
+
+func (s *stubOAuthAuthService) Authorize(_ context.Context, req service.AuthorizeRequest) (*service.AuthorizeResponse, error) {
+	if req.SessionUUID != s.sessionUUID {
+		return nil, apperrors.ErrSessionNotFound
+	}
+	return &service.AuthorizeResponse{RedirectURI: req.RedirectURI, Code: testOAuthCode}, nil
+}
+
+func (s *stubOAuthAuthService) Login(_ context.Context, req service.LoginRequest) (*service.LoginResponse, error) {
+	s.loginClient = req.Client
+	if req.Password != testOAuthPassword {
+		return nil, apperrors.ErrInvalidCredentials
+	}
+	return &service.LoginResponse{SessionUUID: s.sessionUUID, ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func (s *stubOAuthAuthService) ExchangeOAuthToken(
+	_ context.Context,
+	req service.OAuthTokenRequest,
+) (*service.OAuthTokenResponse, error) {
+	if req.Client.ClientID != s.clientID || req.Code != testOAuthCode || req.CodeVerifier != testOAuthCodeVerifier {
+		return nil, apperrors.ErrInvalidGrant
+	}
+	return &service.OAuthTokenResponse{
+		AccessToken:          testOAuthAccessToken,
+		AccessTokenExpiresAt: time.Now().Add(time.Hour),
+	}, nil
+}
+
+func newTestOAuthMux(authService service.AuthService) *http.ServeMux {
+	h := NewOAuthHandler(authService, config.OAuthConfig{
+		PublicURL:     "https://auth.example.com",
+		SessionCookie: "session_uuid",
+		LoginURL:      "https://auth.example.com/login",
+	}, nil, logger.New(slog.LevelError))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /oauth2/authorize", h.Authorize)
+	mux.HandleFunc("POST /oauth2/login", h.Login)
+	mux.HandleFunc("POST /oauth2/token", h.Token)
+	return mux
+}
+
+func serveForm(mux http.Handler, path string, form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.RemoteAddr = "203.0.113.7:5000"
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func serveGet(mux http.Handler, target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestOAuthAuthorizationCodeFlow проходит путь браузера и клиента: /oauth2/authorize без сессии,
+// вход на /oauth2/login, возврат на /oauth2/authorize с cookie и обмен кода на /oauth2/token
+func TestOAuthAuthorizationCodeFlow(t *testing.T) {
+	authService := &stubOAuthAuthService{clientID: uuid.NewString(), sessionUUID: uuid.NewString()}
+	mux := newTestOAuthMux(authService)
+
+	authorizeURI := "/oauth2/authorize?" + url.Values{
+		"client_id":             {authService.clientID},
+		"redirect_uri":          {testOAuthRedirectURI},
+		"response_type":         {"code"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+		"state":                 {"xyz"},
+	}.Encode()
+
+	// Без сессии пользователь отправляется на страницу входа с адресом возврата
+	rec := serveGet(mux, authorizeURI)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("authorize without session status = %d, want %d", rec.Code, http.StatusFound)
+	}
+	loginLocation, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid login redirect: %v", err)
+	}
+	returnTo := loginLocation.Query().Get("return_to")
+	if returnTo != "https://auth.example.com"+authorizeURI {
+		t.Fatalf("return_to = %q, want the authorize request", returnTo)
+	}
+
+	// Неверный пароль не устанавливает cookie
+	rec = serveForm(mux, "/oauth2/login", url.Values{
+		"tenant":    {"acme"},
+		"email":     {"user@acme.example.com"},
+		"password":  {"wrong-password"},
+		"return_to": {returnTo},
+	})
+	if rec.Code != http.StatusUnauthorized || len(rec.Result().Cookies()) != 0 {
+		t.Fatalf("login with wrong password status = %d, cookies = %v, want %d without cookies",
+			rec.Code, rec.Result().Cookies(), http.StatusUnauthorized)
+	}
+
+	rec = serveForm(mux, "/oauth2/login", url.Values{
+		"tenant":    {"acme"},
+		"email":     {"user@acme.example.com"},
+		"password":  {testOAuthPassword},
+		"return_to": {returnTo},
+	})
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != returnTo {
+		t.Fatalf("login status = %d, location = %q, want %d to %q",
+			rec.Code, rec.Header().Get("Location"), http.StatusSeeOther, returnTo)
+	}
+	if authService.loginClient.IP != "203.0.113.7" {
+		t.Errorf("login client IP = %q, want %q", authService.loginClient.IP, "203.0.113.7")
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("login set %d cookies, want 1", len(cookies))
+	}
+	cookie := cookies[0]
+	if cookie.Name != "session_uuid" || cookie.Value != authService.sessionUUID {
+		t.Errorf("login cookie = %s=%s, want session_uuid=%s", cookie.Name, cookie.Value, authService.sessionUUID)
+	}
+	if !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("login cookie HttpOnly = %v, Secure = %v, SameSite = %v, want HttpOnly, Secure and SameSite=Lax",
+			cookie.HttpOnly, cookie.Secure, cookie.SameSite)
+	}
+
+	// С cookie сессии /oauth2/authorize возвращает пользователя к клиенту с кодом
+	rec = serveGet(mux, authorizeURI, cookie)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("authorize with session status = %d, want %d", rec.Code, http.StatusFound)
+	}
+	callback, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid client redirect: %v", err)
+	}
+	if got := callback.Scheme + "://" + callback.Host + callback.Path; got != testOAuthRedirectURI {
+		t.Fatalf("authorize redirected to %q, want %q", got, testOAuthRedirectURI)
+	}
+	if callback.Query().Get("state") != "xyz" {
+		t.Errorf("state = %q, want %q", callback.Query().Get("state"), "xyz")
+	}
+
+	rec = serveForm(mux, "/oauth2/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {authService.clientID},
+		"code":          {callback.Query().Get("code")},
+		"redirect_uri":  {testOAuthRedirectURI},
+		"code_verifier": {testOAuthCodeVerifier},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("token status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var tokens oauthTokenResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &tokens); err != nil {
+		t.Fatalf("invalid token response: %v", err)
+	}
+	if tokens.AccessToken != testOAuthAccessToken || tokens.TokenType != "Bearer" {
+		t.Errorf("token response = %+v, want a bearer access token", tokens)
+	}
+}
+
+func TestOAuthLoginReturnTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		returnTo   string
+		wantStatus int
+	}{
+		{name: "без адреса возврата", returnTo: "", wantStatus: http.StatusNoContent},
+		{name: "authorize от публичного адреса", returnTo: "https://auth.example.com/oauth2/authorize?client_id=1", wantStatus: http.StatusSeeOther},
+		{name: "относительный authorize", returnTo: "/oauth2/authorize?client_id=1", wantStatus: http.StatusSeeOther},
+		{name: "другой сайт", returnTo: "https://evil.example.com/oauth2/authorize", wantStatus: http.StatusBadRequest},
+		{name: "адрес без схемы на другой сайт", returnTo: "//evil.example.com/oauth2/authorize", wantStatus: http.StatusBadRequest},
+		{name: "другой хост после публичного адреса", returnTo: "https://auth.example.com//evil.example.com/oauth2/authorize", wantStatus: http.StatusBadRequest},
+		{name: "публичный адрес как префикс хоста", returnTo: "https://auth.example.com.evil.example.com/oauth2/authorize", wantStatus: http.StatusBadRequest},
+		{name: "обратная косая черта", returnTo: "/\\evil.example.com/oauth2/authorize", wantStatus: http.StatusBadRequest},
+		{name: "другой путь сервера", returnTo: "/oauth2/token", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := &stubOAuthAuthService{sessionUUID: uuid.NewString()}
+			rec := serveForm(newTestOAuthMux(authService), "/oauth2/login", url.Values{
+				"tenant":    {"acme"},
+				"email":     {"user@acme.example.com"},
+				"password":  {testOAuthPassword},
+				"return_to": {tt.returnTo},
+			})
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("login status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code == http.StatusSeeOther && rec.Header().Get("Location") != tt.returnTo {
+				t.Errorf("login location = %q, want %q", rec.Header().Get("Location"), tt.returnTo)
+			}
+			if wantCookie := rec.Code != http.StatusBadRequest; (len(rec.Result().Cookies()) == 1) != wantCookie {
+				t.Errorf("login cookies = %v, want cookie set %v", rec.Result().Cookies(), wantCookie)
+			}
+		})
+	}
+}
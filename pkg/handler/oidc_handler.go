@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/olezhek28/auth-service/pkg/config"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/logger"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/service"
+	"github.com/olezhek28/auth-service/pkg/token"
+)
+
+// Кеширование документа discovery. Документ с адресами из OAUTH_PUBLIC_URL меняется только при смене
+// конфигурации. Документ с адресами от заголовка Host не кешируется: подставив Host, клиент отравил бы
+// общий кеш для всех
+const (
+	discoveryCacheControl     = "public, max-age=3600"
+	hostDiscoveryCacheControl = "no-store"
+)
+
+// openIDConfiguration документ discovery OpenID Connect
+type openIDConfiguration struct {
+	Issuer                                 string   `json:"issuer"`
+	AuthorizationEndpoint                  string   `json:"authorization_endpoint"`
+	TokenEndpoint                          string   `json:"token_endpoint"`
+	UserInfoEndpoint                       string   `json:"userinfo_endpoint"`
+	RevocationEndpoint                     string   `json:"revocation_endpoint"`
+	JWKSURI                                string   `json:"jwks_uri"`
+	ScopesSupported                        []string `json:"scopes_supported"`
+	ResponseTypesSupported                 []string `json:"response_types_supported"`
+	ResponseModesSupported                 []string `json:"response_modes_supported"`
+	GrantTypesSupported                    []string `json:"grant_types_supported"`
+	SubjectTypesSupported                  []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported       []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported      []string `json:"token_endpoint_auth_methods_supported"`
+	RevocationEndpointAuthMethodsSupported []string `json:"revocation_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported          []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                        []string `json:"claims_supported"`
+}
+
+// userInfoResponse тело ответа /userinfo
+type userInfoResponse struct {
+	Subject string `json:"sub"`
+	token.IdentityClaims
+}
+
+// OIDCHandler HTTP обработчик эндпоинтов OpenID Connect поверх сервера авторизации OAuth 2.0
+type OIDCHandler struct {
+	authService service.AuthService
+	// issuer значение claim iss в токенах, оно же issuer в документе discovery
+	issuer string
+	cfg    config.OAuthConfig
+	logger logger.Logger
+}
+
+// NewOIDCHandler создает новый HTTP обработчик OpenID Connect
+func NewOIDCHandler(
+	authService service.AuthService,
+	issuer string,
+	cfg config.OAuthConfig,
+	logger logger.Logger,
+) *OIDCHandler {
+	return &OIDCHandler{
+		authService: authService,
+		issuer:      issuer,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// Discovery обрабатывает GET /.well-known/openid-configuration
+func (h *OIDCHandler) Discovery(w http.ResponseWriter, r *http.Request) {
+	baseURL, cacheControl := h.cfg.PublicURL, discoveryCacheControl
+	if baseURL == "" {
+		baseURL, cacheControl = requestBaseURL(r), hostDiscoveryCacheControl
+	}
+
+	body, err := json.Marshal(openIDConfiguration{
+		Issuer:                 h.issuer,
+		AuthorizationEndpoint:  baseURL + "/oauth2/authorize",
+		TokenEndpoint:          baseURL + "/oauth2/token",
+		UserInfoEndpoint:       baseURL + "/userinfo",
+		RevocationEndpoint:     baseURL + "/oauth2/revoke",
+		JWKSURI:                baseURL + "/.well-known/jwks.json",
+		ScopesSupported:        []string{"openid", "profile", "email"},
+		ResponseTypesSupported: []string{"code"},
+		ResponseModesSupported: []string{"query"},
+		GrantTypesSupported: []string{
+			models.OAuthGrantAuthorizationCode,
+			models.OAuthGrantRefreshToken,
+			models.OAuthGrantClientCredentials,
+		},
+		SubjectTypesSupported:                  []string{"public"},
+		IDTokenSigningAlgValuesSupported:       []string{"EdDSA"},
+		TokenEndpointAuthMethodsSupported:      []string{"client_secret_basic", "client_secret_post", "none"},
+		RevocationEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:          []string{"S256"},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce",
+			"email", "email_verified", "preferred_username",
+		},
+	})
+	if err != nil {
+		h.logger.Error("failed to marshal openid configuration", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", cacheControl)
+	if _, err := w.Write(body); err != nil {
+		h.logger.Warn("failed to write openid configuration", "error", err)
+	}
+}
+
+// UserInfo обрабатывает GET и POST /userinfo. Access токен передается в заголовке Authorization: Bearer
+func (h *OIDCHandler) UserInfo(w http.ResponseWriter, r *http.Request) {
+	accessToken, ok := bearerToken(r)
+	if !ok {
+		// RFC 6750 3.1: на запрос без токена отвечаем без кода ошибки
+		w.Header().Set("WWW-Authenticate", `Bearer realm="userinfo"`)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := h.authService.UserInfo(r.Context(), service.UserInfoRequest{
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		h.writeBearerError(w, err)
+		return
+	}
+
+	writeNoStoreJSON(w, h.logger, http.StatusOK, userInfoResponse{
+		Subject:        resp.Subject.String(),
+		IdentityClaims: resp.Identity,
+	})
+}
+
+// writeBearerError отвечает ошибкой защищенного ресурса по RFC 6750
+func (h *OIDCHandler) writeBearerError(w http.ResponseWriter, err error) {
+	var code, challenge string
+	var status int
+
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidAccessToken):
+		code, status = "invalid_token", http.StatusUnauthorized
+		challenge = `Bearer realm="userinfo", error="invalid_token"`
+	case errors.Is(err, apperrors.ErrInsufficientScope):
+		code, status = "insufficient_scope", http.StatusForbidden
+		challenge = `Bearer realm="userinfo", error="insufficient_scope", scope="openid"`
+	default:
+		h.logger.Error("failed to get userinfo", "error", err)
+		code, status = oauthErrorServerError, http.StatusInternalServerError
+	}
+
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	writeNoStoreJSON(w, h.logger, status, oauthErrorResponse{
+		Error:            code,
+		ErrorDescription: apperrors.FromError(err).Message,
+	})
+}
+
+// bearerToken извлекает access токен из заголовка Authorization
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, accessToken, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	accessToken = strings.TrimSpace(accessToken)
+	return accessToken, accessToken != ""
+}
+
+// requestBaseURL возвращает адрес сервера, по которому пришел запрос. Используется, если не задан OAUTH_PUBLIC_URL
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// tenantHeader metadata ключ со slug организации
+const tenantHeader = "x-tenant"
+
+// tenantFromRequest возвращает организацию из поля запроса, а если оно пустое - из metadata.
+// Metadata позволяет указать организацию клиентам auth.v1, в запросах которых нет такого поля
+func tenantFromRequest(ctx context.Context, tenant string) string {
+	if tenant != "" {
+		return tenant
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	return firstMetadataValue(md, tenantHeader)
+}
@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DeadlineInterceptor ограничивает время обработки unary вызова, если клиент не передал свой дедлайн
+func DeadlineInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := withDefaultDeadline(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamDeadlineInterceptor streaming вариант DeadlineInterceptor
+func StreamDeadlineInterceptor(timeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := withDefaultDeadline(ss.Context(), timeout)
+		defer cancel()
+
+		return handler(srv, wrapServerStream(ctx, ss))
+	}
+}
+
+// withDefaultDeadline выставляет таймаут только если в контексте еще нет дедлайна
+func withDefaultDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,74 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/olezhek28/auth-service/pkg/logger"
+)
+
+// LoggingInterceptor логирует каждый unary вызов: метод, длительность, код ответа и адрес клиента
+func LoggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		logCall(ctx, log, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamLoggingInterceptor логирует каждый streaming вызов аналогично LoggingInterceptor
+func StreamLoggingInterceptor(log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		logCall(ss.Context(), log, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// logCall пишет запись о завершенном вызове с уровнем, зависящим от кода ответа
+func logCall(ctx context.Context, log logger.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	args := []any{
+		"method", method,
+		"duration", time.Since(start),
+		"code", code.String(),
+		"peer", peerAddress(ctx),
+		"request_id", RequestIDFromContext(ctx),
+	}
+	if err != nil {
+		args = append(args, "error", err)
+	}
+
+	switch code {
+	case codes.OK:
+		log.Info("gRPC call finished", args...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		log.Error("gRPC call failed", args...)
+	default:
+		log.Warn("gRPC call failed", args...)
+	}
+}
+
+// peerAddress возвращает адрес клиента из контекста вызова
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return p.Addr.String()
+}
@@ -0,0 +1,50 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/olezhek28/auth-service/pkg/logger"
+)
+
+// RecoveryInterceptor перехватывает панику в unary обработчике и возвращает codes.Internal
+func RecoveryInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = handlePanic(ctx, log, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor перехватывает панику в streaming обработчике и возвращает codes.Internal
+func StreamRecoveryInterceptor(log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = handlePanic(ss.Context(), log, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// handlePanic логирует панику вместе со стектрейсом и формирует ответ клиенту
+func handlePanic(ctx context.Context, log logger.Logger, method string, r any) error {
+	log.Error("panic recovered",
+		"method", method,
+		"request_id", RequestIDFromContext(ctx),
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+
+	return status.Error(codes.Internal, "Internal server error")
+}
@@ -0,0 +1,58 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader имя metadata ключа с идентификатором запроса
+const RequestIDHeader = "x-request-id"
+
+// requestIDKey ключ контекста для идентификатора запроса
+type requestIDKey struct{}
+
+// RequestIDInterceptor берет идентификатор запроса из входящих metadata или генерирует новый,
+// кладет его в контекст и возвращает клиенту в заголовке ответа
+func RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = withRequestID(ctx)
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestIDInterceptor streaming вариант RequestIDInterceptor
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestID(ss.Context())
+
+		return handler(srv, wrapServerStream(ctx, ss))
+	}
+}
+
+// RequestIDFromContext возвращает идентификатор запроса из контекста
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// withRequestID сохраняет идентификатор запроса в контекст и в заголовок ответа
+func withRequestID(ctx context.Context) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	// Ошибка возможна только если заголовки уже отправлены, что здесь исключено
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
@@ -0,0 +1,26 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// wrappedServerStream позволяет подменить контекст серверного стрима
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context возвращает подмененный контекст стрима
+func (s *wrappedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// wrapServerStream оборачивает стрим с новым контекстом
+func wrapServerStream(ctx context.Context, ss grpc.ServerStream) grpc.ServerStream {
+	return &wrappedServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	}
+}
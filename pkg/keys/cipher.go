@@ -0,0 +1,74 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// masterKeySize размер мастер-ключа AES-256 в байтах
+const masterKeySize = 32
+
+// Cipher шифрует секреты (приватные части ключей, секреты TOTP) перед сохранением в базу
+type Cipher interface {
+	Encrypt(plaintext, additionalData []byte) ([]byte, error)
+	Decrypt(ciphertext, additionalData []byte) ([]byte, error)
+}
+
+// aesCipher реализация Cipher на AES-256-GCM. Nonce хранится перед шифртекстом
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher создает шифр из мастер-ключа в base64
+func NewAESCipher(encodedMasterKey string) (Cipher, error) {
+	masterKey, err := base64.StdEncoding.DecodeString(encodedMasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode master key: %w", err)
+	}
+	if len(masterKey) != masterKeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", masterKeySize, len(masterKey))
+	}
+
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return &aesCipher{
+		aead: aead,
+	}, nil
+}
+
+// Encrypt шифрует данные, привязывая шифртекст к additionalData
+func (c *aesCipher) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt расшифровывает данные, зашифрованные Encrypt с тем же additionalData
+func (c *aesCipher) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+)
+
+// JSONWebKey публичный ключ в формате JWK (RFC 7517, RFC 8037 для Ed25519)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// JSONWebKeySet набор публичных ключей в формате JWKS
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// newJSONWebKey конвертирует публичный ключ Ed25519 в JWK
+func newJSONWebKey(kid string, publicKey ed25519.PublicKey) JSONWebKey {
+	return JSONWebKey{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(publicKey),
+		KeyID:     kid,
+		Algorithm: AlgorithmEdDSA,
+		Use:       "sig",
+	}
+}
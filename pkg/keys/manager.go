@@ -0,0 +1,248 @@
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/olezhek28/auth-service/pkg/logger"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/repository"
+)
+
+// AlgorithmEdDSA алгоритм подписи ключей, выпускаемых менеджером
+const AlgorithmEdDSA = "EdDSA"
+
+// Предопределенные ошибки
+var (
+	ErrNoSigningKey = errors.New("no active signing key")
+	ErrUnknownKey   = errors.New("unknown signing key")
+)
+
+// Config настройки ротации ключей подписи
+type Config struct {
+	// RotationPeriod как часто выпускается новый ключ
+	RotationPeriod time.Duration
+	// Overlap сколько старый ключ остается в JWKS после активации нового
+	Overlap time.Duration
+	// RefreshInterval как часто инстанс перечитывает ключи из базы.
+	// Новый ключ начинает использоваться для подписи через два интервала после создания,
+	// чтобы к этому моменту все инстансы уже опубликовали и принимали его
+	RefreshInterval time.Duration
+}
+
+// Manager хранит ключи подписи в памяти, периодически перечитывает их из базы и ротирует
+type Manager struct {
+	repo   repository.SigningKeyRepository
+	cipher Cipher
+	logger logger.Logger
+	cfg    Config
+
+	mu      sync.RWMutex
+	signing *models.SigningKey
+	keys    map[string]*models.SigningKey
+	jwks    JSONWebKeySet
+	newest  time.Time
+}
+
+// NewManager создает новый менеджер ключей подписи
+func NewManager(repo repository.SigningKeyRepository, cipher Cipher, logger logger.Logger, cfg Config) *Manager {
+	return &Manager{
+		repo:   repo,
+		cipher: cipher,
+		logger: logger,
+		cfg:    cfg,
+		keys:   make(map[string]*models.SigningKey),
+	}
+}
+
+// Load загружает ключи из базы и при необходимости выпускает новый ключ.
+// Должен быть вызван до начала обслуживания запросов
+func (m *Manager) Load(ctx context.Context) error {
+	if err := m.reload(ctx); err != nil {
+		return err
+	}
+
+	return m.rotateIfNeeded(ctx)
+}
+
+// Run периодически перечитывает и ротирует ключи, пока не будет отменен контекст
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.reload(ctx); err != nil {
+				m.logger.Error("failed to reload signing keys", "error", err)
+				continue
+			}
+			if err := m.rotateIfNeeded(ctx); err != nil {
+				m.logger.Error("failed to rotate signing keys", "error", err)
+			}
+		}
+	}
+}
+
+// SigningKey возвращает идентификатор и приватную часть текущего ключа подписи
+func (m *Manager) SigningKey() (string, ed25519.PrivateKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.signing == nil {
+		return "", nil, ErrNoSigningKey
+	}
+
+	return m.signing.KID, m.signing.PrivateKey, nil
+}
+
+// VerificationKey возвращает публичную часть ключа по его идентификатору
+func (m *Manager) VerificationKey(kid string) (ed25519.PublicKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[kid]
+	if !ok || (key.ExpiresAt != nil && !time.Now().Before(*key.ExpiresAt)) {
+		return nil, ErrUnknownKey
+	}
+
+	return key.PublicKey, nil
+}
+
+// JWKS возвращает набор опубликованных публичных ключей
+func (m *Manager) JWKS() JSONWebKeySet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.jwks
+}
+
+// reload перечитывает ключи из базы и выбирает ключ для подписи
+func (m *Manager) reload(ctx context.Context) error {
+	now := time.Now()
+
+	stored, err := m.repo.ListSigningKeys(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make(map[string]*models.SigningKey, len(stored))
+	jwks := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(stored))}
+	var signing *models.SigningKey
+	var newest time.Time
+
+	// Ключи отсортированы от самого нового, поэтому первый активный и есть текущий
+	for _, key := range stored {
+		keys[key.KID] = key
+		jwks.Keys = append(jwks.Keys, newJSONWebKey(key.KID, key.PublicKey))
+		if key.CreatedAt.After(newest) {
+			newest = key.CreatedAt
+		}
+
+		if signing == nil && !key.ActivatesAt.After(now) {
+			privateKey, err := m.cipher.Decrypt(key.PrivateKeyEncrypted, []byte(key.KID))
+			if err != nil {
+				return fmt.Errorf("failed to decrypt signing key %s: %w", key.KID, err)
+			}
+			key.PrivateKey = ed25519.NewKeyFromSeed(privateKey)
+			signing = key
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if kidOf(m.signing) != kidOf(signing) {
+		m.logger.Info("signing key changed", "kid", kidOf(signing), "published_keys", len(keys))
+	}
+
+	m.signing = signing
+	m.keys = keys
+	m.jwks = jwks
+	m.newest = newest
+
+	return nil
+}
+
+// rotateIfNeeded выпускает новый ключ, если самый свежий старше периода ротации
+func (m *Manager) rotateIfNeeded(ctx context.Context) error {
+	now := time.Now()
+	rotateBefore := now.Add(-m.cfg.RotationPeriod)
+
+	m.mu.RLock()
+	newest := m.newest
+	hasSigningKey := m.signing != nil
+	m.mu.RUnlock()
+
+	if !newest.IsZero() && newest.After(rotateBefore) {
+		return nil
+	}
+
+	// Первый ключ активируется сразу, так как подписывать больше нечем
+	activatesAt := now.Add(2 * m.cfg.RefreshInterval)
+	if !hasSigningKey {
+		activatesAt = now
+	}
+
+	key, err := m.generateKey(now, activatesAt)
+	if err != nil {
+		return err
+	}
+
+	rotated, err := m.repo.RotateSigningKey(ctx, key, rotateBefore, m.cfg.Overlap)
+	if err != nil {
+		return fmt.Errorf("failed to store signing key: %w", err)
+	}
+	if rotated {
+		m.logger.Info("signing key rotated", "kid", key.KID, "activates_at", key.ActivatesAt)
+	}
+
+	return m.reload(ctx)
+}
+
+// generateKey создает новую пару ключей Ed25519 и шифрует приватную часть
+func (m *Manager) generateKey(now, activatesAt time.Time) (*models.SigningKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	kid := keyID(publicKey)
+
+	encrypted, err := m.cipher.Encrypt(privateKey.Seed(), []byte(kid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	return &models.SigningKey{
+		KID:                 kid,
+		Algorithm:           AlgorithmEdDSA,
+		PublicKey:           publicKey,
+		PrivateKeyEncrypted: encrypted,
+		CreatedAt:           now,
+		ActivatesAt:         activatesAt,
+	}, nil
+}
+
+// keyID вычисляет идентификатор ключа по его публичной части
+func keyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// kidOf возвращает идентификатор ключа или пустую строку, если ключа нет
+func kidOf(key *models.SigningKey) string {
+	if key == nil {
+		return ""
+	}
+	return key.KID
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE signing_keys (
+    id BIGSERIAL PRIMARY KEY,
+    kid VARCHAR(64) NOT NULL UNIQUE,
+    algorithm VARCHAR(16) NOT NULL,
+    public_key BYTEA NOT NULL,
+    -- Приватная часть ключа зашифрована мастер-ключом (AES-256-GCM)
+    private_key_encrypted BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    -- С этого момента ключ используется для подписи
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- После этого момента ключ не публикуется и подписи им не принимаются
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_signing_keys_activates_at ON signing_keys(activates_at);
+CREATE INDEX idx_signing_keys_expires_at ON signing_keys(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS signing_keys;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_totp (
+    user_uuid UUID PRIMARY KEY,
+    -- Секрет TOTP зашифрован мастер-ключом (AES-256-GCM)
+    secret_encrypted BYTEA NOT NULL,
+    -- Не заполнено, пока пользователь не подтвердил подключение кодом из приложения
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    -- Последний использованный временной шаг, защищает от повторного использования кода
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_uuid UUID NOT NULL,
+    -- SHA-256 от кода восстановления, сам код не хранится
+    code_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_uuid, code_hash)
+);
+
+CREATE INDEX idx_user_recovery_codes_user_uuid ON user_recovery_codes(user_uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE password_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_uuid UUID NOT NULL,
+    -- Хеш прежнего пароля в формате PHC, которым он был сохранен в users
+    password_hash VARCHAR(255) NOT NULL,
+    -- Время, когда пароль был заменен
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_history_user_uuid ON password_history(user_uuid, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS password_history;
+-- +goose StatementEnd
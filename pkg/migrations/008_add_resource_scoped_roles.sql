@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пустой resource означает, что роль выдана глобально и действует для любого ресурса
+ALTER TABLE user_roles ADD COLUMN resource VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE user_roles DROP CONSTRAINT user_roles_pkey;
+ALTER TABLE user_roles ADD PRIMARY KEY (user_uuid, role_id, resource);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM user_roles WHERE resource <> '';
+
+ALTER TABLE user_roles DROP CONSTRAINT user_roles_pkey;
+ALTER TABLE user_roles ADD PRIMARY KEY (user_uuid, role_id);
+
+ALTER TABLE user_roles DROP COLUMN resource;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- Идентификатор, которым клиенты указывают организацию в запросах
+    slug VARCHAR(63) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Уже существующие пользователи и роли переносятся в организацию default
+INSERT INTO organizations (slug, name) VALUES ('default', 'Default');
+
+-- Пользователи: email и username уникальны только внутри организации
+ALTER TABLE users ADD COLUMN tenant_id UUID REFERENCES organizations(id);
+UPDATE users SET tenant_id = (SELECT id FROM organizations WHERE slug = 'default');
+ALTER TABLE users ALTER COLUMN tenant_id SET NOT NULL;
+
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+DROP INDEX IF EXISTS idx_users_email;
+DROP INDEX IF EXISTS idx_users_username;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_email_key UNIQUE (tenant_id, email);
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_username_key UNIQUE (tenant_id, username);
+
+-- Роли: имя уникально только внутри организации
+ALTER TABLE roles ADD COLUMN tenant_id UUID REFERENCES organizations(id);
+UPDATE roles SET tenant_id = (SELECT id FROM organizations WHERE slug = 'default');
+ALTER TABLE roles ALTER COLUMN tenant_id SET NOT NULL;
+
+ALTER TABLE roles DROP CONSTRAINT roles_name_key;
+ALTER TABLE roles ADD CONSTRAINT roles_tenant_id_name_key UNIQUE (tenant_id, name);
+
+-- Одноразовые токены помнят организацию пользователя, которому выданы
+ALTER TABLE password_reset_tokens ADD COLUMN tenant_id UUID;
+UPDATE password_reset_tokens t SET tenant_id = u.tenant_id FROM users u WHERE u.uuid = t.user_uuid;
+DELETE FROM password_reset_tokens WHERE tenant_id IS NULL;
+ALTER TABLE password_reset_tokens ALTER COLUMN tenant_id SET NOT NULL;
+
+ALTER TABLE email_verification_tokens ADD COLUMN tenant_id UUID;
+UPDATE email_verification_tokens t SET tenant_id = u.tenant_id FROM users u WHERE u.uuid = t.user_uuid;
+DELETE FROM email_verification_tokens WHERE tenant_id IS NULL;
+ALTER TABLE email_verification_tokens ALTER COLUMN tenant_id SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE email_verification_tokens DROP COLUMN tenant_id;
+ALTER TABLE password_reset_tokens DROP COLUMN tenant_id;
+
+ALTER TABLE roles DROP CONSTRAINT roles_tenant_id_name_key;
+ALTER TABLE roles ADD CONSTRAINT roles_name_key UNIQUE (name);
+ALTER TABLE roles DROP COLUMN tenant_id;
+
+ALTER TABLE users DROP CONSTRAINT users_tenant_id_username_key;
+ALTER TABLE users DROP CONSTRAINT users_tenant_id_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+CREATE INDEX idx_users_email ON users(email);
+CREATE INDEX idx_users_username ON users(username);
+ALTER TABLE users DROP COLUMN tenant_id;
+
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_uuid UUID NOT NULL,
+    -- Роль участника в организации, роль принадлежит этой же организации
+    role_id BIGINT REFERENCES roles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_uuid)
+);
+
+CREATE INDEX idx_organization_members_user_uuid ON organization_members(user_uuid);
+
+-- Каждый пользователь состоит в организации, в которой зарегистрирован
+INSERT INTO organization_members (organization_id, user_uuid, created_at)
+SELECT tenant_id, uuid, created_at FROM users;
+
+CREATE TABLE organization_invitations (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role_id BIGINT REFERENCES roles(id) ON DELETE SET NULL,
+    invited_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    accepted_by UUID
+);
+
+CREATE INDEX idx_organization_invitations_organization_id ON organization_invitations(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE service_accounts (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    -- Пользователь-владелец. NULL - сервисным аккаунтом владеет организация
+    owner_user_uuid UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE INDEX idx_service_accounts_owner_user_uuid ON service_accounts(owner_user_uuid);
+
+-- API ключ принадлежит пользователю или сервисному аккаунту
+ALTER TABLE api_keys RENAME COLUMN user_uuid TO principal_id;
+ALTER TABLE api_keys ADD COLUMN principal_type VARCHAR(32) NOT NULL DEFAULT 'user';
+ALTER INDEX idx_api_keys_user_uuid RENAME TO idx_api_keys_principal_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM api_keys WHERE principal_type <> 'user';
+ALTER INDEX idx_api_keys_principal_id RENAME TO idx_api_keys_user_uuid;
+ALTER TABLE api_keys DROP COLUMN principal_type;
+ALTER TABLE api_keys RENAME COLUMN principal_id TO user_uuid;
+
+DROP TABLE IF EXISTS service_accounts;
+-- +goose StatementEnd
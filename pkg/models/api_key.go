@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey ключ для машинных клиентов. Сам ключ показывается один раз при создании,
+// на сервере хранится только его хеш
+type APIKey struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	// PrincipalType и PrincipalID пользователь или сервисный аккаунт, от имени которого действует ключ
+	PrincipalType PrincipalType
+	PrincipalID   uuid.UUID
+	Name          string
+	// Prefix начало ключа, по которому его можно узнать в списке
+	Prefix  string
+	KeyHash string
+	// Scopes области действия ключа, их соблюдение проверяет вызывающий сервис
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt nil, если ключ бессрочный
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken представляет одноразовый токен подтверждения email.
+// Сам токен не хранится, только его хеш
+type EmailVerificationToken struct {
+	ID        int64
+	TokenHash string
+	UserUUID  uuid.UUID
+	TenantID  uuid.UUID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// UsedAt заполняется при использовании токена
+	UsedAt *time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TOTP представляет подключенный у пользователя TOTP
+type TOTP struct {
+	UserUUID uuid.UUID
+	// Secret секрет в base32, заполняется только после расшифровки
+	Secret          string
+	SecretEncrypted []byte
+	// ConfirmedAt не заполнено, пока подключение не подтверждено кодом
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+// MFAChallenge представляет вход, ожидающий второго фактора
+type MFAChallenge struct {
+	ID       string
+	UserUUID uuid.UUID
+	TenantID uuid.UUID
+	// IssueTokens клиент запросил токены вместо сессии
+	IssueTokens bool
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы грантов OAuth 2.0, которые поддерживает сервер
+const (
+	OAuthGrantAuthorizationCode = "authorization_code"
+	OAuthGrantRefreshToken      = "refresh_token"
+	OAuthGrantClientCredentials = "client_credentials"
+)
+
+// OAuthClient приложение, зарегистрированное для получения токенов по OAuth 2.0
+type OAuthClient struct {
+	// ID значение client_id
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Name     string
+	// SecretHash хеш секрета конфиденциального клиента, пустой у публичного клиента
+	SecretHash   string
+	RedirectURIs []string
+	GrantTypes   []string
+	// Scopes области действия, которые клиент может запросить
+	Scopes []string
+	// FirstParty собственное приложение, согласие пользователя не запрашивается
+	FirstParty bool
+	// ServiceAccountID сервисный аккаунт, от имени которого клиент получает токены по client_credentials
+	ServiceAccountID *uuid.UUID
+	CreatedAt        time.Time
+}
+
+// Confidential проверяет, что у клиента есть секрет
+func (c *OAuthClient) Confidential() bool {
+	return c.SecretHash != ""
+}
+
+// AllowsGrant проверяет, что клиенту разрешен тип гранта
+func (c *OAuthClient) AllowsGrant(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// AllowsScopes проверяет, что клиент может запросить все области действия
+func (c *OAuthClient) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// OAuthConsent согласие пользователя на доступ клиента к областям действия
+type OAuthConsent struct {
+	UserUUID  uuid.UUID
+	ClientID  uuid.UUID
+	Scopes    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Covers проверяет, что согласие дано на все области действия
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// OAuthAuthorizationCode одноразовый код авторизации. Сам код не хранится, только его хеш
+type OAuthAuthorizationCode struct {
+	CodeHash    string
+	ClientID    uuid.UUID
+	RedirectURI string
+	UserUUID    uuid.UUID
+	TenantID    uuid.UUID
+	Scopes      []string
+	// CodeChallenge PKCE challenge метода S256
+	CodeChallenge string
+	// Nonce из запроса OpenID Connect, возвращается клиенту в ID токене
+	Nonce string
+	// AuthTime время входа пользователя, по сессии которого выдан код
+	AuthTime  time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization представляет организацию (tenant). Пользователи, роли и сессии
+// разных организаций изолированы друг от друга
+type Organization struct {
+	ID uuid.UUID
+	// Slug человекочитаемый идентификатор, которым клиенты указывают организацию в запросах
+	Slug      string
+	Name      string
+	CreatedAt time.Time
+}
+
+// UserRef ссылка на пользователя внутри его организации
+type UserRef struct {
+	TenantID uuid.UUID
+	UserUUID uuid.UUID
+}
+
+// Member участник организации. Пользователь может состоять в нескольких организациях
+// с разной ролью в каждой, помимо той, в которой зарегистрирован
+type Member struct {
+	OrganizationID uuid.UUID
+	UserUUID       uuid.UUID
+	// TenantID организация, в которой зарегистрирован пользователь
+	TenantID uuid.UUID
+	Email    string
+	Username string
+	// Role роль участника в организации. Пустая - участник без роли
+	Role     string
+	JoinedAt time.Time
+}
+
+// Invitation приглашение в организацию, отправленное на email
+type Invitation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Email          string
+	// Role роль, которую получит принявший приглашение. Пустая - участник без роли
+	Role       string
+	InvitedBy  uuid.UUID
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken представляет одноразовый токен сброса пароля.
+// Сам токен не хранится, только его хеш
+type PasswordResetToken struct {
+	ID        int64
+	TokenHash string
+	UserUUID  uuid.UUID
+	TenantID  uuid.UUID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// UsedAt заполняется при использовании токена
+	UsedAt *time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PrincipalType тип субъекта, от имени которого выполняется запрос
+type PrincipalType string
+
+const (
+	// PrincipalTypeUser человек, входящий по email и паролю
+	PrincipalTypeUser PrincipalType = "user"
+	// PrincipalTypeServiceAccount машинный клиент без email и пароля.
+	// Аутентифицируется только API ключами и учетными данными клиента
+	PrincipalTypeServiceAccount PrincipalType = "service_account"
+)
+
+// ServiceAccount сервисный аккаунт организации
+type ServiceAccount struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	Name        string
+	Description string
+	// OwnerUserUUID пользователь-владелец. nil - аккаунтом владеет организация
+	OwnerUserUUID *uuid.UUID
+	CreatedAt     time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken представляет refresh токен, хранящийся на сервере.
+// Сам токен не хранится, только его хеш
+type RefreshToken struct {
+	TokenHash string
+	UserUUID  uuid.UUID
+	TenantID  uuid.UUID
+	// FamilyID общий идентификатор всех токенов, полученных ротацией от одного входа
+	FamilyID uuid.UUID
+	// FamilyCreatedAt время входа, с которого началось семейство. Ротация не продлевает
+	// семейство дальше максимального времени жизни от этого момента
+	FamilyCreatedAt time.Time
+	// ClientID OAuth клиент, которому выдан токен. uuid.Nil - токен выдан при входе в сам сервис
+	ClientID uuid.UUID
+	// Scopes области действия, на которые согласился пользователь. Только у токенов OAuth клиентов
+	Scopes    []string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role роль с набором разрешений, которую можно выдать пользователю
+// Роли принадлежат организации и выдаются только ее пользователям
+type Role struct {
+	ID          int64
+	TenantID    uuid.UUID
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+}
+
+// RoleGrant роль, выданная пользователю глобально или на конкретный ресурс
+type RoleGrant struct {
+	Role string
+	// Resource пустой, если роль выдана глобально
+	Resource    string
+	Permissions []string
+}
+
+// UserAccess роли пользователя и разрешения, которые они дают
+type UserAccess struct {
+	// Roles и Permissions глобально выданные роли и их разрешения
+	Roles       []string
+	Permissions []string
+	// Grants все выданные роли, в том числе на конкретные ресурсы
+	Grants []RoleGrant
+}
+
+// FindGrant ищет роль, которая дает разрешение на ресурс. Глобальные роли
+// дают разрешение на любой ресурс и проверяются первыми
+func (a *UserAccess) FindGrant(permission, resource string) (RoleGrant, bool) {
+	var scoped *RoleGrant
+	for i, grant := range a.Grants {
+		if !slices.Contains(grant.Permissions, permission) {
+			continue
+		}
+		if grant.Resource == "" {
+			return grant, true
+		}
+		if resource != "" && grant.Resource == resource && scoped == nil {
+			scoped = &a.Grants[i]
+		}
+	}
+
+	if scoped != nil {
+		return *scoped, true
+	}
+	return RoleGrant{}, false
+}
+
+// RestrictTo возвращает права, ограниченные разрешениями из scopes.
+// Роли, не дающие ни одного из них, в результат не попадают
+func (a *UserAccess) RestrictTo(scopes []string) *UserAccess {
+	restricted := &UserAccess{}
+	for _, grant := range a.Grants {
+		var permissions []string
+		for _, permission := range grant.Permissions {
+			if slices.Contains(scopes, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+
+		restricted.Grants = append(restricted.Grants, RoleGrant{
+			Role:        grant.Role,
+			Resource:    grant.Resource,
+			Permissions: permissions,
+		})
+		if grant.Resource == "" {
+			restricted.Roles = append(restricted.Roles, grant.Role)
+			restricted.Permissions = append(restricted.Permissions, permissions...)
+		}
+	}
+	slices.Sort(restricted.Permissions)
+	restricted.Permissions = slices.Compact(restricted.Permissions)
+
+	return restricted
+}
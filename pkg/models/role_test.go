@@ -0,0 +1,144 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUserAccessFindGrant(t *testing.T) {
+	access := &UserAccess{
+		Grants: []RoleGrant{
+			{Role: "doc-editor", Resource: "docs/1", Permissions: []string{"docs:read", "docs:write"}},
+			{Role: "doc-reader", Resource: "docs/1", Permissions: []string{"docs:read"}},
+			{Role: "viewer", Permissions: []string{"docs:read"}},
+			{Role: "billing", Resource: "invoices/7", Permissions: []string{"invoices:read"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		permission string
+		resource   string
+		wantFound  bool
+		want       RoleGrant
+	}{
+		{
+			name:       "глобальная роль важнее роли на ресурс",
+			permission: "docs:read",
+			resource:   "docs/1",
+			wantFound:  true,
+			want:       access.Grants[2],
+		},
+		{
+			name:       "глобальная роль без ресурса",
+			permission: "docs:read",
+			wantFound:  true,
+			want:       access.Grants[2],
+		},
+		{
+			name:       "первая подходящая роль на ресурс",
+			permission: "docs:write",
+			resource:   "docs/1",
+			wantFound:  true,
+			want:       access.Grants[0],
+		},
+		{
+			name:       "роль на другой ресурс",
+			permission: "docs:write",
+			resource:   "docs/2",
+		},
+		{
+			name:       "роль на ресурс не действует без ресурса",
+			permission: "invoices:read",
+		},
+		{
+			name:       "разрешение не выдано",
+			permission: "docs:delete",
+			resource:   "docs/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := access.FindGrant(tt.permission, tt.resource)
+			if found != tt.wantFound {
+				t.Fatalf("FindGrant() found = %v, want %v", found, tt.wantFound)
+			}
+			if got.Role != tt.want.Role || got.Resource != tt.want.Resource {
+				t.Errorf("FindGrant() = %s on %q, want %s on %q", got.Role, got.Resource, tt.want.Role, tt.want.Resource)
+			}
+		})
+	}
+}
+
+func TestUserAccessRestrictTo(t *testing.T) {
+	access := &UserAccess{
+		Roles:       []string{"billing", "viewer"},
+		Permissions: []string{"docs:read", "invoices:read", "invoices:write"},
+		Grants: []RoleGrant{
+			{Role: "billing", Permissions: []string{"invoices:read", "invoices:write"}},
+			{Role: "viewer", Permissions: []string{"docs:read"}},
+			{Role: "editor", Resource: "docs/1", Permissions: []string{"docs:read", "docs:write"}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		scopes          []string
+		wantRoles       []string
+		wantPermissions []string
+		wantGrants      []RoleGrant
+	}{
+		{
+			name:            "области из глобальной роли и роли на ресурс",
+			scopes:          []string{"docs:read"},
+			wantRoles:       []string{"viewer"},
+			wantPermissions: []string{"docs:read"},
+			wantGrants: []RoleGrant{
+				{Role: "viewer", Permissions: []string{"docs:read"}},
+				{Role: "editor", Resource: "docs/1", Permissions: []string{"docs:read"}},
+			},
+		},
+		{
+			name:            "часть разрешений роли",
+			scopes:          []string{"invoices:read"},
+			wantRoles:       []string{"billing"},
+			wantPermissions: []string{"invoices:read"},
+			wantGrants: []RoleGrant{
+				{Role: "billing", Permissions: []string{"invoices:read"}},
+			},
+		},
+		{
+			name:   "только роль на ресурс",
+			scopes: []string{"docs:write"},
+			wantGrants: []RoleGrant{
+				{Role: "editor", Resource: "docs/1", Permissions: []string{"docs:write"}},
+			},
+		},
+		{
+			name:   "область, которой нет в правах",
+			scopes: []string{"users:delete"},
+		},
+		{
+			name: "без областей",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := access.RestrictTo(tt.scopes)
+
+			if !slices.Equal(got.Roles, tt.wantRoles) {
+				t.Errorf("RestrictTo() roles = %v, want %v", got.Roles, tt.wantRoles)
+			}
+			if !slices.Equal(got.Permissions, tt.wantPermissions) {
+				t.Errorf("RestrictTo() permissions = %v, want %v", got.Permissions, tt.wantPermissions)
+			}
+			if !slices.EqualFunc(got.Grants, tt.wantGrants, func(a, b RoleGrant) bool {
+				return a.Role == b.Role && a.Resource == b.Resource && slices.Equal(a.Permissions, b.Permissions)
+			}) {
+				t.Errorf("RestrictTo() grants = %+v, want %+v", got.Grants, tt.wantGrants)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session представляет сессию пользователя вместе с информацией об устройстве
+type Session struct {
+	UUID     string
+	UserUUID uuid.UUID
+	TenantID uuid.UUID
+	// ActiveOrganizationID организация, от имени которой работает пользователь в этой сессии.
+	// При входе это организация пользователя, переключается SwitchOrganization
+	ActiveOrganizationID uuid.UUID
+	IP                   string
+	UserAgent            string
+	ClientName           string
+	CreatedAt            time.Time
+	LastSeenAt           time.Time
+	// ExpiresAt момент, после которого сессия истекает независимо от активности
+	ExpiresAt time.Time
+}
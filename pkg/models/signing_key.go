@@ -0,0 +1,21 @@
+package models
+
+import (
+	"crypto/ed25519"
+	"time"
+)
+
+// SigningKey представляет ключ подписи access токенов.
+// PrivateKey - расшифрованная приватная часть, в базе хранится только PrivateKeyEncrypted.
+// ExpiresAt заполняется при ротации, nil означает, что ключ еще не заменен
+type SigningKey struct {
+	ID                  int64
+	KID                 string
+	Algorithm           string
+	PublicKey           ed25519.PublicKey
+	PrivateKey          ed25519.PrivateKey
+	PrivateKeyEncrypted []byte
+	CreatedAt           time.Time
+	ActivatesAt         time.Time
+	ExpiresAt           *time.Time
+}
@@ -8,13 +8,15 @@ import (
 
 // User представляет пользователя в системе
 type User struct {
-	ID           int64     `db:"id"`
-	UUID         uuid.UUID `db:"uuid"`
-	Email        string    `db:"email"`
-	Username     string    `db:"username"`
-	PasswordHash string    `db:"password_hash"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID              int64      `db:"id"`
+	UUID            uuid.UUID  `db:"uuid"`
+	TenantID        uuid.UUID  `db:"tenant_id"`
+	Email           string     `db:"email"`
+	Username        string     `db:"username"`
+	PasswordHash    string     `db:"password_hash"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
 }
 
 // CreateUserRequest запрос на создание пользователя
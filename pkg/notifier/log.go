@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/olezhek28/auth-service/pkg/logger"
+)
+
+// logNotifier пишет сообщения в лог вместо отправки. Только для локальной разработки:
+// в сообщениях есть одноразовые токены
+type logNotifier struct {
+	logger logger.Logger
+}
+
+// NewLogNotifier создает notifier, который пишет сообщения в лог
+func NewLogNotifier(logger logger.Logger) Notifier {
+	return &logNotifier{
+		logger: logger,
+	}
+}
+
+// Notify пишет сообщение в лог
+func (n *logNotifier) Notify(_ context.Context, msg Message) error {
+	n.logger.Info("notification", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryNotifier сохраняет сообщения в памяти. Используется как фейк в тестах
+type MemoryNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryNotifier создает notifier, который сохраняет сообщения в памяти
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+// Notify сохраняет сообщение
+func (n *MemoryNotifier) Notify(_ context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// Messages возвращает копию сохраненных сообщений в порядке отправки
+func (n *MemoryNotifier) Messages() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]Message(nil), n.messages...)
+}
@@ -0,0 +1,16 @@
+package notifier
+
+import "context"
+
+// Message сообщение пользователю
+type Message struct {
+	// To адрес получателя
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier доставляет сообщения пользователям
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
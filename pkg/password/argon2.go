@@ -0,0 +1,167 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2idPrefix идентификатор argon2id в начале PHC строки
+const argon2idPrefix = "$argon2id$"
+
+// Пределы параметров argon2id. Параметры проверки берутся из самого хеша,
+// поэтому без пределов испорченный хеш в базе заставил бы выделить гигабайты памяти на каждый вход
+const (
+	// maxArgon2Memory максимальный объем памяти в KiB, 1 GiB
+	maxArgon2Memory      = 1024 * 1024
+	maxArgon2Iterations  = 32
+	maxArgon2Parallelism = 16
+	minArgon2SaltLength  = 16
+	minArgon2KeyLength   = 16
+	maxArgon2SaltLength  = 64
+	maxArgon2KeyLength   = 64
+)
+
+// Argon2Params параметры argon2id
+type Argon2Params struct {
+	// Memory объем памяти в KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// argon2Hasher хеширование argon2id. Хеш хранится в формате PHC:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+type argon2Hasher struct {
+	params Argon2Params
+}
+
+// newArgon2Hasher создает argon2id хешер с заданными параметрами
+func newArgon2Hasher(params Argon2Params) (*argon2Hasher, error) {
+	if err := validateArgon2Params(params); err != nil {
+		return nil, err
+	}
+
+	return &argon2Hasher{
+		params: params,
+	}, nil
+}
+
+// validateArgon2Params проверяет, что параметры argon2id в допустимых пределах
+func validateArgon2Params(params Argon2Params) error {
+	if params.Iterations < 1 || params.Iterations > maxArgon2Iterations {
+		return fmt.Errorf("argon2 iterations must be between 1 and %d, got %d", maxArgon2Iterations, params.Iterations)
+	}
+	if params.Parallelism < 1 || params.Parallelism > maxArgon2Parallelism {
+		return fmt.Errorf("argon2 parallelism must be between 1 and %d, got %d", maxArgon2Parallelism, params.Parallelism)
+	}
+	if params.Memory < 8*uint32(params.Parallelism) {
+		return fmt.Errorf("argon2 memory must be at least 8 KiB per lane")
+	}
+	if params.Memory > maxArgon2Memory {
+		return fmt.Errorf("argon2 memory must be at most %d KiB, got %d", maxArgon2Memory, params.Memory)
+	}
+	if params.SaltLength < minArgon2SaltLength || params.SaltLength > maxArgon2SaltLength {
+		return fmt.Errorf("argon2 salt must be between %d and %d bytes, got %d",
+			minArgon2SaltLength, maxArgon2SaltLength, params.SaltLength)
+	}
+	if params.KeyLength < minArgon2KeyLength || params.KeyLength > maxArgon2KeyLength {
+		return fmt.Errorf("argon2 key must be between %d and %d bytes, got %d",
+			minArgon2KeyLength, maxArgon2KeyLength, params.KeyLength)
+	}
+	return nil
+}
+
+// Hash хеширует пароль argon2id со случайной солью
+func (h *argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return encodeArgon2Hash(h.params, salt, key), nil
+}
+
+// Verify проверяет пароль по argon2id хешу с параметрами из самого хеша
+func (h *argon2Hasher) Verify(password, encodedHash string) (bool, error) {
+	params, salt, key, err := decodeArgon2Hash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
+
+// NeedsRehash сообщает, что параметры хеша отличаются от настроенных
+func (h *argon2Hasher) NeedsRehash(encodedHash string) bool {
+	params, _, _, err := decodeArgon2Hash(encodedHash)
+	return err != nil || params != h.params
+}
+
+// Matches сообщает, что хеш получен argon2id
+func (h *argon2Hasher) Matches(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, argon2idPrefix)
+}
+
+// encodeArgon2Hash собирает PHC строку argon2id
+func encodeArgon2Hash(params Argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// decodeArgon2Hash разбирает PHC строку argon2id
+func decodeArgon2Hash(encodedHash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, fmt.Errorf("%w: malformed argon2id hash", ErrUnknownHashFormat)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: malformed argon2id version", ErrUnknownHashFormat)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported argon2id version %d", ErrUnknownHashFormat, version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: malformed argon2id parameters", ErrUnknownHashFormat)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: malformed argon2id salt", ErrUnknownHashFormat)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: malformed argon2id key", ErrUnknownHashFormat)
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	if err := validateArgon2Params(params); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrUnknownHashFormat, err)
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,67 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptPrefixes идентификаторы версий bcrypt в начале хеша
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// bcryptHasher хеширование bcrypt. Хеш хранится в традиционном формате $2a$<cost>$...,
+// который совместим с PHC и уже лежит в базе у существующих пользователей
+type bcryptHasher struct {
+	cost int
+}
+
+// newBcryptHasher создает bcrypt хешер с заданной стоимостью
+func newBcryptHasher(cost int) (*bcryptHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+
+	return &bcryptHasher{
+		cost: cost,
+	}, nil
+}
+
+// Hash хеширует пароль bcrypt
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password with bcrypt: %w", err)
+	}
+	return string(hash), nil
+}
+
+// Verify проверяет пароль по bcrypt хешу
+func (h *bcryptHasher) Verify(password, encodedHash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to verify bcrypt hash: %w", err)
+	}
+}
+
+// NeedsRehash сообщает, что стоимость хеша отличается от настроенной
+func (h *bcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	return err != nil || cost != h.cost
+}
+
+// Matches сообщает, что хеш получен bcrypt
+func (h *bcryptHasher) Matches(encodedHash string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(encodedHash, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 задан форматом списков утекших паролей, а не используется для защиты
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// breachedPrefixLength длина префикса SHA-1 в hex символах, по которому группируются хеши
+const breachedPrefixLength = 5
+
+// BreachedList локальный список утекших паролей. Хранит только SHA-1 хеши, сгруппированные
+// по первым пяти hex символам, как в k-anonymity API Have I Been Pwned
+type BreachedList struct {
+	ranges map[string]map[string]struct{}
+	size   int
+}
+
+// LoadBreachedList загружает список из файла, каждая строка которого - SHA-1 хеш пароля в hex,
+// за которым может идти :<количество утечек>, как в выгрузках Have I Been Pwned.
+// Пустые строки и строки, начинающиеся с #, пропускаются
+func LoadBreachedList(path string) (*BreachedList, error) {
+	file, err := os.Open(path) //nolint:gosec // путь задается конфигурацией сервиса
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	list := &BreachedList{
+		ranges: make(map[string]map[string]struct{}),
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("invalid SHA-1 hash on line %d of breached password list", lineNumber)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("invalid SHA-1 hash on line %d of breached password list", lineNumber)
+		}
+
+		list.add(hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+
+	return list, nil
+}
+
+// Size возвращает количество хешей в списке
+func (l *BreachedList) Size() int {
+	if l == nil {
+		return 0
+	}
+	return l.size
+}
+
+// Contains сообщает, что пароль есть в списке. Для nil списка всегда false
+func (l *BreachedList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // см. импорт
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, ok := l.ranges[hash[:breachedPrefixLength]]
+	if !ok {
+		return false
+	}
+	_, found := suffixes[hash[breachedPrefixLength:]]
+	return found
+}
+
+// add добавляет SHA-1 хеш в верхнем регистре
+func (l *BreachedList) add(hash string) {
+	prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+
+	suffixes, ok := l.ranges[prefix]
+	if !ok {
+		suffixes = make(map[string]struct{})
+		l.ranges[prefix] = suffixes
+	}
+	if _, exists := suffixes[suffix]; !exists {
+		suffixes[suffix] = struct{}{}
+		l.size++
+	}
+}
@@ -0,0 +1,109 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Алгоритмы хеширования паролей
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// ErrUnknownHashFormat хеш не распознан ни одним из поддерживаемых алгоритмов
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+// Hasher хеширует пароли и проверяет их по хешам в формате PHC
+type Hasher interface {
+	// Hash хеширует пароль предпочтительным алгоритмом
+	Hash(password string) (string, error)
+	// Verify проверяет пароль по хешу любого поддерживаемого алгоритма
+	Verify(password, encodedHash string) (bool, error)
+	// NeedsRehash сообщает, что хеш получен не предпочтительным алгоритмом или с устаревшими параметрами
+	NeedsRehash(encodedHash string) bool
+}
+
+// Config выбор алгоритма и его параметров для новых хешей
+type Config struct {
+	// Algorithm алгоритм новых хешей: bcrypt или argon2id
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// algorithmHasher хеширование одним алгоритмом
+type algorithmHasher interface {
+	Hasher
+	// Matches сообщает, что хеш получен этим алгоритмом
+	Matches(encodedHash string) bool
+}
+
+// hasher хеширует предпочтительным алгоритмом и проверяет хеши всех поддерживаемых,
+// чтобы пользователей можно было переводить на новый алгоритм при входе
+type hasher struct {
+	preferred  algorithmHasher
+	algorithms []algorithmHasher
+}
+
+// NewHasher создает хешер паролей с предпочтительным алгоритмом из конфигурации
+func NewHasher(cfg Config) (Hasher, error) {
+	bcryptHasher, err := newBcryptHasher(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+	argon2Hasher, err := newArgon2Hasher(cfg.Argon2)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &hasher{
+		algorithms: []algorithmHasher{bcryptHasher, argon2Hasher},
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmBcrypt:
+		h.preferred = bcryptHasher
+	case AlgorithmArgon2id:
+		h.preferred = argon2Hasher
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", cfg.Algorithm)
+	}
+
+	return h, nil
+}
+
+// Hash хеширует пароль предпочтительным алгоритмом
+func (h *hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify проверяет пароль по хешу любого поддерживаемого алгоритма
+func (h *hasher) Verify(password, encodedHash string) (bool, error) {
+	algorithm, err := h.algorithmOf(encodedHash)
+	if err != nil {
+		return false, err
+	}
+	return algorithm.Verify(password, encodedHash)
+}
+
+// NeedsRehash сообщает, что хеш нужно пересчитать предпочтительным алгоритмом
+func (h *hasher) NeedsRehash(encodedHash string) bool {
+	if !h.preferred.Matches(encodedHash) {
+		return true
+	}
+	return h.preferred.NeedsRehash(encodedHash)
+}
+
+// algorithmOf определяет алгоритм хеша по его префиксу
+func (h *hasher) algorithmOf(encodedHash string) (algorithmHasher, error) {
+	for _, algorithm := range h.algorithms {
+		if algorithm.Matches(encodedHash) {
+			return algorithm, nil
+		}
+	}
+
+	prefix, _, _ := strings.Cut(strings.TrimPrefix(encodedHash, "$"), "$")
+	return nil, fmt.Errorf("%w: %q", ErrUnknownHashFormat, prefix)
+}
@@ -0,0 +1,223 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2Params быстрые параметры argon2id для тестов
+var testArgon2Params = Argon2Params{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func newTestHasher(t *testing.T, algorithm string, argon2Params Argon2Params) Hasher {
+	t.Helper()
+
+	h, err := NewHasher(Config{
+		Algorithm:  algorithm,
+		BcryptCost: bcrypt.MinCost,
+		Argon2:     argon2Params,
+	})
+	if err != nil {
+		t.Fatalf("NewHasher() unexpected error: %v", err)
+	}
+	return h
+}
+
+// validArgon2Tail соль и ключ корректной длины в base64 для PHC строк в тестах
+const validArgon2Tail = "$c29tZXNhbHRzb21lc2FsdA$c29tZWtleXNvbWVrZXlzb21la2V5c29tZWtleTEy"
+
+func TestDecodeArgon2Hash(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr bool
+	}{
+		{name: "корректный хеш", hash: "$argon2id$v=19$m=64,t=1,p=1" + validArgon2Tail},
+		{name: "не хватает частей", hash: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHRzb21lc2FsdA", wantErr: true},
+		{name: "другой вариант argon2", hash: "$argon2i$v=19$m=64,t=1,p=1" + validArgon2Tail, wantErr: true},
+		{name: "испорченная версия", hash: "$argon2id$version$m=64,t=1,p=1" + validArgon2Tail, wantErr: true},
+		{name: "неподдерживаемая версия", hash: "$argon2id$v=16$m=64,t=1,p=1" + validArgon2Tail, wantErr: true},
+		{name: "испорченные параметры", hash: "$argon2id$v=19$m=64;t=1;p=1" + validArgon2Tail, wantErr: true},
+		{name: "испорченная соль", hash: "$argon2id$v=19$m=64,t=1,p=1$!!!$c29tZWtleXNvbWVrZXlzb21la2V5c29tZWtleTEy", wantErr: true},
+		{name: "испорченный ключ", hash: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHRzb21lc2FsdA$!!!", wantErr: true},
+		{name: "пустой ключ", hash: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHRzb21lc2FsdA$", wantErr: true},
+		{name: "слишком много памяти", hash: "$argon2id$v=19$m=4194304,t=1,p=1" + validArgon2Tail, wantErr: true},
+		{name: "слишком много итераций", hash: "$argon2id$v=19$m=64,t=1000,p=1" + validArgon2Tail, wantErr: true},
+		{name: "слишком много потоков", hash: "$argon2id$v=19$m=1024,t=1,p=64" + validArgon2Tail, wantErr: true},
+		{name: "переполнение потоков", hash: "$argon2id$v=19$m=64,t=1,p=300" + validArgon2Tail, wantErr: true},
+		{name: "нулевые итерации", hash: "$argon2id$v=19$m=64,t=0,p=1" + validArgon2Tail, wantErr: true},
+		{name: "нулевые потоки", hash: "$argon2id$v=19$m=64,t=1,p=0" + validArgon2Tail, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := decodeArgon2Hash(tt.hash)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownHashFormat) {
+					t.Errorf("decodeArgon2Hash() error = %v, want %v", err, ErrUnknownHashFormat)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("decodeArgon2Hash() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHasherVerify(t *testing.T) {
+	argon2Hasher := newTestHasher(t, AlgorithmArgon2id, testArgon2Params)
+	bcryptHasher := newTestHasher(t, AlgorithmBcrypt, testArgon2Params)
+
+	argon2Hash, err := argon2Hasher.Hash("correct-password")
+	if err != nil {
+		t.Fatalf("Hash() unexpected error: %v", err)
+	}
+	bcryptHash, err := bcryptHasher.Hash("correct-password")
+	if err != nil {
+		t.Fatalf("Hash() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+		wantErr  error
+	}{
+		{name: "верный пароль argon2id", password: "correct-password", hash: argon2Hash, want: true},
+		{name: "неверный пароль argon2id", password: "wrong-password", hash: argon2Hash},
+		{name: "верный пароль по старому хешу bcrypt", password: "correct-password", hash: bcryptHash, want: true},
+		{name: "неверный пароль по старому хешу bcrypt", password: "wrong-password", hash: bcryptHash},
+		{
+			name:     "неизвестный алгоритм",
+			password: "correct-password",
+			hash:     "$scrypt$ln=16,r=8,p=1$c29tZXNhbHQ$c29tZWtleQ",
+			wantErr:  ErrUnknownHashFormat,
+		},
+		{name: "пустой хеш", password: "correct-password", hash: "", wantErr: ErrUnknownHashFormat},
+		{
+			name:     "испорченный хеш argon2id",
+			password: "correct-password",
+			hash:     strings.Replace(argon2Hash, "v=19", "v=16", 1),
+			wantErr:  ErrUnknownHashFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := argon2Hasher.Verify(tt.password, tt.hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasherNeedsRehash(t *testing.T) {
+	current := newTestHasher(t, AlgorithmArgon2id, testArgon2Params)
+
+	drifted := testArgon2Params
+	drifted.Memory *= 2
+	outdated := newTestHasher(t, AlgorithmArgon2id, drifted)
+
+	shortKey := testArgon2Params
+	shortKey.KeyLength = 16
+	outdatedKey := newTestHasher(t, AlgorithmArgon2id, shortKey)
+
+	bcryptHasher := newTestHasher(t, AlgorithmBcrypt, testArgon2Params)
+
+	hash := func(h Hasher) string {
+		t.Helper()
+		encoded, err := h.Hash("password")
+		if err != nil {
+			t.Fatalf("Hash() unexpected error: %v", err)
+		}
+		return encoded
+	}
+
+	bcryptHash := hash(bcryptHasher)
+	costlyBcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost+1)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		hasher Hasher
+		hash   string
+		want   bool
+	}{
+		{name: "текущие параметры argon2id", hasher: current, hash: hash(current)},
+		{name: "изменился объем памяти", hasher: current, hash: hash(outdated), want: true},
+		{name: "изменилась длина ключа", hasher: current, hash: hash(outdatedKey), want: true},
+		{name: "переход с bcrypt на argon2id", hasher: current, hash: bcryptHash, want: true},
+		{name: "переход с argon2id на bcrypt", hasher: bcryptHasher, hash: hash(current), want: true},
+		{name: "текущая стоимость bcrypt", hasher: bcryptHasher, hash: bcryptHash},
+		{name: "изменилась стоимость bcrypt", hasher: bcryptHasher, hash: string(costlyBcryptHash), want: true},
+		{name: "испорченный хеш", hasher: current, hash: "$argon2id$v=19$garbage", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHasher(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "argon2id", cfg: Config{Algorithm: AlgorithmArgon2id, BcryptCost: bcrypt.MinCost, Argon2: testArgon2Params}},
+		{name: "bcrypt", cfg: Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost, Argon2: testArgon2Params}},
+		{
+			name:    "неизвестный алгоритм",
+			cfg:     Config{Algorithm: "scrypt", BcryptCost: bcrypt.MinCost, Argon2: testArgon2Params},
+			wantErr: true,
+		},
+		{
+			name:    "слишком низкая стоимость bcrypt",
+			cfg:     Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost - 1, Argon2: testArgon2Params},
+			wantErr: true,
+		},
+		{
+			name: "слишком много памяти argon2id",
+			cfg: Config{Algorithm: AlgorithmArgon2id, BcryptCost: bcrypt.MinCost, Argon2: Argon2Params{
+				Memory: maxArgon2Memory + 1, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "короткая соль argon2id",
+			cfg: Config{Algorithm: AlgorithmArgon2id, BcryptCost: bcrypt.MinCost, Argon2: Argon2Params{
+				Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 8, KeyLength: 32,
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHasher(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHasher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
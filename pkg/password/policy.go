@@ -0,0 +1,145 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minPersonalInfoLength минимальная длина email или имени пользователя, которую ищем в пароле.
+// Более короткие значения слишком часто встречаются в паролях случайно
+const minPersonalInfoLength = 3
+
+// PolicyConfig требования к новым паролям
+type PolicyConfig struct {
+	// MinLength минимальная длина в символах
+	MinLength int
+	// MaxLength максимальная длина в байтах, 0 - без ограничения. bcrypt учитывает только первые 72 байта
+	MaxLength     int
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MaxRepeated максимальное количество одинаковых символов подряд, 0 - без ограничения
+	MaxRepeated int
+	// ForbidPersonalInfo запрещает email и имя пользователя внутри пароля
+	ForbidPersonalInfo bool
+}
+
+// Policy проверяет новые пароли на соответствие требованиям и по списку утекших паролей
+type Policy struct {
+	cfg      PolicyConfig
+	breached *BreachedList
+}
+
+// NewPolicy создает политику паролей. breached может быть nil, если список утекших паролей не задан
+func NewPolicy(cfg PolicyConfig, breached *BreachedList) *Policy {
+	return &Policy{
+		cfg:      cfg,
+		breached: breached,
+	}
+}
+
+// Check возвращает описания всех нарушенных требований, пустой список - пароль подходит.
+// personalInfo - email и имя пользователя, которые не должны встречаться в пароле
+func (p *Policy) Check(password string, personalInfo ...string) []string {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.cfg.MinLength))
+	}
+	if p.cfg.MaxLength > 0 && len(password) > p.cfg.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes", p.cfg.MaxLength))
+	}
+
+	violations = append(violations, p.checkCharacterClasses(password)...)
+
+	if p.cfg.MaxRepeated > 0 && maxRepeatedRun(password) > p.cfg.MaxRepeated {
+		violations = append(violations,
+			fmt.Sprintf("must not repeat the same character more than %d times in a row", p.cfg.MaxRepeated))
+	}
+
+	if p.cfg.ForbidPersonalInfo && containsPersonalInfo(password, personalInfo) {
+		violations = append(violations, "must not contain the email or username")
+	}
+
+	if p.breached.Contains(password) {
+		violations = append(violations, "appears in a list of breached passwords, choose another one")
+	}
+
+	return violations
+}
+
+// checkCharacterClasses проверяет наличие обязательных классов символов
+func (p *Policy) checkCharacterClasses(password string) []string {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	var violations []string
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+	return violations
+}
+
+// maxRepeatedRun возвращает длину самой длинной серии одинаковых символов подряд
+func maxRepeatedRun(password string) int {
+	longest, current := 0, 0
+	var previous rune
+	for i, r := range []rune(password) {
+		if i > 0 && r == previous {
+			current++
+		} else {
+			current = 1
+		}
+		previous = r
+		longest = max(longest, current)
+	}
+	return longest
+}
+
+// containsPersonalInfo ищет в пароле email, его локальную часть и имя пользователя без учета регистра
+func containsPersonalInfo(password string, personalInfo []string) bool {
+	lowered := strings.ToLower(password)
+
+	for _, value := range personalInfo {
+		candidates := []string{value}
+		if local, _, found := strings.Cut(value, "@"); found {
+			candidates = append(candidates, local)
+		}
+
+		for _, candidate := range candidates {
+			candidate = strings.ToLower(strings.TrimSpace(candidate))
+			if utf8.RuneCountInString(candidate) < minPersonalInfoLength {
+				continue
+			}
+			if strings.Contains(lowered, candidate) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,205 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// breachedPasswordHash SHA-1 хеш пароля "password" в нижнем регистре, как его иногда выгружают
+const breachedPasswordHash = "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8"
+
+func writeBreachedList(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write breached password list: %v", err)
+	}
+	return path
+}
+
+func TestPolicyCheck(t *testing.T) {
+	breached, err := LoadBreachedList(writeBreachedList(t, breachedPasswordHash+":3861493\n"))
+	if err != nil {
+		t.Fatalf("LoadBreachedList() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cfg          PolicyConfig
+		password     string
+		personalInfo []string
+		want         []string
+	}{
+		{name: "пароль подходит", cfg: PolicyConfig{MinLength: 8}, password: "long enough"},
+		{
+			name:     "короткий пароль",
+			cfg:      PolicyConfig{MinLength: 8},
+			password: "short",
+			want:     []string{"must be at least 8 characters"},
+		},
+		{
+			name:     "длина считается в символах",
+			cfg:      PolicyConfig{MinLength: 6},
+			password: "пароль",
+		},
+		{
+			name:     "длинный пароль",
+			cfg:      PolicyConfig{MaxLength: 8},
+			password: "much too long",
+			want:     []string{"must be at most 8 bytes"},
+		},
+		{
+			name:     "максимальная длина считается в байтах",
+			cfg:      PolicyConfig{MaxLength: 8},
+			password: "пароль",
+			want:     []string{"must be at most 8 bytes"},
+		},
+		{
+			name:     "все классы символов",
+			cfg:      PolicyConfig{RequireLower: true, RequireUpper: true, RequireDigit: true, RequireSymbol: true},
+			password: "Passw0rd!",
+		},
+		{
+			name:     "нет ни одного класса символов",
+			cfg:      PolicyConfig{RequireLower: true, RequireUpper: true, RequireDigit: true, RequireSymbol: true},
+			password: "",
+			want: []string{
+				"must contain a lowercase letter",
+				"must contain an uppercase letter",
+				"must contain a digit",
+				"must contain a symbol",
+			},
+		},
+		{
+			name:     "пробел считается символом",
+			cfg:      PolicyConfig{RequireSymbol: true},
+			password: "correct horse",
+		},
+		{
+			name:     "допустимая серия повторов",
+			cfg:      PolicyConfig{MaxRepeated: 3},
+			password: "aaabbb",
+		},
+		{
+			name:     "длинная серия повторов",
+			cfg:      PolicyConfig{MaxRepeated: 3},
+			password: "abaaaab",
+			want:     []string{"must not repeat the same character more than 3 times in a row"},
+		},
+		{
+			name:         "email в пароле",
+			cfg:          PolicyConfig{ForbidPersonalInfo: true},
+			password:     "my-JOHN.DOE@example.com-password",
+			personalInfo: []string{"john.doe@example.com", "jdoe"},
+			want:         []string{"must not contain the email or username"},
+		},
+		{
+			name:         "локальная часть email в пароле",
+			cfg:          PolicyConfig{ForbidPersonalInfo: true},
+			password:     "john.doe-2024",
+			personalInfo: []string{"john.doe@example.com", ""},
+			want:         []string{"must not contain the email or username"},
+		},
+		{
+			name:         "имя пользователя в пароле",
+			cfg:          PolicyConfig{ForbidPersonalInfo: true},
+			password:     "hello-JDoe-42",
+			personalInfo: []string{"john.doe@example.com", "jdoe"},
+			want:         []string{"must not contain the email or username"},
+		},
+		{
+			name:         "короткое имя пользователя не проверяется",
+			cfg:          PolicyConfig{ForbidPersonalInfo: true},
+			password:     "jd-password-42",
+			personalInfo: []string{"jd@example.com", "jd"},
+		},
+		{
+			name:         "личные данные разрешены",
+			cfg:          PolicyConfig{},
+			password:     "john.doe-2024",
+			personalInfo: []string{"john.doe@example.com"},
+		},
+		{
+			name:     "утекший пароль",
+			cfg:      PolicyConfig{},
+			password: "password",
+			want:     []string{"appears in a list of breached passwords, choose another one"},
+		},
+		{
+			name:     "все нарушения сразу",
+			cfg:      PolicyConfig{MinLength: 10, RequireDigit: true, MaxRepeated: 1},
+			password: "password",
+			want: []string{
+				"must be at least 10 characters",
+				"must contain a digit",
+				"must not repeat the same character more than 1 times in a row",
+				"appears in a list of breached passwords, choose another one",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPolicy(tt.cfg, breached).Check(tt.password, tt.personalInfo...)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckWithoutBreachedList(t *testing.T) {
+	if got := NewPolicy(PolicyConfig{}, nil).Check("password"); len(got) != 0 {
+		t.Errorf("Check() = %q, want no violations", got)
+	}
+}
+
+func TestLoadBreachedList(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantSize int
+		wantErr  bool
+	}{
+		{
+			name:     "хеши с количеством утечек, комментарии и пустые строки",
+			content:  "# выгрузка\n\n" + breachedPasswordHash + ":3861493\n7C4A8D09CA3762AF61E59520943DC26494F8941B\n",
+			wantSize: 2,
+		},
+		{
+			name:     "повторяющиеся хеши в разном регистре",
+			content:  breachedPasswordHash + "\n5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8\n",
+			wantSize: 1,
+		},
+		{name: "короткий хеш", content: "5baa61e4c9b93f3f\n", wantErr: true},
+		{name: "не hex", content: "zzaa61e4c9b93f3f0682250b6cf8331b7ee68fd8\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := LoadBreachedList(writeBreachedList(t, tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("LoadBreachedList() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadBreachedList() unexpected error: %v", err)
+			}
+			if list.Size() != tt.wantSize {
+				t.Errorf("Size() = %d, want %d", list.Size(), tt.wantSize)
+			}
+			if !list.Contains("password") {
+				t.Error("Contains(password) = false, want true")
+			}
+		})
+	}
+
+	if _, err := LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadBreachedList() expected error for missing file")
+	}
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// APIKeyRepository интерфейс для работы с API ключами пользователей и сервисных аккаунтов
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, apiKey *models.APIKey) error
+	GetActiveAPIKeyByHash(ctx context.Context, keyHash string, now time.Time) (*models.APIKey, error)
+	ListAPIKeys(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		principalType models.PrincipalType,
+		principalID uuid.UUID,
+	) ([]*models.APIKey, error)
+	RevokeAPIKey(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		principalType models.PrincipalType,
+		principalID uuid.UUID,
+		keyID uuid.UUID,
+		now time.Time,
+	) (bool, error)
+	TouchAPIKey(ctx context.Context, keyID uuid.UUID, now time.Time) error
+}
+
+// apiKeyColumns колонки api_keys в порядке, ожидаемом scanAPIKey
+var apiKeyColumns = []string{
+	"id",
+	"tenant_id",
+	"principal_type",
+	"principal_id",
+	"name",
+	"prefix",
+	"key_hash",
+	"scopes",
+	"created_at",
+	"expires_at",
+	"last_used_at",
+	"revoked_at",
+}
+
+// apiKeyRepository реализация репозитория API ключей
+type apiKeyRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewAPIKeyRepository создает новый репозиторий API ключей
+func NewAPIKeyRepository(db *pgxpool.Pool) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateAPIKey сохраняет API ключ
+func (r *apiKeyRepository) CreateAPIKey(ctx context.Context, apiKey *models.APIKey) error {
+	query, args, err := r.qb.
+		Insert("api_keys").
+		Columns(
+			"id",
+			"tenant_id",
+			"principal_type",
+			"principal_id",
+			"name",
+			"prefix",
+			"key_hash",
+			"scopes",
+			"created_at",
+			"expires_at",
+		).
+		Values(
+			apiKey.ID,
+			apiKey.TenantID,
+			apiKey.PrincipalType,
+			apiKey.PrincipalID,
+			apiKey.Name,
+			apiKey.Prefix,
+			apiKey.KeyHash,
+			apiKey.Scopes,
+			apiKey.CreatedAt,
+			apiKey.ExpiresAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveAPIKeyByHash находит не отозванный и не истекший API ключ по хешу
+func (r *apiKeyRepository) GetActiveAPIKeyByHash(
+	ctx context.Context,
+	keyHash string,
+	now time.Time,
+) (*models.APIKey, error) {
+	query, args, err := r.qb.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where(squirrel.Eq{"key_hash": keyHash, "revoked_at": nil}).
+		Where(squirrel.Or{
+			squirrel.Eq{"expires_at": nil},
+			squirrel.Gt{"expires_at": now},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	apiKey, err := scanAPIKey(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// ListAPIKeys возвращает не отозванные API ключи пользователя или сервисного аккаунта, начиная с самого нового.
+// Истекшие ключи тоже возвращаются, чтобы их можно было заменить
+func (r *apiKeyRepository) ListAPIKeys(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	principalType models.PrincipalType,
+	principalID uuid.UUID,
+) ([]*models.APIKey, error) {
+	query, args, err := r.qb.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where(squirrel.Eq{
+			"tenant_id":      tenantID,
+			"principal_type": principalType,
+			"principal_id":   principalID,
+			"revoked_at":     nil,
+		}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []*models.APIKey
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey отзывает API ключ пользователя или сервисного аккаунта.
+// Возвращает false, если такого действующего ключа нет
+func (r *apiKeyRepository) RevokeAPIKey(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	principalType models.PrincipalType,
+	principalID uuid.UUID,
+	keyID uuid.UUID,
+	now time.Time,
+) (bool, error) {
+	query, args, err := r.qb.
+		Update("api_keys").
+		Set("revoked_at", now).
+		Where(squirrel.Eq{
+			"id":             keyID,
+			"tenant_id":      tenantID,
+			"principal_type": principalType,
+			"principal_id":   principalID,
+			"revoked_at":     nil,
+		}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// TouchAPIKey отмечает время последнего использования API ключа
+func (r *apiKeyRepository) TouchAPIKey(ctx context.Context, keyID uuid.UUID, now time.Time) error {
+	query, args, err := r.qb.
+		Update("api_keys").
+		Set("last_used_at", now).
+		Where(squirrel.Eq{"id": keyID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+
+	return nil
+}
+
+// scanAPIKey сканирует API ключ из строки с колонками apiKeyColumns
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.TenantID,
+		&apiKey.PrincipalType,
+		&apiKey.PrincipalID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&apiKey.Scopes,
+		&apiKey.CreatedAt,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
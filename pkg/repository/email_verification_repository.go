@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// EmailVerificationRepository интерфейс для работы с токенами подтверждения email
+type EmailVerificationRepository interface {
+	CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error
+	ConsumeEmailVerificationToken(ctx context.Context, tokenHash string, now time.Time) (models.UserRef, error)
+}
+
+// emailVerificationRepository реализация репозитория токенов подтверждения email
+type emailVerificationRepository struct {
+	tokens *oneTimeTokenTable
+}
+
+// NewEmailVerificationRepository создает новый репозиторий токенов подтверждения email
+func NewEmailVerificationRepository(db *pgxpool.Pool) EmailVerificationRepository {
+	return &emailVerificationRepository{
+		tokens: &oneTimeTokenTable{
+			db:         db,
+			qb:         squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+			table:      "email_verification_tokens",
+			errInvalid: apperrors.ErrInvalidEmailVerificationToken,
+		},
+	}
+}
+
+// CreateEmailVerificationToken сохраняет новый токен подтверждения email и удаляет
+// уже ненужные токены того же пользователя
+func (r *emailVerificationRepository) CreateEmailVerificationToken(
+	ctx context.Context,
+	token *models.EmailVerificationToken,
+) error {
+	user := models.UserRef{TenantID: token.TenantID, UserUUID: token.UserUUID}
+	id, err := r.tokens.create(ctx, token.TokenHash, user, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	token.ID = id
+	return nil
+}
+
+// ConsumeEmailVerificationToken помечает токен использованным и возвращает его пользователя
+func (r *emailVerificationRepository) ConsumeEmailVerificationToken(
+	ctx context.Context,
+	tokenHash string,
+	now time.Time,
+) (models.UserRef, error) {
+	return r.tokens.consume(ctx, tokenHash, now)
+}
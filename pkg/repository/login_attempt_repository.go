@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// LoginBlockKind причина блокировки попыток входа
+type LoginBlockKind string
+
+// Причины блокировки попыток входа
+const (
+	LoginBlockNone    LoginBlockKind = ""
+	LoginBlockBackoff LoginBlockKind = "backoff"
+	LoginBlockLockout LoginBlockKind = "lockout"
+)
+
+// LoginScope объект, для которого считаются неудачные попытки входа
+type LoginScope string
+
+// Объекты, для которых считаются неудачные попытки входа
+const (
+	LoginScopeAccount LoginScope = "account"
+	LoginScopeIP      LoginScope = "ip"
+)
+
+// LoginBlock действующая блокировка попыток входа
+type LoginBlock struct {
+	Kind       LoginBlockKind
+	RetryAfter time.Duration
+}
+
+// LoginThrottlePolicy правила блокировки для одного объекта
+type LoginThrottlePolicy struct {
+	// Window окно, в котором считаются неудачные попытки
+	Window time.Duration
+	// BackoffAfter количество неудач, после которого каждая следующая попытка откладывается
+	BackoffAfter int
+	// BackoffBase задержка после первой неудачи сверх BackoffAfter, дальше удваивается
+	BackoffBase time.Duration
+	// BackoffMax максимальная задержка
+	BackoffMax time.Duration
+	// LockoutAfter количество неудач, после которого вход блокируется на LockoutDuration
+	LockoutAfter    int
+	LockoutDuration time.Duration
+}
+
+// LoginAttemptRepository интерфейс для учета неудачных попыток входа.
+// Попытка резервируется до проверки пароля и сразу считается неудачной: проверка блокировки
+// и учет попытки атомарны, поэтому параллельные запросы не обходят задержку и блокировку
+type LoginAttemptRepository interface {
+	ReserveLoginAttempt(ctx context.Context, scope LoginScope, id string, policy LoginThrottlePolicy) (LoginBlock, bool, error)
+	ReleaseLoginAttempt(ctx context.Context, scope LoginScope, id string) error
+	ResetLoginFailures(ctx context.Context, scope LoginScope, id string) (bool, error)
+}
+
+// reserveLoginAttemptScript атомарно проверяет блокировку и, если ее нет, учитывает попытку
+// как неудачную и при необходимости блокирует следующие попытки. Счетчик живет Window с первой неудачи.
+// Возвращает {0, вид блокировки, оставшееся время}, если попытка запрещена,
+// и {1, вид блокировки, задержка}, если попытка зарезервирована и наступила блокировка следующих.
+// KEYS[1] - счетчик неудач, KEYS[2] - блокировка.
+// ARGV: окно, порог задержки, базовая задержка, максимальная задержка,
+// порог блокировки, длительность блокировки. Все длительности в миллисекундах
+var reserveLoginAttemptScript = redis.NewScript(2, `
+local blocked = redis.call("GET", KEYS[2])
+if blocked then
+	local ttl = redis.call("PTTL", KEYS[2])
+	if ttl > 0 then
+		return {0, blocked, ttl}
+	end
+end
+local failures = redis.call("INCR", KEYS[1])
+if failures == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local backoff_after = tonumber(ARGV[2])
+local lockout_after = tonumber(ARGV[5])
+local kind = ""
+local delay = 0
+if lockout_after > 0 and failures >= lockout_after then
+	kind = "lockout"
+	delay = tonumber(ARGV[6])
+elseif backoff_after > 0 and failures >= backoff_after then
+	kind = "backoff"
+	delay = math.min(tonumber(ARGV[3]) * 2 ^ (failures - backoff_after), tonumber(ARGV[4]))
+end
+if delay > 0 then
+	redis.call("SET", KEYS[2], kind, "PX", math.floor(delay))
+end
+return {1, kind, math.floor(delay)}
+`)
+
+// releaseLoginAttemptScript снимает со счетчика зарезервированную попытку, оказавшуюся удачной.
+// KEYS[1] - счетчик неудач, KEYS[2] - блокировка, ARGV[1] - снять ли блокировку
+var releaseLoginAttemptScript = redis.NewScript(2, `
+local failures = tonumber(redis.call("GET", KEYS[1]) or "0")
+if failures > 0 then
+	redis.call("DECR", KEYS[1])
+end
+if ARGV[1] == "1" then
+	redis.call("DEL", KEYS[2])
+end
+return 1
+`)
+
+// loginAttemptRepository реализация репозитория неудачных попыток входа
+type loginAttemptRepository struct {
+	pool *redis.Pool
+}
+
+// NewLoginAttemptRepository создает новый репозиторий неудачных попыток входа
+func NewLoginAttemptRepository(pool *redis.Pool) LoginAttemptRepository {
+	return &loginAttemptRepository{
+		pool: pool,
+	}
+}
+
+// loginFailuresKey возвращает ключ счетчика неудачных попыток в Redis
+func loginFailuresKey(scope LoginScope, id string) string {
+	return fmt.Sprintf("login_failures:%s:%s", scope, normalizeLoginID(scope, id))
+}
+
+// loginBlockKey возвращает ключ блокировки попыток входа в Redis
+func loginBlockKey(scope LoginScope, id string) string {
+	return fmt.Sprintf("login_block:%s:%s", scope, normalizeLoginID(scope, id))
+}
+
+// normalizeLoginID приводит email к одному виду, чтобы регистр не обходил счетчик
+func normalizeLoginID(scope LoginScope, id string) string {
+	if scope == LoginScopeAccount {
+		return strings.ToLower(strings.TrimSpace(id))
+	}
+	return id
+}
+
+// ReserveLoginAttempt проверяет блокировку и заранее учитывает попытку входа как неудачную.
+// Если попытка запрещена, возвращает false и действующую блокировку. Если разрешена - true
+// и блокировку следующих попыток, если она наступила. Удачную попытку нужно вернуть
+// через ReleaseLoginAttempt или ResetLoginFailures
+func (r *loginAttemptRepository) ReserveLoginAttempt(
+	ctx context.Context,
+	scope LoginScope,
+	id string,
+	policy LoginThrottlePolicy,
+) (LoginBlock, bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	reply, err := redis.Values(reserveLoginAttemptScript.Do(conn,
+		loginFailuresKey(scope, id),
+		loginBlockKey(scope, id),
+		policy.Window.Milliseconds(),
+		policy.BackoffAfter,
+		policy.BackoffBase.Milliseconds(),
+		policy.BackoffMax.Milliseconds(),
+		policy.LockoutAfter,
+		policy.LockoutDuration.Milliseconds(),
+	))
+	if err != nil {
+		return LoginBlock{}, false, fmt.Errorf("failed to reserve login attempt: %w", err)
+	}
+
+	var (
+		reserved bool
+		kind     string
+		delay    int64
+	)
+	if _, err := redis.Scan(reply, &reserved, &kind, &delay); err != nil {
+		return LoginBlock{}, false, fmt.Errorf("failed to scan login attempt result: %w", err)
+	}
+
+	return LoginBlock{
+		Kind:       LoginBlockKind(kind),
+		RetryAfter: time.Duration(delay) * time.Millisecond,
+	}, reserved, nil
+}
+
+// ReleaseLoginAttempt возвращает зарезервированную попытку входа, оказавшуюся удачной.
+// Для аккаунта снимается и блокировка, которую могла поставить эта попытка. Блокировка IP
+// остается, иначе вход в свой аккаунт позволял бы снимать ее между попытками перебора чужих
+func (r *loginAttemptRepository) ReleaseLoginAttempt(ctx context.Context, scope LoginScope, id string) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	if _, err := releaseLoginAttemptScript.Do(conn,
+		loginFailuresKey(scope, id),
+		loginBlockKey(scope, id),
+		scope == LoginScopeAccount,
+	); err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+
+	return nil
+}
+
+// ResetLoginFailures сбрасывает счетчик неудач и снимает блокировку.
+// Возвращает true, если было что сбрасывать
+func (r *loginAttemptRepository) ResetLoginFailures(ctx context.Context, scope LoginScope, id string) (bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	deleted, err := redis.Int(conn.Do("DEL", loginFailuresKey(scope, id), loginBlockKey(scope, id)))
+	if err != nil {
+		return false, fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return deleted > 0, nil
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// MembershipRepository интерфейс для работы с участниками организаций и приглашениями.
+// Участниками могут быть пользователи любых организаций, поэтому поиск пользователей
+// здесь не ограничен организацией, в которой они зарегистрированы
+type MembershipRepository interface {
+	GetMember(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.Member, error)
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]*models.Member, error)
+	RemoveMember(ctx context.Context, organizationID, userUUID uuid.UUID) (bool, error)
+	CreateInvitation(ctx context.Context, invitation *models.Invitation) error
+	DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error
+	GetInvitation(ctx context.Context, invitationID uuid.UUID, now time.Time) (*models.Invitation, error)
+	AcceptInvitation(ctx context.Context, invitationID, userUUID uuid.UUID, now time.Time) error
+}
+
+// memberColumns колонки участника в порядке, ожидаемом scanMember
+var memberColumns = []string{
+	"m.organization_id",
+	"m.user_uuid",
+	"u.tenant_id",
+	"u.email",
+	"u.username",
+	"COALESCE(r.name, '')",
+	"m.created_at",
+}
+
+// membershipRepository реализация репозитория участников организаций
+type membershipRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewMembershipRepository создает новый репозиторий участников организаций
+func NewMembershipRepository(db *pgxpool.Pool) MembershipRepository {
+	return &membershipRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// selectMembers возвращает запрос участников вместе с данными пользователей и ролью
+func (r *membershipRepository) selectMembers() squirrel.SelectBuilder {
+	return r.qb.
+		Select(memberColumns...).
+		From("organization_members m").
+		Join("users u ON u.uuid = m.user_uuid").
+		LeftJoin("roles r ON r.id = m.role_id")
+}
+
+// GetMember получает участника организации
+func (r *membershipRepository) GetMember(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.Member, error) {
+	query, args, err := r.selectMembers().
+		Where(squirrel.Eq{"m.organization_id": organizationID, "m.user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	member, err := scanMember(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	return member, nil
+}
+
+// ListMembers возвращает участников организации в порядке вступления
+func (r *membershipRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]*models.Member, error) {
+	query, args, err := r.selectMembers().
+		Where(squirrel.Eq{"m.organization_id": organizationID}).
+		OrderBy("m.created_at", "u.email").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*models.Member
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	return members, nil
+}
+
+// RemoveMember исключает пользователя из организации вместе со всеми ролями организации,
+// выданными ему напрямую, и принадлежащими ему сервисными аккаунтами организации с их API ключами.
+// Возвращает false, если пользователь не был участником
+func (r *membershipRepository) RemoveMember(ctx context.Context, organizationID, userUUID uuid.UUID) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query, args, err := r.qb.
+		Delete("organization_members").
+		Where(squirrel.Eq{"organization_id": organizationID, "user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	query, args, err = r.qb.
+		Delete("user_roles").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		Where("role_id IN (SELECT id FROM roles WHERE tenant_id = ?)", organizationID).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to revoke member roles: %w", err)
+	}
+
+	// Сервисные аккаунты исключенного участника действовали бы от его имени и после исключения
+	ownedServiceAccounts := squirrel.Eq{"tenant_id": organizationID, "owner_user_uuid": userUUID}
+
+	query, args, err = r.qb.
+		Delete("api_keys").
+		Where(squirrel.Eq{"tenant_id": organizationID, "principal_type": models.PrincipalTypeServiceAccount}).
+		Where(squirrel.Expr("principal_id IN (?)", squirrel.Select("id").From("service_accounts").Where(ownedServiceAccounts))).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to delete member service account api keys: %w", err)
+	}
+
+	query, args, err = r.qb.
+		Delete("service_accounts").
+		Where(ownedServiceAccounts).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to delete member service accounts: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CreateInvitation сохраняет приглашение. Роль ищется в организации приглашения
+func (r *membershipRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) error {
+	var roleID *int64
+	if invitation.Role != "" {
+		query, args, err := r.qb.
+			Select("id").
+			From("roles").
+			Where(squirrel.Eq{"tenant_id": invitation.OrganizationID, "name": invitation.Role}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build select query: %w", err)
+		}
+
+		var id int64
+		if err := r.db.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperrors.ErrRoleNotFound
+			}
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		roleID = &id
+	}
+
+	query, args, err := r.qb.
+		Insert("organization_invitations").
+		Columns("id", "organization_id", "email", "role_id", "invited_by", "created_at", "expires_at").
+		Values(
+			invitation.ID,
+			invitation.OrganizationID,
+			invitation.Email,
+			roleID,
+			invitation.InvitedBy,
+			invitation.CreatedAt,
+			invitation.ExpiresAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteInvitation удаляет приглашение
+func (r *membershipRepository) DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	query, args, err := r.qb.
+		Delete("organization_invitations").
+		Where(squirrel.Eq{"id": invitationID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetInvitation получает приглашение, которое еще не принято и не истекло
+func (r *membershipRepository) GetInvitation(
+	ctx context.Context,
+	invitationID uuid.UUID,
+	now time.Time,
+) (*models.Invitation, error) {
+	query, args, err := r.qb.
+		Select(
+			"i.id",
+			"i.organization_id",
+			"i.email",
+			"COALESCE(r.name, '')",
+			"i.invited_by",
+			"i.created_at",
+			"i.expires_at",
+		).
+		From("organization_invitations i").
+		LeftJoin("roles r ON r.id = i.role_id").
+		Where(squirrel.Eq{"i.id": invitationID, "i.accepted_at": nil}).
+		Where(squirrel.Gt{"i.expires_at": now}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var invitation models.Invitation
+	err = r.db.QueryRow(ctx, query, args...).Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.InvitedBy,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrInvalidInvitation
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return &invitation, nil
+}
+
+// AcceptInvitation атомарно отмечает приглашение принятым и делает пользователя участником организации.
+// Если пользователь уже участник, роль из приглашения заменяет прежнюю
+func (r *membershipRepository) AcceptInvitation(
+	ctx context.Context,
+	invitationID uuid.UUID,
+	userUUID uuid.UUID,
+	now time.Time,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Условие на accepted_at исключает повторное принятие при конкурентных запросах
+	query, args, err := r.qb.
+		Update("organization_invitations").
+		Set("accepted_at", now).
+		Set("accepted_by", userUUID).
+		Where(squirrel.Eq{"id": invitationID, "accepted_at": nil}).
+		Where(squirrel.Gt{"expires_at": now}).
+		Suffix("RETURNING organization_id, role_id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	var organizationID uuid.UUID
+	var roleID *int64
+	if err := tx.QueryRow(ctx, query, args...).Scan(&organizationID, &roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.ErrInvalidInvitation
+		}
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	query, args, err = r.qb.
+		Insert("organization_members").
+		Columns("organization_id", "user_uuid", "role_id", "created_at").
+		Values(organizationID, userUUID, roleID, now).
+		Suffix("ON CONFLICT (organization_id, user_uuid) DO UPDATE SET " +
+			"role_id = COALESCE(EXCLUDED.role_id, organization_members.role_id)").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// scanMember сканирует участника из строки с колонками memberColumns
+func scanMember(row pgx.Row) (*models.Member, error) {
+	var member models.Member
+	err := row.Scan(
+		&member.OrganizationID,
+		&member.UserUUID,
+		&member.TenantID,
+		&member.Email,
+		&member.Username,
+		&member.Role,
+		&member.JoinedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// MFAChallengeRepository интерфейс для работы с входами, ожидающими второго фактора
+type MFAChallengeRepository interface {
+	CreateMFAChallenge(ctx context.Context, challenge *models.MFAChallenge) error
+	GetMFAChallenge(ctx context.Context, challengeID string) (*models.MFAChallenge, error)
+	RecordMFAFailure(ctx context.Context, challengeID string, maxAttempts int) error
+	ConsumeMFAChallenge(ctx context.Context, challengeID string) (bool, error)
+}
+
+// recordMFAFailureScript увеличивает счетчик неудачных попыток и удаляет challenge,
+// когда попытки исчерпаны. ARGV[1] - максимальное количество попыток
+var recordMFAFailureScript = redis.NewScript(1, `
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+local attempts = redis.call("HINCRBY", KEYS[1], "attempts", 1)
+if attempts >= tonumber(ARGV[1]) then
+	redis.call("DEL", KEYS[1])
+end
+return attempts
+`)
+
+// mfaChallengeHash представление challenge в Redis hash
+type mfaChallengeHash struct {
+	UserUUID    string `redis:"user_uuid"`
+	TenantID    string `redis:"tenant_id"`
+	IssueTokens bool   `redis:"issue_tokens"`
+	ExpiresAt   int64  `redis:"expires_at"`
+	Attempts    int    `redis:"attempts"`
+}
+
+// mfaChallengeRepository реализация репозитория challenge второго фактора
+type mfaChallengeRepository struct {
+	pool *redis.Pool
+}
+
+// NewMFAChallengeRepository создает новый репозиторий challenge второго фактора
+func NewMFAChallengeRepository(pool *redis.Pool) MFAChallengeRepository {
+	return &mfaChallengeRepository{
+		pool: pool,
+	}
+}
+
+// mfaChallengeKey возвращает ключ challenge в Redis
+func mfaChallengeKey(challengeID string) string {
+	return fmt.Sprintf("mfa_challenge:%s", challengeID)
+}
+
+// CreateMFAChallenge сохраняет challenge до challenge.ExpiresAt и заполняет его идентификатор
+func (r *mfaChallengeRepository) CreateMFAChallenge(ctx context.Context, challenge *models.MFAChallenge) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	challenge.ID = uuid.New().String()
+	key := mfaChallengeKey(challenge.ID)
+
+	hash := mfaChallengeHash{
+		UserUUID:    challenge.UserUUID.String(),
+		TenantID:    challenge.TenantID.String(),
+		IssueTokens: challenge.IssueTokens,
+		ExpiresAt:   challenge.ExpiresAt.Unix(),
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("failed to create mfa challenge: %w", err)
+	}
+	_ = conn.Send("HSET", redis.Args{}.Add(key).AddFlat(&hash)...)
+	_ = conn.Send("EXPIREAT", key, hash.ExpiresAt)
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to create mfa challenge: %w", err)
+	}
+
+	return nil
+}
+
+// GetMFAChallenge получает challenge по идентификатору
+func (r *mfaChallengeRepository) GetMFAChallenge(ctx context.Context, challengeID string) (*models.MFAChallenge, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.Values(conn.Do("HGETALL", mfaChallengeKey(challengeID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, apperrors.ErrMFAChallengeNotFound
+	}
+
+	var hash mfaChallengeHash
+	if err := redis.ScanStruct(values, &hash); err != nil {
+		return nil, fmt.Errorf("failed to scan mfa challenge: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(hash.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user UUID in mfa challenge: %w", err)
+	}
+	// Challenge, созданный до появления организаций, не привязан к организации и недействителен
+	tenantID, err := uuid.Parse(hash.TenantID)
+	if err != nil {
+		return nil, apperrors.ErrMFAChallengeNotFound
+	}
+
+	return &models.MFAChallenge{
+		ID:          challengeID,
+		UserUUID:    userUUID,
+		TenantID:    tenantID,
+		IssueTokens: hash.IssueTokens,
+		ExpiresAt:   time.Unix(hash.ExpiresAt, 0),
+	}, nil
+}
+
+// RecordMFAFailure учитывает неверный код. После maxAttempts неудач challenge удаляется
+func (r *mfaChallengeRepository) RecordMFAFailure(ctx context.Context, challengeID string, maxAttempts int) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	if _, err := recordMFAFailureScript.Do(conn, mfaChallengeKey(challengeID), maxAttempts); err != nil {
+		return fmt.Errorf("failed to record mfa failure: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeMFAChallenge удаляет challenge после успешной проверки второго фактора.
+// Возвращает false, если challenge уже использован параллельным запросом или истек
+func (r *mfaChallengeRepository) ConsumeMFAChallenge(ctx context.Context, challengeID string) (bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	deleted, err := redis.Int(conn.Do("DEL", mfaChallengeKey(challengeID)))
+	if err != nil {
+		return false, fmt.Errorf("failed to consume mfa challenge: %w", err)
+	}
+
+	return deleted > 0, nil
+}
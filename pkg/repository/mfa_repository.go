@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// MFARepository интерфейс для работы с TOTP и кодами восстановления
+type MFARepository interface {
+	GetTOTP(ctx context.Context, userUUID uuid.UUID) (*models.TOTP, error)
+	SaveUnconfirmedTOTP(ctx context.Context, totp *models.TOTP) error
+	ConfirmTOTP(ctx context.Context, userUUID uuid.UUID, confirmedAt time.Time, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userUUID uuid.UUID, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userUUID uuid.UUID, recoveryCodeHashes []string, createdAt time.Time) error
+	UseRecoveryCode(ctx context.Context, userUUID uuid.UUID, codeHash string, now time.Time) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userUUID uuid.UUID) (int, error)
+	DeleteTOTP(ctx context.Context, userUUID uuid.UUID) error
+}
+
+// mfaRepository реализация репозитория TOTP и кодов восстановления
+type mfaRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewMFARepository создает новый репозиторий TOTP и кодов восстановления
+func NewMFARepository(db *pgxpool.Pool) MFARepository {
+	return &mfaRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// GetTOTP получает TOTP пользователя, в том числе неподтвержденный
+func (r *mfaRepository) GetTOTP(ctx context.Context, userUUID uuid.UUID) (*models.TOTP, error) {
+	query, args, err := r.qb.
+		Select("user_uuid", "secret_encrypted", "confirmed_at", "last_used_step", "created_at").
+		From("user_totp").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var totp models.TOTP
+	err = r.db.QueryRow(ctx, query, args...).Scan(
+		&totp.UserUUID,
+		&totp.SecretEncrypted,
+		&totp.ConfirmedAt,
+		&totp.LastUsedStep,
+		&totp.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTOTPNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get TOTP: %w", err)
+	}
+
+	return &totp, nil
+}
+
+// SaveUnconfirmedTOTP сохраняет новый неподтвержденный TOTP, заменяя предыдущий неподтвержденный.
+// Подтвержденный TOTP не заменяется, в этом случае возвращается ErrTOTPAlreadyEnabled
+func (r *mfaRepository) SaveUnconfirmedTOTP(ctx context.Context, totp *models.TOTP) error {
+	query, args, err := r.qb.
+		Insert("user_totp").
+		Columns("user_uuid", "secret_encrypted", "created_at").
+		Values(totp.UserUUID, totp.SecretEncrypted, totp.CreatedAt).
+		Suffix(`ON CONFLICT (user_uuid) DO UPDATE
+			SET secret_encrypted = EXCLUDED.secret_encrypted, created_at = EXCLUDED.created_at, last_used_step = 0
+			WHERE user_totp.confirmed_at IS NULL`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save TOTP: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTOTPAlreadyEnabled
+	}
+
+	return nil
+}
+
+// ConfirmTOTP подтверждает TOTP, запоминает использованный при подтверждении шаг
+// и заменяет коды восстановления пользователя
+func (r *mfaRepository) ConfirmTOTP(
+	ctx context.Context,
+	userUUID uuid.UUID,
+	confirmedAt time.Time,
+	step int64,
+	recoveryCodeHashes []string,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query, args, err := r.qb.
+		Update("user_totp").
+		Set("confirmed_at", confirmedAt).
+		Set("last_used_step", step).
+		Where(squirrel.Eq{"user_uuid": userUUID, "confirmed_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to confirm TOTP: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTOTPAlreadyEnabled
+	}
+
+	if err := r.replaceRecoveryCodes(ctx, tx, userUUID, recoveryCodeHashes, confirmedAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep отмечает временной шаг использованным.
+// Возвращает false, если этот или более поздний шаг уже использован
+func (r *mfaRepository) UseTOTPStep(ctx context.Context, userUUID uuid.UUID, step int64) (bool, error) {
+	query, args, err := r.qb.
+		Update("user_totp").
+		Set("last_used_step", step).
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		Where(squirrel.NotEq{"confirmed_at": nil}).
+		Where(squirrel.Lt{"last_used_step": step}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to use TOTP step: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReplaceRecoveryCodes заменяет все коды восстановления пользователя новыми
+func (r *mfaRepository) ReplaceRecoveryCodes(
+	ctx context.Context,
+	userUUID uuid.UUID,
+	recoveryCodeHashes []string,
+	createdAt time.Time,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := r.replaceRecoveryCodes(ctx, tx, userUUID, recoveryCodeHashes, createdAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode отмечает код восстановления использованным.
+// Возвращает false, если код не найден или уже использован
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userUUID uuid.UUID, codeHash string, now time.Time) (bool, error) {
+	query, args, err := r.qb.
+		Update("user_recovery_codes").
+		Set("used_at", now).
+		Where(squirrel.Eq{"user_uuid": userUUID, "code_hash": codeHash, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CountRecoveryCodes возвращает количество неиспользованных кодов восстановления пользователя
+func (r *mfaRepository) CountRecoveryCodes(ctx context.Context, userUUID uuid.UUID) (int, error) {
+	query, args, err := r.qb.
+		Select("COUNT(*)").
+		From("user_recovery_codes").
+		Where(squirrel.Eq{"user_uuid": userUUID, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteTOTP отключает TOTP пользователя вместе с кодами восстановления
+func (r *mfaRepository) DeleteTOTP(ctx context.Context, userUUID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, table := range []string{"user_recovery_codes", "user_totp"} {
+		query, args, err := r.qb.
+			Delete(table).
+			Where(squirrel.Eq{"user_uuid": userUUID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete from %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// replaceRecoveryCodes заменяет коды восстановления пользователя внутри транзакции
+func (r *mfaRepository) replaceRecoveryCodes(
+	ctx context.Context,
+	tx pgx.Tx,
+	userUUID uuid.UUID,
+	recoveryCodeHashes []string,
+	createdAt time.Time,
+) error {
+	query, args, err := r.qb.
+		Delete("user_recovery_codes").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if len(recoveryCodeHashes) == 0 {
+		return nil
+	}
+
+	insert := r.qb.
+		Insert("user_recovery_codes").
+		Columns("user_uuid", "code_hash", "created_at")
+	for _, codeHash := range recoveryCodeHashes {
+		insert = insert.Values(userUUID, codeHash, createdAt)
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// OAuthCodeRepository интерфейс для работы с кодами авторизации OAuth 2.0
+type OAuthCodeRepository interface {
+	CreateAuthorizationCode(ctx context.Context, code *models.OAuthAuthorizationCode) error
+	ConsumeAuthorizationCode(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error)
+}
+
+// oauthCodeHash представление кода авторизации в Redis hash
+type oauthCodeHash struct {
+	ClientID      string `redis:"client_id"`
+	RedirectURI   string `redis:"redirect_uri"`
+	UserUUID      string `redis:"user_uuid"`
+	TenantID      string `redis:"tenant_id"`
+	Scope         string `redis:"scope"`
+	CodeChallenge string `redis:"code_challenge"`
+	Nonce         string `redis:"nonce"`
+	AuthTime      int64  `redis:"auth_time"`
+	ExpiresAt     int64  `redis:"expires_at"`
+}
+
+// oauthCodeRepository реализация репозитория кодов авторизации
+type oauthCodeRepository struct {
+	pool *redis.Pool
+}
+
+// NewOAuthCodeRepository создает новый репозиторий кодов авторизации
+func NewOAuthCodeRepository(pool *redis.Pool) OAuthCodeRepository {
+	return &oauthCodeRepository{
+		pool: pool,
+	}
+}
+
+// oauthCodeKey возвращает ключ кода авторизации в Redis
+func oauthCodeKey(codeHash string) string {
+	return fmt.Sprintf("oauth_code:%s", codeHash)
+}
+
+// CreateAuthorizationCode сохраняет код авторизации до code.ExpiresAt
+func (r *oauthCodeRepository) CreateAuthorizationCode(ctx context.Context, code *models.OAuthAuthorizationCode) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	key := oauthCodeKey(code.CodeHash)
+
+	hash := oauthCodeHash{
+		ClientID:      code.ClientID.String(),
+		RedirectURI:   code.RedirectURI,
+		UserUUID:      code.UserUUID.String(),
+		TenantID:      code.TenantID.String(),
+		Scope:         strings.Join(code.Scopes, " "),
+		CodeChallenge: code.CodeChallenge,
+		Nonce:         code.Nonce,
+		AuthTime:      code.AuthTime.Unix(),
+		ExpiresAt:     code.ExpiresAt.Unix(),
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("failed to create authorization code: %w", err)
+	}
+	_ = conn.Send("HSET", redis.Args{}.Add(key).AddFlat(&hash)...)
+	_ = conn.Send("EXPIREAT", key, hash.ExpiresAt)
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeAuthorizationCode атомарно получает и удаляет код авторизации, так что код
+// можно обменять только один раз
+func (r *oauthCodeRepository) ConsumeAuthorizationCode(
+	ctx context.Context,
+	codeHash string,
+) (*models.OAuthAuthorizationCode, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	key := oauthCodeKey(codeHash)
+
+	if err := conn.Send("MULTI"); err != nil {
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+	_ = conn.Send("HGETALL", key)
+	_ = conn.Send("DEL", key)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+
+	values, err := redis.Values(replies[0], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, apperrors.ErrInvalidGrant
+	}
+
+	var hash oauthCodeHash
+	if err := redis.ScanStruct(values, &hash); err != nil {
+		return nil, fmt.Errorf("failed to scan authorization code: %w", err)
+	}
+
+	clientID, err := uuid.Parse(hash.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client ID in authorization code: %w", err)
+	}
+	userUUID, err := uuid.Parse(hash.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user UUID in authorization code: %w", err)
+	}
+	tenantID, err := uuid.Parse(hash.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID in authorization code: %w", err)
+	}
+
+	return &models.OAuthAuthorizationCode{
+		CodeHash:      codeHash,
+		ClientID:      clientID,
+		RedirectURI:   hash.RedirectURI,
+		UserUUID:      userUUID,
+		TenantID:      tenantID,
+		Scopes:        strings.Fields(hash.Scope),
+		CodeChallenge: hash.CodeChallenge,
+		Nonce:         hash.Nonce,
+		AuthTime:      time.Unix(hash.AuthTime, 0),
+		ExpiresAt:     time.Unix(hash.ExpiresAt, 0),
+	}, nil
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// OAuthRepository интерфейс для работы с OAuth клиентами и согласиями пользователей
+type OAuthRepository interface {
+	CreateOAuthClient(ctx context.Context, client *models.OAuthClient) error
+	GetOAuthClient(ctx context.Context, clientID uuid.UUID) (*models.OAuthClient, error)
+	DeleteOAuthClient(ctx context.Context, tenantID, clientID uuid.UUID) (bool, error)
+	GetOAuthConsent(ctx context.Context, userUUID, clientID uuid.UUID) (*models.OAuthConsent, error)
+	SaveOAuthConsent(ctx context.Context, consent *models.OAuthConsent) error
+	ListOAuthConsents(ctx context.Context, userUUID uuid.UUID) ([]*models.OAuthConsent, error)
+	DeleteOAuthConsent(ctx context.Context, userUUID, clientID uuid.UUID) (bool, error)
+}
+
+// oauthClientColumns колонки oauth_clients в порядке, ожидаемом scanOAuthClient
+var oauthClientColumns = []string{
+	"id",
+	"tenant_id",
+	"name",
+	"COALESCE(secret_hash, '')",
+	"redirect_uris",
+	"grant_types",
+	"scopes",
+	"first_party",
+	"service_account_id",
+	"created_at",
+}
+
+// oauthConsentColumns колонки oauth_consents в порядке, ожидаемом scanOAuthConsent
+var oauthConsentColumns = []string{
+	"user_uuid",
+	"client_id",
+	"scopes",
+	"created_at",
+	"updated_at",
+}
+
+// oauthRepository реализация репозитория OAuth клиентов
+type oauthRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewOAuthRepository создает новый репозиторий OAuth клиентов
+func NewOAuthRepository(db *pgxpool.Pool) OAuthRepository {
+	return &oauthRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateOAuthClient регистрирует OAuth клиента
+func (r *oauthRepository) CreateOAuthClient(ctx context.Context, client *models.OAuthClient) error {
+	var secretHash *string
+	if client.SecretHash != "" {
+		secretHash = &client.SecretHash
+	}
+
+	query, args, err := r.qb.
+		Insert("oauth_clients").
+		Columns(
+			"id",
+			"tenant_id",
+			"name",
+			"secret_hash",
+			"redirect_uris",
+			"grant_types",
+			"scopes",
+			"first_party",
+			"service_account_id",
+			"created_at",
+		).
+		Values(
+			client.ID,
+			client.TenantID,
+			client.Name,
+			secretHash,
+			client.RedirectURIs,
+			client.GrantTypes,
+			client.Scopes,
+			client.FirstParty,
+			client.ServiceAccountID,
+			client.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create oauth client: %w", err)
+	}
+
+	return nil
+}
+
+// GetOAuthClient получает OAuth клиента по client_id
+func (r *oauthRepository) GetOAuthClient(ctx context.Context, clientID uuid.UUID) (*models.OAuthClient, error) {
+	query, args, err := r.qb.
+		Select(oauthClientColumns...).
+		From("oauth_clients").
+		Where(squirrel.Eq{"id": clientID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	client, err := scanOAuthClient(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrOAuthClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	return client, nil
+}
+
+// DeleteOAuthClient удаляет OAuth клиента организации вместе с согласиями на его доступ.
+// Возвращает false, если такого клиента нет
+func (r *oauthRepository) DeleteOAuthClient(ctx context.Context, tenantID, clientID uuid.UUID) (bool, error) {
+	query, args, err := r.qb.
+		Delete("oauth_clients").
+		Where(squirrel.Eq{"id": clientID, "tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetOAuthConsent получает согласие пользователя на доступ клиента
+func (r *oauthRepository) GetOAuthConsent(
+	ctx context.Context,
+	userUUID uuid.UUID,
+	clientID uuid.UUID,
+) (*models.OAuthConsent, error) {
+	query, args, err := r.qb.
+		Select(oauthConsentColumns...).
+		From("oauth_consents").
+		Where(squirrel.Eq{"user_uuid": userUUID, "client_id": clientID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	consent, err := scanOAuthConsent(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrOAuthConsentNotFound
+		}
+		return nil, fmt.Errorf("failed to get oauth consent: %w", err)
+	}
+
+	return consent, nil
+}
+
+// SaveOAuthConsent сохраняет согласие пользователя. Повторное согласие заменяет области действия
+func (r *oauthRepository) SaveOAuthConsent(ctx context.Context, consent *models.OAuthConsent) error {
+	query, args, err := r.qb.
+		Insert("oauth_consents").
+		Columns(oauthConsentColumns...).
+		Values(consent.UserUUID, consent.ClientID, consent.Scopes, consent.CreatedAt, consent.UpdatedAt).
+		Suffix("ON CONFLICT (user_uuid, client_id) DO UPDATE SET " +
+			"scopes = EXCLUDED.scopes, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save oauth consent: %w", err)
+	}
+
+	return nil
+}
+
+// ListOAuthConsents возвращает согласия пользователя, начиная с последнего измененного
+func (r *oauthRepository) ListOAuthConsents(ctx context.Context, userUUID uuid.UUID) ([]*models.OAuthConsent, error) {
+	query, args, err := r.qb.
+		Select(oauthConsentColumns...).
+		From("oauth_consents").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		OrderBy("updated_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+	defer rows.Close()
+
+	var consents []*models.OAuthConsent
+	for rows.Next() {
+		consent, err := scanOAuthConsent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan oauth consent: %w", err)
+		}
+		consents = append(consents, consent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+
+	return consents, nil
+}
+
+// DeleteOAuthConsent отзывает согласие пользователя. Возвращает false, если согласия не было
+func (r *oauthRepository) DeleteOAuthConsent(ctx context.Context, userUUID, clientID uuid.UUID) (bool, error) {
+	query, args, err := r.qb.
+		Delete("oauth_consents").
+		Where(squirrel.Eq{"user_uuid": userUUID, "client_id": clientID}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete oauth consent: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// scanOAuthClient сканирует OAuth клиента из строки с колонками oauthClientColumns
+func scanOAuthClient(row pgx.Row) (*models.OAuthClient, error) {
+	var client models.OAuthClient
+	err := row.Scan(
+		&client.ID,
+		&client.TenantID,
+		&client.Name,
+		&client.SecretHash,
+		&client.RedirectURIs,
+		&client.GrantTypes,
+		&client.Scopes,
+		&client.FirstParty,
+		&client.ServiceAccountID,
+		&client.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+// scanOAuthConsent сканирует согласие из строки с колонками oauthConsentColumns
+func scanOAuthConsent(row pgx.Row) (*models.OAuthConsent, error) {
+	var consent models.OAuthConsent
+	err := row.Scan(
+		&consent.UserUUID,
+		&consent.ClientID,
+		&consent.Scopes,
+		&consent.CreatedAt,
+		&consent.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
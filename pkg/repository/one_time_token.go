@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// oneTimeTokenTable таблица одноразовых токенов пользователя с колонками
+// token_hash, user_uuid, tenant_id, created_at, expires_at и used_at
+type oneTimeTokenTable struct {
+	db    *pgxpool.Pool
+	qb    squirrel.StatementBuilderType
+	table string
+	// errInvalid возвращается, если токен не найден, истек или уже использован
+	errInvalid error
+}
+
+// create сохраняет новый токен и удаляет уже ненужные токены того же пользователя
+func (t *oneTimeTokenTable) create(
+	ctx context.Context,
+	tokenHash string,
+	user models.UserRef,
+	createdAt time.Time,
+	expiresAt time.Time,
+) (int64, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Удаляем использованные и истекшие токены пользователя
+	query, args, err := t.qb.
+		Delete(t.table).
+		Where(squirrel.Eq{"user_uuid": user.UserUUID}).
+		Where(squirrel.Or{
+			squirrel.NotEq{"used_at": nil},
+			squirrel.LtOrEq{"expires_at": createdAt},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build delete query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to delete stale tokens from %s: %w", t.table, err)
+	}
+
+	query, args, err = t.qb.
+		Insert(t.table).
+		Columns("token_hash", "user_uuid", "tenant_id", "created_at", "expires_at").
+		Values(tokenHash, user.UserUUID, user.TenantID, createdAt, expiresAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	if err := tx.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to create token in %s: %w", t.table, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// lookup возвращает пользователя действующего токена, не используя его
+func (t *oneTimeTokenTable) lookup(ctx context.Context, tokenHash string, now time.Time) (models.UserRef, error) {
+	query, args, err := t.qb.
+		Select("user_uuid", "tenant_id").
+		From(t.table).
+		Where(squirrel.Eq{"token_hash": tokenHash, "used_at": nil}).
+		Where(squirrel.Gt{"expires_at": now}).
+		ToSql()
+	if err != nil {
+		return models.UserRef{}, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var user models.UserRef
+	if err := t.db.QueryRow(ctx, query, args...).Scan(&user.UserUUID, &user.TenantID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserRef{}, t.errInvalid
+		}
+		return models.UserRef{}, fmt.Errorf("failed to get token from %s: %w", t.table, err)
+	}
+
+	return user, nil
+}
+
+// consume помечает токен использованным и возвращает его пользователя.
+// Остальные неиспользованные токены пользователя тоже становятся недействительными
+func (t *oneTimeTokenTable) consume(ctx context.Context, tokenHash string, now time.Time) (models.UserRef, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return models.UserRef{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Условие на used_at делает использование токена атомарным при параллельных запросах
+	query, args, err := t.qb.
+		Update(t.table).
+		Set("used_at", now).
+		Where(squirrel.Eq{"token_hash": tokenHash, "used_at": nil}).
+		Where(squirrel.Gt{"expires_at": now}).
+		Suffix("RETURNING user_uuid, tenant_id").
+		ToSql()
+	if err != nil {
+		return models.UserRef{}, fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	var user models.UserRef
+	if err := tx.QueryRow(ctx, query, args...).Scan(&user.UserUUID, &user.TenantID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserRef{}, t.errInvalid
+		}
+		return models.UserRef{}, fmt.Errorf("failed to consume token from %s: %w", t.table, err)
+	}
+
+	query, args, err = t.qb.
+		Update(t.table).
+		Set("used_at", now).
+		Where(squirrel.Eq{"user_uuid": user.UserUUID, "used_at": nil}).
+		ToSql()
+	if err != nil {
+		return models.UserRef{}, fmt.Errorf("failed to build update query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return models.UserRef{}, fmt.Errorf("failed to invalidate tokens in %s: %w", t.table, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.UserRef{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return user, nil
+}
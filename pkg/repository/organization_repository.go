@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// OrganizationRepository интерфейс для работы с организациями
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, organization *models.Organization) error
+	GetOrganizationBySlug(ctx context.Context, slug string) (*models.Organization, error)
+	GetOrganizationByID(ctx context.Context, organizationID uuid.UUID) (*models.Organization, error)
+}
+
+// organizationRepository реализация репозитория организаций
+type organizationRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewOrganizationRepository создает новый репозиторий организаций
+func NewOrganizationRepository(db *pgxpool.Pool) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateOrganization создает организацию
+func (r *organizationRepository) CreateOrganization(ctx context.Context, organization *models.Organization) error {
+	query, args, err := r.qb.
+		Insert("organizations").
+		Columns("id", "slug", "name", "created_at").
+		Values(organization.ID, organization.Slug, organization.Name, organization.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return apperrors.ErrOrganizationAlreadyExists
+		}
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganizationBySlug получает организацию по slug
+func (r *organizationRepository) GetOrganizationBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	return r.getOrganization(ctx, squirrel.Eq{"slug": slug})
+}
+
+// GetOrganizationByID получает организацию по идентификатору
+func (r *organizationRepository) GetOrganizationByID(
+	ctx context.Context,
+	organizationID uuid.UUID,
+) (*models.Organization, error) {
+	return r.getOrganization(ctx, squirrel.Eq{"id": organizationID})
+}
+
+// getOrganization получает организацию по условию
+func (r *organizationRepository) getOrganization(ctx context.Context, where squirrel.Eq) (*models.Organization, error) {
+	query, args, err := r.qb.
+		Select("id", "slug", "name", "created_at").
+		From("organizations").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var organization models.Organization
+	err = r.db.QueryRow(ctx, query, args...).Scan(
+		&organization.ID,
+		&organization.Slug,
+		&organization.Name,
+		&organization.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return &organization, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// PasswordResetRepository интерфейс для работы с токенами сброса пароля
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error
+	GetPasswordResetTokenUser(ctx context.Context, tokenHash string, now time.Time) (models.UserRef, error)
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string, now time.Time) (models.UserRef, error)
+}
+
+// passwordResetRepository реализация репозитория токенов сброса пароля
+type passwordResetRepository struct {
+	tokens *oneTimeTokenTable
+}
+
+// NewPasswordResetRepository создает новый репозиторий токенов сброса пароля
+func NewPasswordResetRepository(db *pgxpool.Pool) PasswordResetRepository {
+	return &passwordResetRepository{
+		tokens: &oneTimeTokenTable{
+			db:         db,
+			qb:         squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+			table:      "password_reset_tokens",
+			errInvalid: apperrors.ErrInvalidPasswordResetToken,
+		},
+	}
+}
+
+// CreatePasswordResetToken сохраняет новый токен сброса пароля и удаляет
+// уже ненужные токены того же пользователя
+func (r *passwordResetRepository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	user := models.UserRef{TenantID: token.TenantID, UserUUID: token.UserUUID}
+	id, err := r.tokens.create(ctx, token.TokenHash, user, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	token.ID = id
+	return nil
+}
+
+// GetPasswordResetTokenUser возвращает пользователя действующего токена, не используя его.
+// Позволяет проверить новый пароль до того, как токен будет потрачен
+func (r *passwordResetRepository) GetPasswordResetTokenUser(
+	ctx context.Context,
+	tokenHash string,
+	now time.Time,
+) (models.UserRef, error) {
+	return r.tokens.lookup(ctx, tokenHash, now)
+}
+
+// ConsumePasswordResetToken помечает токен использованным и возвращает его пользователя.
+// Остальные неиспользованные токены пользователя тоже становятся недействительными
+func (r *passwordResetRepository) ConsumePasswordResetToken(
+	ctx context.Context,
+	tokenHash string,
+	now time.Time,
+) (models.UserRef, error) {
+	return r.tokens.consume(ctx, tokenHash, now)
+}
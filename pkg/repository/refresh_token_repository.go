@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// RefreshTokenRepository интерфейс для работы с refresh токенами
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	RotateRefreshToken(
+		ctx context.Context,
+		oldTokenHash string,
+		clientID uuid.UUID,
+		newToken *models.RefreshToken,
+		now time.Time,
+		maxFamilyLifetime time.Duration,
+	) error
+	RevokeRefreshToken(ctx context.Context, tokenHash string, clientID uuid.UUID) (bool, error)
+	RevokeUserRefreshTokens(ctx context.Context, userUUID uuid.UUID) (int, error)
+	RevokeUserClientRefreshTokens(ctx context.Context, userUUID, clientID uuid.UUID) (int, error)
+}
+
+// Результаты rotateRefreshTokenScript
+const (
+	rotateResultNotFound = 0
+	rotateResultOK       = 1
+	rotateResultReused   = 2
+)
+
+// rotateRefreshTokenScript атомарно помечает старый токен использованным и сохраняет новый.
+// Повторное предъявление уже использованного токена означает его кражу,
+// поэтому в этом случае удаляется все семейство токенов.
+// KEYS[1] - старый токен, KEYS[2] - семейство, KEYS[3] - новый токен, KEYS[4] - индекс семейств пользователя,
+// KEYS[5] - индекс семейств пользователя у клиента, продлевается только для токенов OAuth клиентов.
+// ARGV[1] - user_uuid, ARGV[2] - family_id, ARGV[3] - expires_at нового токена в unix секундах,
+// ARGV[4] - tenant_id, ARGV[5] - client_id, ARGV[6] - scope, ARGV[7] - family_created_at в unix секундах
+var rotateRefreshTokenScript = redis.NewScript(5, `
+local used = redis.call("HGET", KEYS[1], "used")
+if not used then
+	return 0
+end
+if redis.call("EXISTS", KEYS[2]) == 0 then
+	return 0
+end
+if used == "1" then
+	redis.call("DEL", KEYS[2])
+	return 2
+end
+redis.call("HSET", KEYS[1], "used", "1")
+redis.call("HSET", KEYS[3], "user_uuid", ARGV[1], "tenant_id", ARGV[4], "family_id", ARGV[2], "expires_at", ARGV[3], "used", "0",
+	"client_id", ARGV[5], "scope", ARGV[6], "family_created_at", ARGV[7])
+redis.call("EXPIREAT", KEYS[3], ARGV[3])
+redis.call("EXPIREAT", KEYS[2], ARGV[3])
+redis.call("EXPIREAT", KEYS[4], ARGV[3])
+if ARGV[5] ~= "" then
+	redis.call("EXPIREAT", KEYS[5], ARGV[3])
+end
+return 1
+`)
+
+// refreshTokenHash представление refresh токена в Redis hash
+type refreshTokenHash struct {
+	UserUUID string `redis:"user_uuid"`
+	TenantID string `redis:"tenant_id"`
+	FamilyID string `redis:"family_id"`
+	// ClientID пустой у токенов, выданных при входе в сам сервис
+	ClientID string `redis:"client_id"`
+	// Scope области действия через пробел, как в OAuth 2.0
+	Scope     string `redis:"scope"`
+	ExpiresAt int64  `redis:"expires_at"`
+	// FamilyCreatedAt нулевой у токенов, выданных до ограничения времени жизни семейства
+	FamilyCreatedAt int64 `redis:"family_created_at"`
+	Used            bool  `redis:"used"`
+}
+
+// refreshTokenClientID возвращает client_id для хранения в Redis. uuid.Nil хранится пустой строкой
+func refreshTokenClientID(clientID uuid.UUID) string {
+	if clientID == uuid.Nil {
+		return ""
+	}
+	return clientID.String()
+}
+
+// refreshTokenRepository реализация репозитория refresh токенов
+type refreshTokenRepository struct {
+	pool *redis.Pool
+}
+
+// NewRefreshTokenRepository создает новый репозиторий refresh токенов
+func NewRefreshTokenRepository(pool *redis.Pool) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		pool: pool,
+	}
+}
+
+// refreshTokenKey возвращает ключ refresh токена в Redis
+func refreshTokenKey(tokenHash string) string {
+	return fmt.Sprintf("refresh_token:%s", tokenHash)
+}
+
+// refreshFamilyKey возвращает ключ семейства refresh токенов в Redis
+func refreshFamilyKey(familyID string) string {
+	return fmt.Sprintf("refresh_family:%s", familyID)
+}
+
+// userRefreshFamiliesKey возвращает ключ индекса семейств refresh токенов пользователя в Redis
+func userRefreshFamiliesKey(userUUID uuid.UUID) string {
+	return fmt.Sprintf("user_refresh_families:%s", userUUID)
+}
+
+// userClientRefreshFamiliesKey возвращает ключ индекса семейств refresh токенов,
+// выданных OAuth клиенту от имени пользователя, в Redis
+func userClientRefreshFamiliesKey(userUUID, clientID uuid.UUID) string {
+	return fmt.Sprintf("user_client_refresh_families:%s:%s", userUUID, clientID)
+}
+
+// CreateRefreshToken сохраняет первый refresh токен нового семейства
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	key := refreshTokenKey(token.TokenHash)
+	familyKey := refreshFamilyKey(token.FamilyID.String())
+	indexKey := userRefreshFamiliesKey(token.UserUUID)
+	expiresAt := token.ExpiresAt.Unix()
+
+	hash := refreshTokenHash{
+		UserUUID:        token.UserUUID.String(),
+		TenantID:        token.TenantID.String(),
+		FamilyID:        token.FamilyID.String(),
+		ClientID:        refreshTokenClientID(token.ClientID),
+		Scope:           strings.Join(token.Scopes, " "),
+		ExpiresAt:       expiresAt,
+		FamilyCreatedAt: token.FamilyCreatedAt.Unix(),
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	_ = conn.Send("HSET", redis.Args{}.Add(key).AddFlat(&hash)...)
+	_ = conn.Send("EXPIREAT", key, expiresAt)
+	_ = conn.Send("SET", familyKey, token.UserUUID.String(), "EXAT", expiresAt)
+	_ = conn.Send("SADD", indexKey, token.FamilyID.String())
+	_ = conn.Send("EXPIREAT", indexKey, expiresAt)
+	if token.ClientID != uuid.Nil {
+		clientIndexKey := userClientRefreshFamiliesKey(token.UserUUID, token.ClientID)
+		_ = conn.Send("SADD", clientIndexKey, token.FamilyID.String())
+		_ = conn.Send("EXPIREAT", clientIndexKey, expiresAt)
+	}
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken заменяет старый refresh токен новым в том же семействе.
+// Токен, выданный другому клиенту, считается недействительным и не используется.
+// Семейство старше maxFamilyLifetime больше не ротируется, а срок нового токена
+// не выходит за этот предел. Заполняет у нового токена UUID пользователя, организацию,
+// идентификатор семейства, время его начала и области действия
+func (r *refreshTokenRepository) RotateRefreshToken(
+	ctx context.Context,
+	oldTokenHash string,
+	clientID uuid.UUID,
+	newToken *models.RefreshToken,
+	now time.Time,
+	maxFamilyLifetime time.Duration,
+) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	oldKey := refreshTokenKey(oldTokenHash)
+
+	values, err := redis.Values(conn.Do("HGETALL", oldKey))
+	if err != nil {
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if len(values) == 0 {
+		return apperrors.ErrInvalidRefreshToken
+	}
+
+	var old refreshTokenHash
+	if err := redis.ScanStruct(values, &old); err != nil {
+		return fmt.Errorf("failed to scan refresh token: %w", err)
+	}
+	if old.ClientID != refreshTokenClientID(clientID) {
+		return apperrors.ErrInvalidRefreshToken
+	}
+
+	newToken.ClientID = clientID
+	newToken.Scopes = strings.Fields(old.Scope)
+	newToken.UserUUID, err = uuid.Parse(old.UserUUID)
+	if err != nil {
+		return fmt.Errorf("invalid user UUID in refresh token: %w", err)
+	}
+	// Токены, выданные до появления организаций, не привязаны к организации и недействительны
+	newToken.TenantID, err = uuid.Parse(old.TenantID)
+	if err != nil {
+		return apperrors.ErrInvalidRefreshToken
+	}
+	newToken.FamilyID, err = uuid.Parse(old.FamilyID)
+	if err != nil {
+		return fmt.Errorf("invalid family ID in refresh token: %w", err)
+	}
+
+	if !limitToFamilyLifetime(newToken, old.FamilyCreatedAt, now, maxFamilyLifetime) {
+		return apperrors.ErrInvalidRefreshToken
+	}
+
+	result, err := redis.Int(rotateRefreshTokenScript.Do(conn,
+		oldKey,
+		refreshFamilyKey(old.FamilyID),
+		refreshTokenKey(newToken.TokenHash),
+		userRefreshFamiliesKey(newToken.UserUUID),
+		userClientRefreshFamiliesKey(newToken.UserUUID, clientID),
+		old.UserUUID,
+		old.FamilyID,
+		newToken.ExpiresAt.Unix(),
+		old.TenantID,
+		old.ClientID,
+		old.Scope,
+		newToken.FamilyCreatedAt.Unix(),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	switch result {
+	case rotateResultOK:
+		return nil
+	case rotateResultReused:
+		return apperrors.ErrRefreshTokenReused
+	case rotateResultNotFound:
+		return apperrors.ErrInvalidRefreshToken
+	default:
+		return fmt.Errorf("unexpected refresh token rotation result: %d", result)
+	}
+}
+
+// limitToFamilyLifetime заполняет время начала семейства у нового токена и ограничивает срок токена
+// максимальным временем жизни семейства. Возвращает false, если семейство уже прожило этот срок.
+// Семействам, начатым до ограничения времени жизни, срок отсчитывается от первой ротации
+func limitToFamilyLifetime(
+	newToken *models.RefreshToken,
+	familyCreatedAt int64,
+	now time.Time,
+	maxFamilyLifetime time.Duration,
+) bool {
+	newToken.FamilyCreatedAt = now
+	if familyCreatedAt > 0 {
+		newToken.FamilyCreatedAt = time.Unix(familyCreatedAt, 0)
+	}
+
+	familyExpiresAt := newToken.FamilyCreatedAt.Add(maxFamilyLifetime)
+	if !familyExpiresAt.After(now) {
+		return false
+	}
+	if newToken.ExpiresAt.After(familyExpiresAt) {
+		newToken.ExpiresAt = familyExpiresAt
+	}
+
+	return true
+}
+
+// RevokeRefreshToken отзывает семейство, которому принадлежит refresh токен клиента.
+// Возвращает false, если токен не найден или выдан другому клиенту
+func (r *refreshTokenRepository) RevokeRefreshToken(
+	ctx context.Context,
+	tokenHash string,
+	clientID uuid.UUID,
+) (bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.Values(conn.Do("HGETALL", refreshTokenKey(tokenHash)))
+	if err != nil {
+		return false, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if len(values) == 0 {
+		return false, nil
+	}
+
+	var hash refreshTokenHash
+	if err := redis.ScanStruct(values, &hash); err != nil {
+		return false, fmt.Errorf("failed to scan refresh token: %w", err)
+	}
+	if hash.ClientID != refreshTokenClientID(clientID) {
+		return false, nil
+	}
+
+	// Без семейства токен недействителен, как и после обнаружения повторного использования
+	deleted, err := redis.Int(conn.Do("DEL", refreshFamilyKey(hash.FamilyID)))
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return deleted > 0, nil
+}
+
+// RevokeUserRefreshTokens отзывает все семейства refresh токенов пользователя
+// и возвращает количество отозванных
+func (r *refreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userUUID uuid.UUID) (int, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userRefreshFamiliesKey(userUUID)
+
+	familyIDs, err := redis.Strings(conn.Do("SMEMBERS", indexKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list refresh token families: %w", err)
+	}
+
+	if len(familyIDs) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]any, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		keys = append(keys, refreshFamilyKey(familyID))
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	_ = conn.Send("DEL", keys...)
+	_ = conn.Send("DEL", indexKey)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	revoked, err := redis.Int(replies[0], nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// RevokeUserClientRefreshTokens отзывает семейства refresh токенов, выданных OAuth клиенту
+// от имени пользователя, и возвращает количество отозванных
+func (r *refreshTokenRepository) RevokeUserClientRefreshTokens(
+	ctx context.Context,
+	userUUID uuid.UUID,
+	clientID uuid.UUID,
+) (int, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userClientRefreshFamiliesKey(userUUID, clientID)
+
+	familyIDs, err := redis.Strings(conn.Do("SMEMBERS", indexKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list refresh token families: %w", err)
+	}
+
+	if len(familyIDs) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]any, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		keys = append(keys, refreshFamilyKey(familyID))
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	_ = conn.Send("DEL", keys...)
+	_ = conn.Send("DEL", indexKey)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	revoked, err := redis.Int(replies[0], nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+func TestLimitToFamilyLifetime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	const (
+		ttl         = 30 * 24 * time.Hour
+		maxLifetime = 90 * 24 * time.Hour
+	)
+
+	tests := []struct {
+		name            string
+		familyCreatedAt time.Time
+		wantOK          bool
+		wantCreatedAt   time.Time
+		wantExpiresAt   time.Time
+	}{
+		{
+			name:            "молодое семейство",
+			familyCreatedAt: now.Add(-24 * time.Hour),
+			wantOK:          true,
+			wantCreatedAt:   now.Add(-24 * time.Hour),
+			wantExpiresAt:   now.Add(ttl),
+		},
+		{
+			name:            "срок токена упирается в срок семейства",
+			familyCreatedAt: now.Add(-80 * 24 * time.Hour),
+			wantOK:          true,
+			wantCreatedAt:   now.Add(-80 * 24 * time.Hour),
+			wantExpiresAt:   now.Add(10 * 24 * time.Hour),
+		},
+		{
+			name:            "семейство прожило максимальный срок",
+			familyCreatedAt: now.Add(-maxLifetime),
+		},
+		{
+			name:            "семейство старше максимального срока",
+			familyCreatedAt: now.Add(-maxLifetime - time.Hour),
+		},
+		{
+			name:          "семейство без времени начала",
+			wantOK:        true,
+			wantCreatedAt: now,
+			wantExpiresAt: now.Add(ttl),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var familyCreatedAt int64
+			if !tt.familyCreatedAt.IsZero() {
+				familyCreatedAt = tt.familyCreatedAt.Unix()
+			}
+			newToken := &models.RefreshToken{ExpiresAt: now.Add(ttl)}
+
+			ok := limitToFamilyLifetime(newToken, familyCreatedAt, now, maxLifetime)
+			if ok != tt.wantOK {
+				t.Fatalf("limitToFamilyLifetime() = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !newToken.FamilyCreatedAt.Equal(tt.wantCreatedAt) {
+				t.Errorf("FamilyCreatedAt = %s, want %s", newToken.FamilyCreatedAt, tt.wantCreatedAt)
+			}
+			if !newToken.ExpiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("ExpiresAt = %s, want %s", newToken.ExpiresAt, tt.wantExpiresAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// RoleRepository интерфейс для работы с ролями, разрешениями и ролями пользователей.
+// Роли ищутся только в организации tenantID, разрешения общие для всех организаций.
+// Права пользователя в организации складываются из выданных ему ролей и роли участника организации
+type RoleRepository interface {
+	CreateRole(ctx context.Context, role *models.Role) error
+	GrantRole(ctx context.Context, tenantID, userUUID uuid.UUID, roleName, resource string) (bool, error)
+	RevokeRole(ctx context.Context, tenantID, userUUID uuid.UUID, roleName, resource string) (bool, error)
+	GetRolePermissions(ctx context.Context, tenantID uuid.UUID, roleName string) ([]string, error)
+	GetUserAccess(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.UserAccess, error)
+}
+
+// roleRepository реализация репозитория ролей
+type roleRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewRoleRepository создает новый репозиторий ролей
+func NewRoleRepository(db *pgxpool.Pool) RoleRepository {
+	return &roleRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateRole создает роль с разрешениями в организации role.TenantID. Разрешения, которых еще нет, создаются
+func (r *roleRepository) CreateRole(ctx context.Context, role *models.Role) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query, args, err := r.qb.
+		Insert("roles").
+		Columns("tenant_id", "name", "description", "created_at").
+		Values(role.TenantID, role.Name, role.Description, role.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if err := tx.QueryRow(ctx, query, args...).Scan(&role.ID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return apperrors.ErrRoleAlreadyExists
+		}
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+
+	if len(role.Permissions) > 0 {
+		permissionIDs, err := r.upsertPermissions(ctx, tx, role.Permissions, role.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		insert := r.qb.
+			Insert("role_permissions").
+			Columns("role_id", "permission_id")
+		for _, permissionID := range permissionIDs {
+			insert = insert.Values(role.ID, permissionID)
+		}
+
+		query, args, err = insert.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to grant permissions to role: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// upsertPermissions создает недостающие разрешения и возвращает идентификаторы всех переданных
+func (r *roleRepository) upsertPermissions(
+	ctx context.Context,
+	tx pgx.Tx,
+	permissions []string,
+	createdAt time.Time,
+) ([]int64, error) {
+	insert := r.qb.
+		Insert("permissions").
+		Columns("name", "created_at")
+	for _, permission := range permissions {
+		insert = insert.Values(permission, createdAt)
+	}
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул и уже существующие разрешения
+	query, args, err := insert.
+		Suffix("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create permissions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan permission: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to create permissions: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GrantRole выдает роль пользователю глобально или, если resource не пустой, на ресурс.
+// Возвращает false, если роль уже была выдана
+func (r *roleRepository) GrantRole(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	userUUID uuid.UUID,
+	roleName string,
+	resource string,
+) (bool, error) {
+	roleID, err := r.getRoleID(ctx, tenantID, roleName)
+	if err != nil {
+		return false, err
+	}
+
+	query, args, err := r.qb.
+		Insert("user_roles").
+		Columns("user_uuid", "role_id", "resource").
+		Values(userUUID, roleID, resource).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RevokeRole отзывает роль, выданную пользователю глобально или на ресурс.
+// Возвращает false, если такой роли у пользователя не было
+func (r *roleRepository) RevokeRole(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	userUUID uuid.UUID,
+	roleName string,
+	resource string,
+) (bool, error) {
+	roleID, err := r.getRoleID(ctx, tenantID, roleName)
+	if err != nil {
+		return false, err
+	}
+
+	query, args, err := r.qb.
+		Delete("user_roles").
+		Where(squirrel.Eq{"user_uuid": userUUID, "role_id": roleID, "resource": resource}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetRolePermissions возвращает отсортированные разрешения роли организации
+func (r *roleRepository) GetRolePermissions(ctx context.Context, tenantID uuid.UUID, roleName string) ([]string, error) {
+	roleID, err := r.getRoleID(ctx, tenantID, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := r.qb.
+		Select("p.name").
+		From("role_permissions rp").
+		Join("permissions p ON p.id = rp.permission_id").
+		Where(squirrel.Eq{"rp.role_id": roleID}).
+		OrderBy("p.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	defer rows.Close()
+
+	var permissions []string
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, fmt.Errorf("failed to scan role permission: %w", err)
+		}
+		permissions = append(permissions, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// GetUserAccess возвращает роли пользователя в организации и все разрешения, которые они дают.
+// Роль участника организации считается выданной глобально.
+// Списки ролей и разрешений отсортированы и не содержат повторов
+func (r *roleRepository) GetUserAccess(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.UserAccess, error) {
+	// UNION убирает повтор, если роль участника выдана пользователю еще и напрямую
+	grants := squirrel.
+		Select("role_id", "resource").
+		From("user_roles").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		Suffix(
+			"UNION SELECT role_id, '' FROM organization_members "+
+				"WHERE organization_id = ? AND user_uuid = ? AND role_id IS NOT NULL",
+			organizationID, userUUID,
+		)
+
+	query, args, err := r.qb.
+		Select("r.name", "ur.resource", "p.name").
+		FromSelect(grants, "ur").
+		Join("roles r ON r.id = ur.role_id").
+		LeftJoin("role_permissions rp ON rp.role_id = r.id").
+		LeftJoin("permissions p ON p.id = rp.permission_id").
+		Where(squirrel.Eq{"r.tenant_id": organizationID}).
+		OrderBy("r.name", "ur.resource", "p.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user access: %w", err)
+	}
+	defer rows.Close()
+
+	access := &models.UserAccess{}
+	for rows.Next() {
+		var roleName, resource string
+		var permission *string
+		if err := rows.Scan(&roleName, &resource, &permission); err != nil {
+			return nil, fmt.Errorf("failed to scan user access: %w", err)
+		}
+
+		// Строки упорядочены по роли и ресурсу, поэтому выдача роли - это серия подряд идущих строк
+		last := len(access.Grants) - 1
+		if last < 0 || access.Grants[last].Role != roleName || access.Grants[last].Resource != resource {
+			access.Grants = append(access.Grants, models.RoleGrant{
+				Role:     roleName,
+				Resource: resource,
+			})
+			last++
+		}
+		if permission == nil {
+			continue
+		}
+		access.Grants[last].Permissions = append(access.Grants[last].Permissions, *permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get user access: %w", err)
+	}
+
+	for _, grant := range access.Grants {
+		if grant.Resource != "" {
+			continue
+		}
+		access.Roles = append(access.Roles, grant.Role)
+		access.Permissions = append(access.Permissions, grant.Permissions...)
+	}
+	slices.Sort(access.Permissions)
+	access.Permissions = slices.Compact(access.Permissions)
+
+	return access, nil
+}
+
+// getRoleID возвращает идентификатор роли организации по имени
+func (r *roleRepository) getRoleID(ctx context.Context, tenantID uuid.UUID, roleName string) (int64, error) {
+	query, args, err := r.qb.
+		Select("id").
+		From("roles").
+		Where(squirrel.Eq{"tenant_id": tenantID, "name": roleName}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var roleID int64
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apperrors.ErrRoleNotFound
+		}
+		return 0, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return roleID, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// ServiceAccountRepository интерфейс для работы с сервисными аккаунтами
+type ServiceAccountRepository interface {
+	CreateServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) error
+	GetServiceAccount(ctx context.Context, tenantID, serviceAccountID uuid.UUID) (*models.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context, tenantID uuid.UUID) ([]*models.ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, tenantID, serviceAccountID uuid.UUID) (bool, error)
+}
+
+// serviceAccountColumns колонки service_accounts в порядке, ожидаемом scanServiceAccount
+var serviceAccountColumns = []string{
+	"id",
+	"tenant_id",
+	"name",
+	"description",
+	"owner_user_uuid",
+	"created_at",
+}
+
+// serviceAccountRepository реализация репозитория сервисных аккаунтов
+type serviceAccountRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewServiceAccountRepository создает новый репозиторий сервисных аккаунтов
+func NewServiceAccountRepository(db *pgxpool.Pool) ServiceAccountRepository {
+	return &serviceAccountRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// CreateServiceAccount создает сервисный аккаунт. Название уникально в пределах организации
+func (r *serviceAccountRepository) CreateServiceAccount(
+	ctx context.Context,
+	serviceAccount *models.ServiceAccount,
+) error {
+	query, args, err := r.qb.
+		Insert("service_accounts").
+		Columns(serviceAccountColumns...).
+		Values(
+			serviceAccount.ID,
+			serviceAccount.TenantID,
+			serviceAccount.Name,
+			serviceAccount.Description,
+			serviceAccount.OwnerUserUUID,
+			serviceAccount.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return apperrors.ErrServiceAccountAlreadyExists
+		}
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return nil
+}
+
+// GetServiceAccount получает сервисный аккаунт организации
+func (r *serviceAccountRepository) GetServiceAccount(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	serviceAccountID uuid.UUID,
+) (*models.ServiceAccount, error) {
+	query, args, err := r.qb.
+		Select(serviceAccountColumns...).
+		From("service_accounts").
+		Where(squirrel.Eq{"id": serviceAccountID, "tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	serviceAccount, err := scanServiceAccount(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return serviceAccount, nil
+}
+
+// ListServiceAccounts возвращает сервисные аккаунты организации в порядке названий
+func (r *serviceAccountRepository) ListServiceAccounts(
+	ctx context.Context,
+	tenantID uuid.UUID,
+) ([]*models.ServiceAccount, error) {
+	query, args, err := r.qb.
+		Select(serviceAccountColumns...).
+		From("service_accounts").
+		Where(squirrel.Eq{"tenant_id": tenantID}).
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var serviceAccounts []*models.ServiceAccount
+	for rows.Next() {
+		serviceAccount, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service account: %w", err)
+		}
+		serviceAccounts = append(serviceAccounts, serviceAccount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	return serviceAccounts, nil
+}
+
+// DeleteServiceAccount удаляет сервисный аккаунт вместе с его API ключами.
+// Возвращает false, если такого аккаунта нет
+func (r *serviceAccountRepository) DeleteServiceAccount(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	serviceAccountID uuid.UUID,
+) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query, args, err := r.qb.
+		Delete("service_accounts").
+		Where(squirrel.Eq{"id": serviceAccountID, "tenant_id": tenantID}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete service account: %w", err)
+	}
+
+	query, args, err = r.qb.
+		Delete("api_keys").
+		Where(squirrel.Eq{
+			"tenant_id":      tenantID,
+			"principal_type": models.PrincipalTypeServiceAccount,
+			"principal_id":   serviceAccountID,
+		}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to delete service account api keys: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// scanServiceAccount сканирует сервисный аккаунт из строки с колонками serviceAccountColumns
+func scanServiceAccount(row pgx.Row) (*models.ServiceAccount, error) {
+	var serviceAccount models.ServiceAccount
+	err := row.Scan(
+		&serviceAccount.ID,
+		&serviceAccount.TenantID,
+		&serviceAccount.Name,
+		&serviceAccount.Description,
+		&serviceAccount.OwnerUserUUID,
+		&serviceAccount.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceAccount, nil
+}
@@ -2,20 +2,80 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
 	"github.com/google/uuid"
 
 	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
 )
 
 // SessionRepository интерфейс для работы с сессиями
 type SessionRepository interface {
-	CreateSession(ctx context.Context, userUUID uuid.UUID, ttl time.Duration) (string, error)
-	GetSession(ctx context.Context, sessionUUID string) (uuid.UUID, error)
+	CreateSession(ctx context.Context, session *models.Session, idleTimeout time.Duration) error
+	GetSession(ctx context.Context, sessionUUID string) (*models.Session, error)
+	TouchSession(ctx context.Context, sessionUUID string, now time.Time, idleTimeout time.Duration) (*models.Session, error)
+	ListUserSessions(ctx context.Context, userUUID uuid.UUID) ([]*models.Session, error)
+	SetActiveOrganization(ctx context.Context, sessionUUID string, organizationID uuid.UUID) error
 	DeleteSession(ctx context.Context, sessionUUID string) error
+	DeleteUserSessions(ctx context.Context, userUUID uuid.UUID) (int, error)
+	DeleteOtherUserSessions(ctx context.Context, userUUID uuid.UUID, keepSessionUUID string) (int, error)
+}
+
+// touchSessionScript атомарно читает сессию, обновляет last_seen_at и продлевает TTL
+// на время бездействия, но не дальше абсолютного срока жизни сессии.
+// Выполнение одним скриптом исключает гонку между чтением сессии и EXPIRE.
+// Сессии, сохраненные до перехода на hash, считаются истекшими и удаляются.
+// ARGV[1] - текущее время в unix секундах, ARGV[2] - время бездействия в секундах
+var touchSessionScript = redis.NewScript(1, `
+local key_type = redis.call("TYPE", KEYS[1]).ok
+if key_type ~= "hash" then
+	if key_type ~= "none" then
+		redis.call("DEL", KEYS[1])
+	end
+	return {}
+end
+local expires_at = redis.call("HGET", KEYS[1], "expires_at")
+if not expires_at then
+	return {}
+end
+local remaining = tonumber(expires_at) - tonumber(ARGV[1])
+if remaining <= 0 then
+	redis.call("DEL", KEYS[1])
+	return {}
+end
+redis.call("HSET", KEYS[1], "last_seen_at", ARGV[1])
+redis.call("EXPIRE", KEYS[1], math.min(tonumber(ARGV[2]), remaining))
+return redis.call("HGETALL", KEYS[1])
+`)
+
+// setActiveOrganizationScript меняет активную организацию, только если сессия еще существует,
+// чтобы не воссоздать истекшую сессию без TTL. ARGV[1] - идентификатор организации
+var setActiveOrganizationScript = redis.NewScript(1, `
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], "active_organization_id", ARGV[1])
+return 1
+`)
+
+// sessionHash представление сессии в Redis hash
+type sessionHash struct {
+	UserUUID string `redis:"user_uuid"`
+	TenantID string `redis:"tenant_id"`
+	// ActiveOrganizationID пустой у сессий, созданных до появления участников организаций
+	ActiveOrganizationID string `redis:"active_organization_id"`
+	IP                   string `redis:"ip"`
+	UserAgent            string `redis:"user_agent"`
+	ClientName           string `redis:"client_name"`
+	CreatedAt            int64  `redis:"created_at"`
+	LastSeenAt           int64  `redis:"last_seen_at"`
+	ExpiresAt            int64  `redis:"expires_at"`
 }
 
 // sessionRepository реализация репозитория сессий
@@ -30,44 +90,160 @@ func NewSessionRepository(pool *redis.Pool) SessionRepository {
 	}
 }
 
-// CreateSession создает новую сессию для пользователя
-func (r *sessionRepository) CreateSession(ctx context.Context, userUUID uuid.UUID, ttl time.Duration) (string, error) {
+// sessionKey возвращает ключ сессии в Redis
+func sessionKey(sessionUUID string) string {
+	return fmt.Sprintf("session:%s", sessionUUID)
+}
+
+// userSessionsKey возвращает ключ индекса сессий пользователя в Redis
+func userSessionsKey(userUUID uuid.UUID) string {
+	return fmt.Sprintf("user_sessions:%s", userUUID)
+}
+
+// CreateSession создает новую сессию для пользователя и заполняет ее UUID.
+// Сессия живет idleTimeout, но не дольше session.ExpiresAt
+func (r *sessionRepository) CreateSession(ctx context.Context, session *models.Session, idleTimeout time.Duration) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	session.UUID = uuid.New().String()
+	key := sessionKey(session.UUID)
+	indexKey := userSessionsKey(session.UserUUID)
+
+	hash := sessionHash{
+		UserUUID:             session.UserUUID.String(),
+		TenantID:             session.TenantID.String(),
+		ActiveOrganizationID: session.ActiveOrganizationID.String(),
+		IP:                   session.IP,
+		UserAgent:            session.UserAgent,
+		ClientName:           session.ClientName,
+		CreatedAt:            session.CreatedAt.Unix(),
+		LastSeenAt:           session.LastSeenAt.Unix(),
+		ExpiresAt:            session.ExpiresAt.Unix(),
+	}
+
+	lifetime := time.Until(session.ExpiresAt)
+	ttl := min(idleTimeout, lifetime)
+
+	// Сохраняем сессию и добавляем ее в индекс сессий пользователя.
+	// Индекс живет до абсолютного срока самой свежей сессии
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	_ = conn.Send("HSET", redis.Args{}.Add(key).AddFlat(&hash)...)
+	_ = conn.Send("EXPIRE", key, int(ttl.Seconds()))
+	_ = conn.Send("SADD", indexKey, session.UUID)
+	_ = conn.Send("EXPIRE", indexKey, int(lifetime.Seconds()))
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSession получает сессию по ее UUID
+func (r *sessionRepository) GetSession(ctx context.Context, sessionUUID string) (*models.Session, error) {
 	conn := r.pool.Get()
 	defer conn.Close()
 
-	sessionUUID := uuid.New().String()
-	sessionKey := fmt.Sprintf("session:%s", sessionUUID)
+	values, err := redis.Values(conn.Do("HGETALL", sessionKey(sessionUUID)))
+	if err != nil {
+		if isWrongTypeError(err) {
+			return nil, apperrors.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return parseSession(sessionUUID, values)
+}
 
-	// Сохраняем сессию с указанным TTL
-	_, err := conn.Do("SETEX", sessionKey, int(ttl.Seconds()), userUUID.String())
+// TouchSession отмечает активность сессии, продлевает ее на время бездействия и возвращает сессию
+func (r *sessionRepository) TouchSession(
+	ctx context.Context,
+	sessionUUID string,
+	now time.Time,
+	idleTimeout time.Duration,
+) (*models.Session, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.Values(touchSessionScript.Do(conn, sessionKey(sessionUUID), now.Unix(), int(idleTimeout.Seconds())))
 	if err != nil {
-		return "", fmt.Errorf("failed to create session: %w", err)
+		return nil, fmt.Errorf("failed to touch session: %w", err)
 	}
 
-	return sessionUUID, nil
+	return parseSession(sessionUUID, values)
 }
 
-// GetSession получает UUID пользователя по UUID сессии
-func (r *sessionRepository) GetSession(ctx context.Context, sessionUUID string) (uuid.UUID, error) {
+// ListUserSessions возвращает активные сессии пользователя, начиная с самой свежей
+func (r *sessionRepository) ListUserSessions(ctx context.Context, userUUID uuid.UUID) ([]*models.Session, error) {
 	conn := r.pool.Get()
 	defer conn.Close()
 
-	sessionKey := fmt.Sprintf("session:%s", sessionUUID)
+	indexKey := userSessionsKey(userUUID)
 
-	userUUIDStr, err := redis.String(conn.Do("GET", sessionKey))
+	sessionUUIDs, err := redis.Strings(conn.Do("SMEMBERS", indexKey))
 	if err != nil {
-		if err == redis.ErrNil {
-			return uuid.Nil, apperrors.ErrSessionNotFound
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	for _, sessionUUID := range sessionUUIDs {
+		if err := conn.Send("HGETALL", sessionKey(sessionUUID)); err != nil {
+			return nil, fmt.Errorf("failed to list user sessions: %w", err)
 		}
-		return uuid.Nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if err := conn.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
 	}
 
-	userUUID, err := uuid.Parse(userUUIDStr)
+	sessions := make([]*models.Session, 0, len(sessionUUIDs))
+	var expired []any
+	for _, sessionUUID := range sessionUUIDs {
+		values, err := redis.Values(conn.Receive())
+		if err != nil && !isWrongTypeError(err) {
+			return nil, fmt.Errorf("failed to list user sessions: %w", err)
+		}
+
+		session, err := parseSession(sessionUUID, values)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrSessionNotFound) {
+				expired = append(expired, sessionUUID)
+				continue
+			}
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	// Вычищаем из индекса уже истекшие сессии
+	if len(expired) > 0 {
+		if _, err := conn.Do("SREM", redis.Args{}.Add(indexKey).Add(expired...)...); err != nil {
+			return nil, fmt.Errorf("failed to cleanup user sessions index: %w", err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// SetActiveOrganization переключает организацию, от имени которой работает сессия
+func (r *sessionRepository) SetActiveOrganization(ctx context.Context, sessionUUID string, organizationID uuid.UUID) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	updated, err := redis.Bool(setActiveOrganizationScript.Do(conn, sessionKey(sessionUUID), organizationID.String()))
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("invalid user UUID in session: %w", err)
+		return fmt.Errorf("failed to set active organization: %w", err)
+	}
+	if !updated {
+		return apperrors.ErrSessionNotFound
 	}
 
-	return userUUID, nil
+	return nil
 }
 
 // DeleteSession удаляет сессию
@@ -75,12 +251,177 @@ func (r *sessionRepository) DeleteSession(ctx context.Context, sessionUUID strin
 	conn := r.pool.Get()
 	defer conn.Close()
 
-	sessionKey := fmt.Sprintf("session:%s", sessionUUID)
+	userUUIDStr, err := redis.String(conn.Do("HGET", sessionKey(sessionUUID), "user_uuid"))
+	if err != nil {
+		if errors.Is(err, redis.ErrNil) {
+			return nil
+		}
+		if isWrongTypeError(err) {
+			// Сессия старого формата уже недействительна, ее ключ просто удаляется
+			if _, err := conn.Do("DEL", sessionKey(sessionUUID)); err != nil {
+				return fmt.Errorf("failed to delete session: %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
 
-	_, err := conn.Do("DEL", sessionKey)
+	userUUID, err := uuid.Parse(userUUIDStr)
 	if err != nil {
+		return fmt.Errorf("invalid user UUID in session: %w", err)
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	_ = conn.Send("DEL", sessionKey(sessionUUID))
+	_ = conn.Send("SREM", userSessionsKey(userUUID), sessionUUID)
+	if _, err := conn.Do("EXEC"); err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
 	return nil
 }
+
+// DeleteUserSessions удаляет все сессии пользователя и возвращает количество удаленных
+func (r *sessionRepository) DeleteUserSessions(ctx context.Context, userUUID uuid.UUID) (int, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userSessionsKey(userUUID)
+
+	sessionUUIDs, err := redis.Strings(conn.Do("SMEMBERS", indexKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	if len(sessionUUIDs) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]any, 0, len(sessionUUIDs))
+	for _, sessionUUID := range sessionUUIDs {
+		keys = append(keys, sessionKey(sessionUUID))
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	_ = conn.Send("DEL", keys...)
+	_ = conn.Send("DEL", indexKey)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	// DEL возвращает число реально удаленных ключей, поэтому уже истекшие сессии не учитываются
+	deleted, err := redis.Int(replies[0], nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteOtherUserSessions удаляет все сессии пользователя, кроме keepSessionUUID,
+// и возвращает количество удаленных
+func (r *sessionRepository) DeleteOtherUserSessions(
+	ctx context.Context,
+	userUUID uuid.UUID,
+	keepSessionUUID string,
+) (int, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	indexKey := userSessionsKey(userUUID)
+
+	sessionUUIDs, err := redis.Strings(conn.Do("SMEMBERS", indexKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	keys := make([]any, 0, len(sessionUUIDs))
+	members := make([]any, 0, len(sessionUUIDs))
+	for _, sessionUUID := range sessionUUIDs {
+		if sessionUUID == keepSessionUUID {
+			continue
+		}
+		keys = append(keys, sessionKey(sessionUUID))
+		members = append(members, sessionUUID)
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	if err := conn.Send("MULTI"); err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	_ = conn.Send("DEL", keys...)
+	_ = conn.Send("SREM", redis.Args{}.Add(indexKey).Add(members...)...)
+	replies, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	deleted, err := redis.Int(replies[0], nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// parseSession собирает сессию из ответа HGETALL
+func parseSession(sessionUUID string, values []any) (*models.Session, error) {
+	if len(values) == 0 {
+		return nil, apperrors.ErrSessionNotFound
+	}
+
+	var hash sessionHash
+	if err := redis.ScanStruct(values, &hash); err != nil {
+		return nil, fmt.Errorf("failed to scan session: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(hash.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user UUID in session: %w", err)
+	}
+
+	// Сессии, созданные до появления организаций, не привязаны к организации и недействительны
+	if hash.TenantID == "" {
+		return nil, apperrors.ErrSessionNotFound
+	}
+	tenantID, err := uuid.Parse(hash.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID in session: %w", err)
+	}
+
+	activeOrganizationID := tenantID
+	if hash.ActiveOrganizationID != "" {
+		activeOrganizationID, err = uuid.Parse(hash.ActiveOrganizationID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid active organization ID in session: %w", err)
+		}
+	}
+
+	return &models.Session{
+		UUID:                 sessionUUID,
+		UserUUID:             userUUID,
+		TenantID:             tenantID,
+		ActiveOrganizationID: activeOrganizationID,
+		IP:                   hash.IP,
+		UserAgent:            hash.UserAgent,
+		ClientName:           hash.ClientName,
+		CreatedAt:            time.Unix(hash.CreatedAt, 0),
+		LastSeenAt:           time.Unix(hash.LastSeenAt, 0),
+		ExpiresAt:            time.Unix(hash.ExpiresAt, 0),
+	}, nil
+}
+
+// isWrongTypeError проверяет, что Redis отказал из-за типа ключа. Так отвечают ключи сессий,
+// сохраненные строкой до перехода на hash, пока не истечет их TTL
+func isWrongTypeError(err error) bool {
+	var redisErr redis.Error
+	return errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "WRONGTYPE")
+}
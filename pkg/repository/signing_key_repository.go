@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// signingKeysLockID идентификатор advisory lock, сериализующего ротацию ключей между инстансами
+const signingKeysLockID = 7_340_001
+
+// SigningKeyRepository интерфейс для работы с ключами подписи
+type SigningKeyRepository interface {
+	ListSigningKeys(ctx context.Context, now time.Time) ([]*models.SigningKey, error)
+	RotateSigningKey(ctx context.Context, key *models.SigningKey, rotateBefore time.Time, overlap time.Duration) (bool, error)
+}
+
+// signingKeyRepository реализация репозитория ключей подписи
+type signingKeyRepository struct {
+	db *pgxpool.Pool
+	qb squirrel.StatementBuilderType
+}
+
+// NewSigningKeyRepository создает новый репозиторий ключей подписи
+func NewSigningKeyRepository(db *pgxpool.Pool) SigningKeyRepository {
+	return &signingKeyRepository{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// ListSigningKeys возвращает ключи, которые еще не истекли, начиная с самого нового
+func (r *signingKeyRepository) ListSigningKeys(ctx context.Context, now time.Time) ([]*models.SigningKey, error) {
+	query, args, err := r.qb.
+		Select("id", "kid", "algorithm", "public_key", "private_key_encrypted", "created_at", "activates_at", "expires_at").
+		From("signing_keys").
+		Where(squirrel.Or{
+			squirrel.Eq{"expires_at": nil},
+			squirrel.Gt{"expires_at": now},
+		}).
+		OrderBy("activates_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*models.SigningKey
+	for rows.Next() {
+		var key models.SigningKey
+		var publicKey []byte
+		if err := rows.Scan(
+			&key.ID,
+			&key.KID,
+			&key.Algorithm,
+			&publicKey,
+			&key.PrivateKeyEncrypted,
+			&key.CreatedAt,
+			&key.ActivatesAt,
+			&key.ExpiresAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		key.PublicKey = publicKey
+		keys = append(keys, &key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RotateSigningKey добавляет новый ключ, если самый свежий ключ создан раньше rotateBefore.
+// Предыдущие ключи остаются действительными еще overlap после активации нового.
+// Возвращает false, если ротацию уже выполнил другой инстанс
+func (r *signingKeyRepository) RotateSigningKey(
+	ctx context.Context,
+	key *models.SigningKey,
+	rotateBefore time.Time,
+	overlap time.Duration,
+) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Блокировка освобождается автоматически при завершении транзакции
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", signingKeysLockID); err != nil {
+		return false, fmt.Errorf("failed to acquire signing keys lock: %w", err)
+	}
+
+	// Проверяем, не выполнил ли ротацию другой инстанс
+	query, args, err := r.qb.
+		Select("MAX(created_at)").
+		From("signing_keys").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var latest *time.Time
+	if err := tx.QueryRow(ctx, query, args...).Scan(&latest); err != nil {
+		return false, fmt.Errorf("failed to get latest signing key: %w", err)
+	}
+	if latest != nil && !latest.Before(rotateBefore) {
+		return false, nil
+	}
+
+	// Ограничиваем срок действия предыдущих ключей
+	query, args, err = r.qb.
+		Update("signing_keys").
+		Set("expires_at", key.ActivatesAt.Add(overlap)).
+		Where(squirrel.Eq{"expires_at": nil}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build update query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to expire previous signing keys: %w", err)
+	}
+
+	// Удаляем ключи, срок действия которых уже истек
+	query, args, err = r.qb.
+		Delete("signing_keys").
+		Where(squirrel.Lt{"expires_at": key.CreatedAt}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build delete query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return false, fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+
+	// Сохраняем новый ключ
+	query, args, err = r.qb.
+		Insert("signing_keys").
+		Columns("kid", "algorithm", "public_key", "private_key_encrypted", "created_at", "activates_at").
+		Values(key.KID, key.Algorithm, []byte(key.PublicKey), key.PrivateKeyEncrypted, key.CreatedAt, key.ActivatesAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build insert query: %w", err)
+	}
+	if err := tx.QueryRow(ctx, query, args...).Scan(&key.ID); err != nil {
+		return false, fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
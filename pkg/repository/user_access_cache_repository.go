@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// userAccessGenerationTTL время жизни поколения кеша прав пользователя. Должно быть
+// больше времени жизни самого кеша, чтобы после сброса поколения в 0 не осталось
+// записей, сохраненных под старым нулевым поколением
+const userAccessGenerationTTL = 24 * time.Hour
+
+// UserAccessCacheRepository интерфейс кеша ролей и разрешений пользователя в организациях.
+// Кеш версионируется поколением: инвалидация увеличивает поколение, и записи,
+// сохраненные под прежним поколением, больше не читаются. Поколение общее для всех
+// организаций пользователя, поэтому инвалидация сбрасывает права во всех сразу
+type UserAccessCacheRepository interface {
+	GetUserAccess(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.UserAccess, int64, error)
+	SetUserAccess(
+		ctx context.Context,
+		organizationID uuid.UUID,
+		userUUID uuid.UUID,
+		generation int64,
+		access *models.UserAccess,
+		ttl time.Duration,
+	) error
+	InvalidateUserAccess(ctx context.Context, userUUID uuid.UUID) error
+}
+
+// roleGrantJSON представление выданной роли в кеше
+type roleGrantJSON struct {
+	Role        string   `json:"role"`
+	Resource    string   `json:"resource,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// userAccessJSON представление прав пользователя в кеше
+type userAccessJSON struct {
+	Roles       []string        `json:"roles,omitempty"`
+	Permissions []string        `json:"permissions,omitempty"`
+	Grants      []roleGrantJSON `json:"grants,omitempty"`
+}
+
+// userAccessCacheRepository реализация кеша прав пользователя в Redis
+type userAccessCacheRepository struct {
+	pool *redis.Pool
+}
+
+// NewUserAccessCacheRepository создает новый кеш прав пользователя
+func NewUserAccessCacheRepository(pool *redis.Pool) UserAccessCacheRepository {
+	return &userAccessCacheRepository{
+		pool: pool,
+	}
+}
+
+// userAccessGenerationKey возвращает ключ поколения кеша прав пользователя в Redis
+func userAccessGenerationKey(userUUID uuid.UUID) string {
+	return fmt.Sprintf("user_access_generation:%s", userUUID)
+}
+
+// userAccessKey возвращает ключ прав пользователя в организации указанного поколения в Redis
+func userAccessKey(organizationID, userUUID uuid.UUID, generation int64) string {
+	return fmt.Sprintf("user_access:%s:%s:%d", userUUID, organizationID, generation)
+}
+
+// GetUserAccess возвращает права пользователя в организации из кеша и текущее поколение.
+// При промахе возвращает nil; права, загруженные из базы, нужно сохранять под этим поколением
+func (r *userAccessCacheRepository) GetUserAccess(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	userUUID uuid.UUID,
+) (*models.UserAccess, int64, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	generation, err := redis.Int64(conn.Do("GET", userAccessGenerationKey(userUUID)))
+	if err != nil && !errors.Is(err, redis.ErrNil) {
+		return nil, 0, fmt.Errorf("failed to get user access generation: %w", err)
+	}
+
+	data, err := redis.Bytes(conn.Do("GET", userAccessKey(organizationID, userUUID, generation)))
+	if err != nil {
+		if errors.Is(err, redis.ErrNil) {
+			return nil, generation, nil
+		}
+		return nil, 0, fmt.Errorf("failed to get user access: %w", err)
+	}
+
+	var cached userAccessJSON
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode user access: %w", err)
+	}
+
+	access := &models.UserAccess{
+		Roles:       cached.Roles,
+		Permissions: cached.Permissions,
+	}
+	for _, grant := range cached.Grants {
+		access.Grants = append(access.Grants, models.RoleGrant{
+			Role:        grant.Role,
+			Resource:    grant.Resource,
+			Permissions: grant.Permissions,
+		})
+	}
+
+	return access, generation, nil
+}
+
+// SetUserAccess сохраняет права пользователя в организации под поколением, полученным из GetUserAccess.
+// Если права успели инвалидировать, запись ляжет под устаревшее поколение и не будет прочитана
+func (r *userAccessCacheRepository) SetUserAccess(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	userUUID uuid.UUID,
+	generation int64,
+	access *models.UserAccess,
+	ttl time.Duration,
+) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	cached := userAccessJSON{
+		Roles:       access.Roles,
+		Permissions: access.Permissions,
+	}
+	for _, grant := range access.Grants {
+		cached.Grants = append(cached.Grants, roleGrantJSON{
+			Role:        grant.Role,
+			Resource:    grant.Resource,
+			Permissions: grant.Permissions,
+		})
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to encode user access: %w", err)
+	}
+
+	if _, err := conn.Do("SET", userAccessKey(organizationID, userUUID, generation), data, "PX", ttl.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to set user access: %w", err)
+	}
+
+	return nil
+}
+
+// InvalidateUserAccess делает недействительными все закешированные права пользователя во всех организациях
+func (r *userAccessCacheRepository) InvalidateUserAccess(ctx context.Context, userUUID uuid.UUID) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	key := userAccessGenerationKey(userUUID)
+
+	if err := conn.Send("MULTI"); err != nil {
+		return fmt.Errorf("failed to invalidate user access: %w", err)
+	}
+	_ = conn.Send("INCR", key)
+	_ = conn.Send("PEXPIRE", key, userAccessGenerationTTL.Milliseconds())
+	if _, err := conn.Do("EXEC"); err != nil {
+		return fmt.Errorf("failed to invalidate user access: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -15,11 +16,29 @@ import (
 	"github.com/olezhek28/auth-service/pkg/models"
 )
 
-// UserRepository интерфейс для работы с пользователями
+// UserRepository интерфейс для работы с пользователями.
+// Все операции ограничены организацией tenantID: пользователь другой организации не находится
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
-	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
-	GetUserByUUID(ctx context.Context, userUUID uuid.UUID) (*models.User, error)
+	GetUserByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*models.User, error)
+	GetUserByUUID(ctx context.Context, tenantID, userUUID uuid.UUID) (*models.User, error)
+	UpdatePassword(ctx context.Context, tenantID, userUUID uuid.UUID, passwordHash string, historySize int) error
+	RehashPassword(ctx context.Context, tenantID, userUUID uuid.UUID, oldHash, newHash string) (bool, error)
+	GetPasswordHistory(ctx context.Context, tenantID, userUUID uuid.UUID, limit int) ([]string, error)
+	MarkEmailVerified(ctx context.Context, tenantID, userUUID uuid.UUID, verifiedAt time.Time) error
+}
+
+// userColumns колонки users в порядке, ожидаемом scanUser
+var userColumns = []string{
+	"id",
+	"uuid",
+	"tenant_id",
+	"email",
+	"username",
+	"password_hash",
+	"email_verified_at",
+	"created_at",
+	"updated_at",
 }
 
 // userRepository реализация репозитория пользователей
@@ -36,13 +55,29 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 	}
 }
 
-// CreateUser создает нового пользователя
+// CreateUser создает нового пользователя в организации user.TenantID
+// и делает его участником этой организации
 func (r *userRepository) CreateUser(ctx context.Context, user *models.User) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	// Строим SQL запрос
 	query, args, err := r.qb.
 		Insert("users").
-		Columns("uuid", "email", "username", "password_hash", "created_at", "updated_at").
-		Values(user.UUID, user.Email, user.Username, user.PasswordHash, user.CreatedAt, user.UpdatedAt).
+		Columns("uuid", "tenant_id", "email", "username", "password_hash", "email_verified_at", "created_at", "updated_at").
+		Values(
+			user.UUID,
+			user.TenantID,
+			user.Email,
+			user.Username,
+			user.PasswordHash,
+			user.EmailVerifiedAt,
+			user.CreatedAt,
+			user.UpdatedAt,
+		).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -50,7 +85,7 @@ func (r *userRepository) CreateUser(ctx context.Context, user *models.User) erro
 	}
 
 	// Выполняем запрос
-	err = r.db.QueryRow(ctx, query, args...).Scan(&user.ID)
+	err = tx.QueryRow(ctx, query, args...).Scan(&user.ID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
@@ -59,58 +94,254 @@ func (r *userRepository) CreateUser(ctx context.Context, user *models.User) erro
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
+	query, args, err = r.qb.
+		Insert("organization_members").
+		Columns("organization_id", "user_uuid", "created_at").
+		Values(user.TenantID, user.UUID, user.CreatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
-// GetUserByEmail получает пользователя по email
-func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+// GetUserByEmail получает пользователя организации по email
+func (r *userRepository) GetUserByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*models.User, error) {
 	query, args, err := r.qb.
-		Select("id", "uuid", "email", "username", "password_hash", "created_at", "updated_at").
+		Select(userColumns...).
 		From("users").
-		Where(squirrel.Eq{"email": email}).
+		Where(squirrel.Eq{"tenant_id": tenantID, "email": email}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build select query: %w", err)
 	}
 
-	var user models.User
-	err = r.db.QueryRow(ctx, query, args...).Scan(
-		&user.ID,
-		&user.UUID,
-		&user.Email,
-		&user.Username,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	return scanUser(r.db.QueryRow(ctx, query, args...))
+}
+
+// GetUserByUUID получает пользователя организации по UUID
+func (r *userRepository) GetUserByUUID(ctx context.Context, tenantID, userUUID uuid.UUID) (*models.User, error) {
+	query, args, err := r.qb.
+		Select(userColumns...).
+		From("users").
+		Where(squirrel.Eq{"tenant_id": tenantID, "uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	return scanUser(r.db.QueryRow(ctx, query, args...))
+}
+
+// UpdatePassword заменяет хеш пароля пользователя и сохраняет прежний хеш в истории паролей.
+// В истории остаются только historySize последних хешей, 0 очищает ее.
+// updated_at обновляется триггером
+func (r *userRepository) UpdatePassword(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	userUUID uuid.UUID,
+	passwordHash string,
+	historySize int,
+) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Блокируем строку, чтобы параллельные смены пароля не потеряли запись в истории
+	query, args, err := r.qb.
+		Select("password_hash").
+		From("users").
+		Where(squirrel.Eq{"tenant_id": tenantID, "uuid": userUUID}).
+		Suffix("FOR UPDATE").
+		ToSql()
 	if err != nil {
+		return fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var oldHash string
+	if err := tx.QueryRow(ctx, query, args...).Scan(&oldHash); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperrors.ErrUserNotFound
+			return apperrors.ErrUserNotFound
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return fmt.Errorf("failed to get password hash: %w", err)
 	}
 
-	return &user, nil
+	query, args, err = r.qb.
+		Update("users").
+		Set("password_hash", passwordHash).
+		Where(squirrel.Eq{"tenant_id": tenantID, "uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if historySize > 0 {
+		query, args, err = r.qb.
+			Insert("password_history").
+			Columns("user_uuid", "password_hash").
+			Values(userUUID, oldHash).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to save password history: %w", err)
+		}
+	}
+
+	// Удаляем хеши, вышедшие за пределы истории
+	keep := r.qb.
+		Select("id").
+		From("password_history").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		OrderBy("id DESC").
+		Limit(uint64(max(historySize, 0)))
+	keepSQL, keepArgs, err := keep.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build select query: %w", err)
+	}
+	query, args, err = r.qb.
+		Delete("password_history").
+		Where(squirrel.Eq{"user_uuid": userUUID}).
+		Where(squirrel.Expr("id NOT IN ("+keepSQL+")", keepArgs...)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
-// GetUserByUUID получает пользователя по UUID
-func (r *userRepository) GetUserByUUID(ctx context.Context, userUUID uuid.UUID) (*models.User, error) {
+// RehashPassword заменяет хеш пароля, только если он не менялся с момента чтения.
+// Пароль при этом остается прежним, поэтому история паролей не пополняется.
+// Возвращает false, если пароль успели сменить
+func (r *userRepository) RehashPassword(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	userUUID uuid.UUID,
+	oldHash string,
+	newHash string,
+) (bool, error) {
 	query, args, err := r.qb.
-		Select("id", "uuid", "email", "username", "password_hash", "created_at", "updated_at").
-		From("users").
-		Where(squirrel.Eq{"uuid": userUUID}).
+		Update("users").
+		Set("password_hash", newHash).
+		Where(squirrel.Eq{"tenant_id": tenantID, "uuid": userUUID, "password_hash": oldHash}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to rehash password: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetPasswordHistory возвращает limit последних прежних хешей пароля пользователя, начиная с самого нового
+func (r *userRepository) GetPasswordHistory(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	userUUID uuid.UUID,
+	limit int,
+) ([]string, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	query, args, err := r.qb.
+		Select("ph.password_hash").
+		From("password_history ph").
+		Join("users u ON u.uuid = ph.user_uuid").
+		Where(squirrel.Eq{"u.tenant_id": tenantID, "ph.user_uuid": userUUID}).
+		OrderBy("ph.id DESC").
+		Limit(uint64(limit)).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build select query: %w", err)
 	}
 
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password history: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan password history: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get password history: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// MarkEmailVerified отмечает email пользователя подтвержденным.
+// Повторное подтверждение не меняет исходное время
+func (r *userRepository) MarkEmailVerified(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	userUUID uuid.UUID,
+	verifiedAt time.Time,
+) error {
+	query, args, err := r.qb.
+		Update("users").
+		Set("email_verified_at", squirrel.Expr("COALESCE(email_verified_at, ?)", verifiedAt)).
+		Where(squirrel.Eq{"tenant_id": tenantID, "uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// scanUser читает пользователя из строки с колонками userColumns
+func scanUser(row pgx.Row) (*models.User, error) {
 	var user models.User
-	err = r.db.QueryRow(ctx, query, args...).Scan(
+	err := row.Scan(
 		&user.ID,
 		&user.UUID,
+		&user.TenantID,
 		&user.Email,
 		&user.Username,
 		&user.PasswordHash,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
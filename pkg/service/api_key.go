@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+const (
+	// maxAPIKeyScopes максимальное количество областей действия одного API ключа
+	maxAPIKeyScopes = 50
+	// apiKeyTouchInterval как часто обновляется время последнего использования API ключа.
+	// Ключи машинных клиентов используются часто, писать в базу на каждый запрос незачем
+	apiKeyTouchInterval = time.Minute
+)
+
+// CreateAPIKeyRequest запрос на создание API ключа пользователя сессии или его сервисного аккаунта
+type CreateAPIKeyRequest struct {
+	SessionUUID string
+	// ServiceAccountID сервисный аккаунт активной организации сессии. Пусто - ключ пользователя сессии
+	ServiceAccountID string
+	Name             string
+	Scopes           []string
+	// ExpiresAt nil - ключ бессрочный
+	ExpiresAt *time.Time
+}
+
+// CreateAPIKeyResponse созданный API ключ. Key возвращается только здесь, сервер хранит лишь его хеш
+type CreateAPIKeyResponse struct {
+	Key    string
+	APIKey *models.APIKey
+}
+
+// ListAPIKeysRequest запрос API ключей пользователя сессии или его сервисного аккаунта
+type ListAPIKeysRequest struct {
+	SessionUUID      string
+	ServiceAccountID string
+}
+
+// ListAPIKeysResponse не отозванные API ключи, начиная с самого нового
+type ListAPIKeysResponse struct {
+	APIKeys []*models.APIKey
+}
+
+// RevokeAPIKeyRequest запрос на отзыв API ключа пользователя сессии или его сервисного аккаунта
+type RevokeAPIKeyRequest struct {
+	SessionUUID      string
+	ServiceAccountID string
+	KeyID            string
+}
+
+// RevokeAPIKeyResponse ответ на отзыв API ключа
+type RevokeAPIKeyResponse struct {
+	// Revoked ключ был действующим
+	Revoked bool
+}
+
+// AuthenticateAPIKeyRequest запрос на проверку API ключа
+type AuthenticateAPIKeyRequest struct {
+	APIKey string
+}
+
+// AuthenticateAPIKeyResponse владелец API ключа и сам ключ с областями действия
+type AuthenticateAPIKeyResponse struct {
+	PrincipalType models.PrincipalType
+	// User заполняется для ключей пользователей
+	User *models.User
+	// ServiceAccount заполняется для ключей сервисных аккаунтов
+	ServiceAccount *models.ServiceAccount
+	APIKey         *models.APIKey
+}
+
+// apiKeyPrincipal владелец API ключей, с которыми работает запрос
+type apiKeyPrincipal struct {
+	tenantID      uuid.UUID
+	principalType models.PrincipalType
+	principalID   uuid.UUID
+	// sessionUserUUID пользователь сессии, который управляет ключами
+	sessionUserUUID uuid.UUID
+}
+
+// CreateAPIKey создает API ключ, который действует от имени пользователя сессии в его организации
+// или от имени сервисного аккаунта в организации аккаунта. Области действия ключа должны входить
+// в разрешения пользователя сессии, иначе ключ давал бы больше прав, чем есть у его создателя
+func (s *authService) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateAPIKeyName(req.Name); err != nil {
+		return nil, err
+	}
+	if len(req.Scopes) > maxAPIKeyScopes {
+		return nil, fmt.Errorf("%w: at most %d scopes are allowed", apperrors.ErrInvalidInput, maxAPIKeyScopes)
+	}
+	for _, scope := range req.Scopes {
+		if err := validator.ValidatePermission(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", apperrors.ErrInvalidInput)
+	}
+
+	principal, err := s.resolveAPIKeyPrincipal(ctx, req.SessionUUID, req.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	// Ключ сервисного аккаунта ограничен правами создателя в организации аккаунта:
+	// иначе любой участник выпустил бы через свой аккаунт ключ с произвольными правами
+	if err := s.requireScopesGranted(ctx, principal.tenantID, principal.sessionUserUUID, req.Scopes); err != nil {
+		return nil, err
+	}
+
+	key, prefix, err := token.GenerateAPIKey()
+	if err != nil {
+		s.logger.Error("failed to generate api key", "error", err)
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	// Пустой список, а не nil: в базе scopes не может быть NULL
+	scopes := append(make([]string, 0, len(req.Scopes)), req.Scopes...)
+	slices.Sort(scopes)
+
+	apiKey := &models.APIKey{
+		ID:            uuid.New(),
+		TenantID:      principal.tenantID,
+		PrincipalType: principal.principalType,
+		PrincipalID:   principal.principalID,
+		Name:          strings.TrimSpace(req.Name),
+		Prefix:        prefix,
+		KeyHash:       token.HashOpaqueToken(key),
+		Scopes:        slices.Compact(scopes),
+		CreatedAt:     now,
+		ExpiresAt:     req.ExpiresAt,
+	}
+	if err := s.apiKeyRepo.CreateAPIKey(ctx, apiKey); err != nil {
+		s.logger.Error("failed to create api key", "error", err, "principal_id", principal.principalID)
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	s.logger.Info("api key created",
+		"principal_type", apiKey.PrincipalType,
+		"principal_id", apiKey.PrincipalID,
+		"key_id", apiKey.ID,
+		"prefix", apiKey.Prefix,
+		"scopes", apiKey.Scopes,
+	)
+
+	return &CreateAPIKeyResponse{
+		Key:    key,
+		APIKey: apiKey,
+	}, nil
+}
+
+// ListAPIKeys возвращает не отозванные API ключи пользователя сессии или его сервисного аккаунта
+func (s *authService) ListAPIKeys(ctx context.Context, req ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	principal, err := s.resolveAPIKeyPrincipal(ctx, req.SessionUUID, req.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListAPIKeys(ctx, principal.tenantID, principal.principalType, principal.principalID)
+	if err != nil {
+		s.logger.Error("failed to list api keys", "error", err, "principal_id", principal.principalID)
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return &ListAPIKeysResponse{
+		APIKeys: apiKeys,
+	}, nil
+}
+
+// RevokeAPIKey отзывает API ключ пользователя сессии или его сервисного аккаунта
+func (s *authService) RevokeAPIKey(ctx context.Context, req RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateAPIKeyID(req.KeyID); err != nil {
+		return nil, err
+	}
+	keyID := uuid.MustParse(req.KeyID)
+
+	principal, err := s.resolveAPIKeyPrincipal(ctx, req.SessionUUID, req.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.apiKeyRepo.RevokeAPIKey(
+		ctx,
+		principal.tenantID,
+		principal.principalType,
+		principal.principalID,
+		keyID,
+		time.Now(),
+	)
+	if err != nil {
+		s.logger.Error("failed to revoke api key", "error", err, "key_id", keyID)
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	s.logger.Info("api key revoked",
+		"principal_type", principal.principalType,
+		"principal_id", principal.principalID,
+		"key_id", keyID,
+		"revoked", revoked,
+	)
+
+	return &RevokeAPIKeyResponse{
+		Revoked: revoked,
+	}, nil
+}
+
+// AuthenticateAPIKey проверяет API ключ и возвращает пользователя или сервисный аккаунт,
+// от имени которого он действует
+func (s *authService) AuthenticateAPIKey(
+	ctx context.Context,
+	req AuthenticateAPIKeyRequest,
+) (*AuthenticateAPIKeyResponse, error) {
+	return s.authenticateAPIKey(ctx, req.APIKey)
+}
+
+// whoAmIByAPIKey возвращает информацию о пользователе или сервисном аккаунте, определенном по API ключу.
+// Права пользователя ограничиваются областями действия ключа
+func (s *authService) whoAmIByAPIKey(ctx context.Context, rawAPIKey string) (*WhoAmIResponse, error) {
+	authenticated, err := s.authenticateAPIKey(ctx, rawAPIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if authenticated.ServiceAccount != nil {
+		resp, err := s.whoAmIServiceAccount(ctx, authenticated.ServiceAccount)
+		if err != nil {
+			return nil, err
+		}
+		resp.APIKey = authenticated.APIKey
+		return resp, nil
+	}
+
+	resp, err := s.whoAmIInHomeOrganization(ctx, authenticated.User)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.getUserAccess(ctx, authenticated.User.TenantID, authenticated.User.UUID)
+	if err != nil {
+		return nil, err
+	}
+	restricted := access.RestrictTo(authenticated.APIKey.Scopes)
+	resp.Roles = restricted.Roles
+	resp.Permissions = restricted.Permissions
+	resp.APIKey = authenticated.APIKey
+
+	return resp, nil
+}
+
+// authenticateAPIKey находит действующий API ключ и его владельца и отмечает использование ключа
+func (s *authService) authenticateAPIKey(ctx context.Context, rawAPIKey string) (*AuthenticateAPIKeyResponse, error) {
+	if err := validator.ValidateAPIKey(rawAPIKey); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	apiKey, err := s.apiKeyRepo.GetActiveAPIKeyByHash(ctx, token.HashOpaqueToken(rawAPIKey), now)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidAPIKey) {
+			return nil, apperrors.ErrInvalidAPIKey
+		}
+		s.logger.Error("failed to get api key", "error", err)
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	resp := &AuthenticateAPIKeyResponse{
+		PrincipalType: apiKey.PrincipalType,
+		APIKey:        apiKey,
+	}
+	switch apiKey.PrincipalType {
+	case models.PrincipalTypeServiceAccount:
+		serviceAccount, err := s.serviceAccountRepo.GetServiceAccount(ctx, apiKey.TenantID, apiKey.PrincipalID)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrServiceAccountNotFound) {
+				return nil, apperrors.ErrInvalidAPIKey
+			}
+			s.logger.Error("failed to get service account", "error", err, "service_account_id", apiKey.PrincipalID)
+			return nil, fmt.Errorf("failed to get service account: %w", err)
+		}
+		resp.ServiceAccount = serviceAccount
+	default:
+		user, err := s.userRepo.GetUserByUUID(ctx, apiKey.TenantID, apiKey.PrincipalID)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrUserNotFound) {
+				return nil, apperrors.ErrInvalidAPIKey
+			}
+			s.logger.Error("failed to get user", "error", err, "user_uuid", apiKey.PrincipalID)
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		resp.User = user
+	}
+
+	// Сбой записи времени использования не должен мешать аутентификации
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			s.logger.Warn("failed to touch api key", "error", err, "key_id", apiKey.ID)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return resp, nil
+}
+
+// resolveAPIKeyPrincipal определяет, с чьими API ключами работает запрос: пользователя сессии
+// или сервисного аккаунта, которым он может управлять
+func (s *authService) resolveAPIKeyPrincipal(
+	ctx context.Context,
+	sessionUUID string,
+	serviceAccountID string,
+) (*apiKeyPrincipal, error) {
+	if serviceAccountID != "" {
+		if err := validator.ValidateServiceAccountID(serviceAccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := s.getSession(ctx, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if serviceAccountID == "" {
+		return &apiKeyPrincipal{
+			tenantID:        session.TenantID,
+			principalType:   models.PrincipalTypeUser,
+			principalID:     session.UserUUID,
+			sessionUserUUID: session.UserUUID,
+		}, nil
+	}
+
+	serviceAccount, err := s.authorizeServiceAccount(ctx, session, uuid.MustParse(serviceAccountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKeyPrincipal{
+		tenantID:        serviceAccount.TenantID,
+		principalType:   models.PrincipalTypeServiceAccount,
+		principalID:     serviceAccount.ID,
+		sessionUserUUID: session.UserUUID,
+	}, nil
+}
+
+// requireScopesGranted проверяет, что все области действия входят в глобальные разрешения пользователя в организации
+func (s *authService) requireScopesGranted(
+	ctx context.Context,
+	organizationID uuid.UUID,
+	userUUID uuid.UUID,
+	scopes []string,
+) error {
+	access, err := s.getUserAccess(ctx, organizationID, userUUID)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(access.Permissions, scope) {
+			return apperrors.ErrPermissionDenied
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+func TestRequireScopesGranted(t *testing.T) {
+	organizationID := uuid.New()
+	otherOrganizationID := uuid.New()
+	userUUID := uuid.New()
+
+	s := newTestAuthService()
+	s.roleRepo = &stubRoleRepository{access: map[uuid.UUID]*models.UserAccess{
+		organizationID: {
+			Roles:       []string{"editor"},
+			Permissions: []string{"docs:read", "docs:write"},
+			Grants: []models.RoleGrant{
+				{Role: "editor", Permissions: []string{"docs:read", "docs:write"}},
+				{Role: "admin", Resource: "docs/1", Permissions: []string{"docs:delete"}},
+			},
+		},
+		otherOrganizationID: {
+			Roles:       []string{"admin"},
+			Permissions: []string{"users:manage"},
+			Grants:      []models.RoleGrant{{Role: "admin", Permissions: []string{"users:manage"}}},
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		scopes  []string
+		wantErr error
+	}{
+		{name: "без областей"},
+		{name: "все области выданы", scopes: []string{"docs:write", "docs:read"}},
+		{
+			name:    "одна из областей не выдана",
+			scopes:  []string{"docs:read", "billing:read"},
+			wantErr: apperrors.ErrPermissionDenied,
+		},
+		{
+			name:    "разрешение роли на ресурс",
+			scopes:  []string{"docs:delete"},
+			wantErr: apperrors.ErrPermissionDenied,
+		},
+		{
+			name:    "разрешение из другой организации",
+			scopes:  []string{"users:manage"},
+			wantErr: apperrors.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.requireScopesGranted(context.Background(), organizationID, userUUID, tt.scopes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("requireScopesGranted() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,24 +7,78 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 
+	"github.com/olezhek28/auth-service/pkg/config"
 	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/keys"
 	"github.com/olezhek28/auth-service/pkg/logger"
 	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/notifier"
+	"github.com/olezhek28/auth-service/pkg/password"
 	"github.com/olezhek28/auth-service/pkg/repository"
+	"github.com/olezhek28/auth-service/pkg/token"
 	"github.com/olezhek28/auth-service/pkg/validator"
 )
 
+// deliveryTimeout ограничивает время фоновой выдачи и доставки токенов пользователю
+const deliveryTimeout = 30 * time.Second
+
 // AuthService интерфейс сервиса аутентификации
 type AuthService interface {
 	Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error)
 	Login(ctx context.Context, req LoginRequest) (*LoginResponse, error)
 	WhoAmI(ctx context.Context, req WhoAmIRequest) (*WhoAmIResponse, error)
+	Logout(ctx context.Context, req LogoutRequest) (*LogoutResponse, error)
+	LogoutAll(ctx context.Context, req LogoutAllRequest) (*LogoutAllResponse, error)
+	ListSessions(ctx context.Context, req ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, req RevokeSessionRequest) (*RevokeSessionResponse, error)
+	Refresh(ctx context.Context, req RefreshRequest) (*RefreshResponse, error)
+	ChangePassword(ctx context.Context, req ChangePasswordRequest) (*ChangePasswordResponse, error)
+	RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) (*RequestPasswordResetResponse, error)
+	ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) (*ConfirmPasswordResetResponse, error)
+	VerifyEmail(ctx context.Context, req VerifyEmailRequest) (*VerifyEmailResponse, error)
+	ResendVerification(ctx context.Context, req ResendVerificationRequest) (*ResendVerificationResponse, error)
+	EnrollTOTP(ctx context.Context, req EnrollTOTPRequest) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, req ConfirmTOTPRequest) (*ConfirmTOTPResponse, error)
+	DisableTOTP(ctx context.Context, req DisableTOTPRequest) (*DisableTOTPResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, req RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error)
+	CompleteMFA(ctx context.Context, req CompleteMFARequest) (*LoginResponse, error)
+	CreateRole(ctx context.Context, req CreateRoleRequest) (*CreateRoleResponse, error)
+	GrantRole(ctx context.Context, req GrantRoleRequest) (*GrantRoleResponse, error)
+	RevokeRole(ctx context.Context, req RevokeRoleRequest) (*RevokeRoleResponse, error)
+	ListUserPermissions(ctx context.Context, req ListUserPermissionsRequest) (*ListUserPermissionsResponse, error)
+	CheckPermission(ctx context.Context, req CheckPermissionRequest) (*PermissionDecision, error)
+	CheckPermissions(ctx context.Context, req CheckPermissionsRequest) (*CheckPermissionsResponse, error)
+	UnlockUser(ctx context.Context, req UnlockUserRequest) (*UnlockUserResponse, error)
+	CreateOrganization(ctx context.Context, req CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	CreateInvitation(ctx context.Context, req CreateInvitationRequest) (*CreateInvitationResponse, error)
+	AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (*AcceptInvitationResponse, error)
+	ListMembers(ctx context.Context, req ListMembersRequest) (*ListMembersResponse, error)
+	RemoveMember(ctx context.Context, req RemoveMemberRequest) (*RemoveMemberResponse, error)
+	SwitchOrganization(ctx context.Context, req SwitchOrganizationRequest) (*SwitchOrganizationResponse, error)
+	CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, req ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	RevokeAPIKey(ctx context.Context, req RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
+	AuthenticateAPIKey(ctx context.Context, req AuthenticateAPIKeyRequest) (*AuthenticateAPIKeyResponse, error)
+	CreateServiceAccount(ctx context.Context, req CreateServiceAccountRequest) (*CreateServiceAccountResponse, error)
+	ListServiceAccounts(ctx context.Context, req ListServiceAccountsRequest) (*ListServiceAccountsResponse, error)
+	DeleteServiceAccount(ctx context.Context, req DeleteServiceAccountRequest) (*DeleteServiceAccountResponse, error)
+	CreateOAuthClient(ctx context.Context, req CreateOAuthClientRequest) (*CreateOAuthClientResponse, error)
+	DeleteOAuthClient(ctx context.Context, req DeleteOAuthClientRequest) (*DeleteOAuthClientResponse, error)
+	GrantOAuthConsent(ctx context.Context, req GrantOAuthConsentRequest) (*GrantOAuthConsentResponse, error)
+	ListOAuthConsents(ctx context.Context, req ListOAuthConsentsRequest) (*ListOAuthConsentsResponse, error)
+	RevokeOAuthConsent(ctx context.Context, req RevokeOAuthConsentRequest) (*RevokeOAuthConsentResponse, error)
+	ResolveOAuthRedirect(ctx context.Context, req ResolveOAuthRedirectRequest) (*ResolveOAuthRedirectResponse, error)
+	Authorize(ctx context.Context, req AuthorizeRequest) (*AuthorizeResponse, error)
+	ExchangeOAuthToken(ctx context.Context, req OAuthTokenRequest) (*OAuthTokenResponse, error)
+	RevokeOAuthToken(ctx context.Context, req RevokeOAuthTokenRequest) (*RevokeOAuthTokenResponse, error)
+	UserInfo(ctx context.Context, req UserInfoRequest) (*UserInfoResponse, error)
 }
 
 // RegisterRequest запрос на регистрацию
 type RegisterRequest struct {
+	// Tenant slug организации, в которой регистрируется пользователь
+	Tenant   string
 	Email    string
 	Username string
 	Password string
@@ -32,53 +86,209 @@ type RegisterRequest struct {
 
 // RegisterResponse ответ на регистрацию
 type RegisterResponse struct {
-	UserUUID uuid.UUID
+	UserUUID        uuid.UUID
+	TenantID        uuid.UUID
+	Email           string
+	Username        string
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // LoginRequest запрос на вход
 type LoginRequest struct {
+	// Tenant slug организации пользователя
+	Tenant   string
 	Email    string
 	Password string
+	Client   ClientInfo
+	// IssueTokens выдать пару access/refresh токенов вместо сессии
+	IssueTokens bool
+}
+
+// ClientInfo информация об устройстве, с которого выполняется вход
+type ClientInfo struct {
+	IP         string
+	UserAgent  string
+	ClientName string
 }
 
 // LoginResponse ответ на вход
 type LoginResponse struct {
 	SessionUUID string
+	UserUUID    uuid.UUID
+	// ExpiresAt срок жизни сессии или MFA challenge
+	ExpiresAt time.Time
+	// Tokens заполняется вместо сессии, если в запросе был IssueTokens
+	Tokens *TokenPair
+	// MFAChallengeID заполняется вместо сессии и токенов, если у пользователя подключен TOTP.
+	// Вход завершается вызовом CompleteMFA
+	MFAChallengeID string
 }
 
-// WhoAmIRequest запрос информации о пользователе
+// WhoAmIRequest запрос информации о пользователе.
+// Должен быть заполнен ровно один из SessionUUID, AccessToken и APIKey
 type WhoAmIRequest struct {
 	SessionUUID string
+	AccessToken string
+	APIKey      string
 }
 
-// WhoAmIResponse ответ с информацией о пользователе
+// WhoAmIResponse ответ с информацией о пользователе или сервисном аккаунте
 type WhoAmIResponse struct {
-	UserUUID  uuid.UUID
-	Email     string
-	Username  string
-	CreatedAt time.Time
+	PrincipalType models.PrincipalType
+	// UserUUID, Email, Username и EmailVerifiedAt пусты для сервисного аккаунта
+	UserUUID        uuid.UUID
+	TenantID        uuid.UUID
+	Email           string
+	Username        string
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	// Session заполняется, только если пользователь определен по сессии
+	Session SessionInfo
+	// APIKey заполняется, только если пользователь определен по API ключу
+	APIKey *models.APIKey
+	// ServiceAccount заполняется, если API ключ принадлежит сервисному аккаунту
+	ServiceAccount *models.ServiceAccount
+	// Organization активная организация сессии или, для access токена, организация пользователя
+	Organization models.Organization
+	// OrganizationRole роль участника в активной организации
+	OrganizationRole string
+	// Roles и Permissions права пользователя в активной организации
+	Roles       []string
+	Permissions []string
+}
+
+// LogoutRequest запрос на завершение текущей сессии
+type LogoutRequest struct {
+	SessionUUID string
+}
+
+// LogoutResponse ответ на завершение текущей сессии
+type LogoutResponse struct{}
+
+// LogoutAllRequest запрос на завершение всех сессий пользователя
+type LogoutAllRequest struct {
+	SessionUUID string
+}
+
+// LogoutAllResponse ответ на завершение всех сессий пользователя
+type LogoutAllResponse struct {
+	RevokedSessions int
 }
 
+// ListSessionsRequest запрос списка активных сессий пользователя
+type ListSessionsRequest struct {
+	SessionUUID string
+}
+
+// SessionInfo информация об активной сессии
+type SessionInfo struct {
+	SessionUUID string
+	Client      ClientInfo
+	CreatedAt   time.Time
+	LastSeenAt  time.Time
+	ExpiresAt   time.Time
+	Current     bool
+}
+
+// ListSessionsResponse ответ со списком активных сессий пользователя
+type ListSessionsResponse struct {
+	Sessions []SessionInfo
+}
+
+// RevokeSessionRequest запрос на завершение другой сессии пользователя
+type RevokeSessionRequest struct {
+	SessionUUID       string
+	TargetSessionUUID string
+}
+
+// RevokeSessionResponse ответ на завершение другой сессии пользователя
+type RevokeSessionResponse struct{}
+
 // authService реализация сервиса аутентификации
 type authService struct {
-	userRepo    repository.UserRepository
-	sessionRepo repository.SessionRepository
-	logger      logger.Logger
-	sessionTTL  time.Duration
+	userRepo           repository.UserRepository
+	organizationRepo   repository.OrganizationRepository
+	membershipRepo     repository.MembershipRepository
+	sessionRepo        repository.SessionRepository
+	refreshTokenRepo   repository.RefreshTokenRepository
+	passwordResetRepo  repository.PasswordResetRepository
+	emailVerifyRepo    repository.EmailVerificationRepository
+	mfaRepo            repository.MFARepository
+	mfaChallengeRepo   repository.MFAChallengeRepository
+	loginAttemptRepo   repository.LoginAttemptRepository
+	roleRepo           repository.RoleRepository
+	accessCacheRepo    repository.UserAccessCacheRepository
+	apiKeyRepo         repository.APIKeyRepository
+	serviceAccountRepo repository.ServiceAccountRepository
+	oauthRepo          repository.OAuthRepository
+	oauthCodeRepo      repository.OAuthCodeRepository
+	tokenManager       token.Manager
+	passwordHasher     password.Hasher
+	passwordPolicy     *password.Policy
+	secretCipher       keys.Cipher
+	notifier           notifier.Notifier
+	logger             logger.Logger
+	cfg                config.AuthConfig
+
+	// dummyPasswordHash хеш случайного пароля, с которым Login сравнивает пароль,
+	// если пользователь не найден. Считается один раз при первом обращении
+	dummyPasswordHash func() (string, error)
 }
 
 // NewAuthService создает новый сервис аутентификации
 func NewAuthService(
 	userRepo repository.UserRepository,
+	organizationRepo repository.OrganizationRepository,
+	membershipRepo repository.MembershipRepository,
 	sessionRepo repository.SessionRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	passwordResetRepo repository.PasswordResetRepository,
+	emailVerifyRepo repository.EmailVerificationRepository,
+	mfaRepo repository.MFARepository,
+	mfaChallengeRepo repository.MFAChallengeRepository,
+	loginAttemptRepo repository.LoginAttemptRepository,
+	roleRepo repository.RoleRepository,
+	accessCacheRepo repository.UserAccessCacheRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	serviceAccountRepo repository.ServiceAccountRepository,
+	oauthRepo repository.OAuthRepository,
+	oauthCodeRepo repository.OAuthCodeRepository,
+	tokenManager token.Manager,
+	passwordHasher password.Hasher,
+	passwordPolicy *password.Policy,
+	secretCipher keys.Cipher,
+	notifier notifier.Notifier,
 	logger logger.Logger,
-	sessionTTL time.Duration,
+	cfg config.AuthConfig,
 ) AuthService {
 	return &authService{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
-		logger:      logger,
-		sessionTTL:  sessionTTL,
+		userRepo:           userRepo,
+		organizationRepo:   organizationRepo,
+		membershipRepo:     membershipRepo,
+		sessionRepo:        sessionRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		passwordResetRepo:  passwordResetRepo,
+		emailVerifyRepo:    emailVerifyRepo,
+		mfaRepo:            mfaRepo,
+		mfaChallengeRepo:   mfaChallengeRepo,
+		loginAttemptRepo:   loginAttemptRepo,
+		roleRepo:           roleRepo,
+		accessCacheRepo:    accessCacheRepo,
+		apiKeyRepo:         apiKeyRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		oauthRepo:          oauthRepo,
+		oauthCodeRepo:      oauthCodeRepo,
+		tokenManager:       tokenManager,
+		passwordHasher:     passwordHasher,
+		passwordPolicy:     passwordPolicy,
+		secretCipher:       secretCipher,
+		notifier:           notifier,
+		logger:             logger,
+		cfg:                cfg,
+		dummyPasswordHash:  newDummyPasswordHash(passwordHasher),
 	}
 }
 
@@ -91,46 +301,43 @@ func (s *authService) Register(ctx context.Context, req RegisterRequest) (*Regis
 	if err := validator.ValidateUsername(req.Username); err != nil {
 		return nil, err
 	}
-	if err := validator.ValidatePassword(req.Password); err != nil {
+	if err := s.checkPasswordPolicy(passwordField, req.Password, req.Email, req.Username); err != nil {
 		return nil, err
 	}
 
-	// Проверяем, что пользователь не существует
-	existingUser, err := s.userRepo.GetUserByEmail(ctx, req.Email)
-	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
-		s.logger.Error("failed to check existing user", "error", err, "email", req.Email)
-		return nil, fmt.Errorf("failed to check existing user: %w", err)
-	}
-	if existingUser != nil {
-		return nil, apperrors.ErrUserAlreadyExists
-	}
-
-	// Хешируем пароль
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	// В отличие от входа, регистрация не скрывает неизвестную организацию:
+	// успешная регистрация и так показывает, что организация существует
+	organization, err := s.resolveTenant(ctx, req.Tenant)
 	if err != nil {
-		s.logger.Error("failed to hash password", "error", err)
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, err
 	}
 
 	// Создаем пользователя
 	user := &models.User{
-		UUID:         uuid.New(),
-		Email:        req.Email,
-		Username:     req.Username,
-		PasswordHash: string(passwordHash),
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		UUID:      uuid.New(),
+		TenantID:  organization.ID,
+		Email:     req.Email,
+		Username:  req.Username,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
-
-	if err := s.userRepo.CreateUser(ctx, user); err != nil {
-		s.logger.Error("failed to create user", "error", err, "email", req.Email)
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	if err := s.createUser(ctx, user, req.Password); err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("user registered successfully", "user_uuid", user.UUID, "email", req.Email)
+	s.logger.Info("user registered successfully", "user_uuid", user.UUID, "tenant", organization.Slug, "email", req.Email)
+
+	// Отправляем токен подтверждения email
+	s.deliverInBackground(ctx, user, "email verification token", s.sendEmailVerificationToken)
 
 	return &RegisterResponse{
-		UserUUID: user.UUID,
+		UserUUID:        user.UUID,
+		TenantID:        user.TenantID,
+		Email:           user.Email,
+		Username:        user.Username,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}, nil
 }
 
@@ -144,68 +351,399 @@ func (s *authService) Login(ctx context.Context, req LoginRequest) (*LoginRespon
 		return nil, err
 	}
 
-	// Получаем пользователя по email
-	user, err := s.userRepo.GetUserByEmail(ctx, req.Email)
+	// Неизвестная организация обрабатывается как незарегистрированный email: пользователь
+	// не найдется, пароль сравнится с фиктивным хешем, и ответ не выдаст, существует ли организация
+	organization, err := s.lookupTenant(ctx, req.Tenant)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserNotFound) {
-			return nil, apperrors.ErrInvalidCredentials
-		}
+		return nil, err
+	}
+	tenantID := uuid.Nil
+	if organization != nil {
+		tenantID = organization.ID
+	}
+
+	// Резервируем попытку входа до проверки пароля, чтобы параллельные запросы не обходили блокировку
+	if err := s.reserveLoginAttempt(ctx, tenantID, req.Email, req.Client.IP); err != nil {
+		return nil, err
+	}
+
+	// Получаем пользователя организации по email
+	user, err := s.userRepo.GetUserByEmail(ctx, tenantID, req.Email)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		s.releaseLoginAttempt(ctx, tenantID, req.Email, req.Client.IP)
 		s.logger.Error("failed to get user", "error", err, "email", req.Email)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// Проверяем пароль
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+	// Проверяем пароль. Для несуществующего пользователя сравниваем с фиктивным хешем,
+	// чтобы время ответа не выдавало, зарегистрирован ли email.
+	// Неверный пароль оставляет зарезервированную попытку неудачной
+	valid, err := s.verifyLoginPassword(user, req.Password)
+	if err != nil {
+		s.releaseLoginAttempt(ctx, tenantID, req.Email, req.Client.IP)
+		return nil, err
+	}
+	if !valid {
 		return nil, apperrors.ErrInvalidCredentials
 	}
+	s.releaseLoginAttempt(ctx, tenantID, req.Email, req.Client.IP)
 
-	// Создаем сессию
-	sessionUUID, err := s.sessionRepo.CreateSession(ctx, user.UUID, s.sessionTTL)
+	s.rehashPasswordIfNeeded(ctx, user, req.Password)
+
+	// Проверяем подтверждение email только после пароля, чтобы не раскрывать существование аккаунта
+	if s.cfg.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return nil, apperrors.ErrEmailNotVerified
+	}
+
+	now := time.Now()
+
+	// Пользователям с подключенным TOTP выдаем challenge вместо сессии
+	mfaEnabled, err := s.isMFAEnabled(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+	// Неудачные попытки сбрасываются только после всех факторов: иначе верный пароль
+	// позволял бы бесконечно перебирать коды второго фактора через новые challenge
+	if mfaEnabled {
+		return s.startMFAChallenge(ctx, user, req.IssueTokens, now)
+	}
+	s.resetAccountLoginFailures(ctx, tenantID, req.Email)
+
+	return s.startSession(ctx, user, req.Client, req.IssueTokens, now)
+}
+
+// createUser хеширует пароль и создает пользователя, если email в организации еще не занят
+func (s *authService) createUser(ctx context.Context, user *models.User, plainPassword string) error {
+	// Проверяем, что пользователь не существует в организации
+	existingUser, err := s.userRepo.GetUserByEmail(ctx, user.TenantID, user.Email)
+	if err != nil && !errors.Is(err, apperrors.ErrUserNotFound) {
+		s.logger.Error("failed to check existing user", "error", err, "email", user.Email)
+		return fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existingUser != nil {
+		return apperrors.ErrUserAlreadyExists
+	}
+
+	// Хешируем пароль
+	user.PasswordHash, err = s.passwordHasher.Hash(plainPassword)
 	if err != nil {
+		s.logger.Error("failed to hash password", "error", err)
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, apperrors.ErrUserAlreadyExists) {
+			return apperrors.ErrUserAlreadyExists
+		}
+		s.logger.Error("failed to create user", "error", err, "email", user.Email)
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil
+}
+
+// startSession создает сессию или выдает токены после успешной аутентификации
+func (s *authService) startSession(
+	ctx context.Context,
+	user *models.User,
+	client ClientInfo,
+	issueTokens bool,
+	now time.Time,
+) (*LoginResponse, error) {
+	// Выдаем токены вместо сессии, если клиент их запросил
+	if issueTokens {
+		tokens, err := s.issueTokens(ctx, user, now)
+		if err != nil {
+			return nil, err
+		}
+
+		s.logger.Info("user logged in with tokens", "user_uuid", user.UUID)
+
+		return &LoginResponse{
+			UserUUID: user.UUID,
+			Tokens:   tokens,
+		}, nil
+	}
+
+	// Создаем сессию
+	session := &models.Session{
+		UserUUID:             user.UUID,
+		TenantID:             user.TenantID,
+		ActiveOrganizationID: user.TenantID,
+		IP:                   client.IP,
+		UserAgent:            client.UserAgent,
+		ClientName:           client.ClientName,
+		CreatedAt:            now,
+		LastSeenAt:           now,
+		ExpiresAt:            now.Add(s.cfg.SessionAbsoluteTimeout),
+	}
+	if err := s.sessionRepo.CreateSession(ctx, session, s.cfg.SessionIdleTimeout); err != nil {
 		s.logger.Error("failed to create session", "error", err, "user_uuid", user.UUID)
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	s.logger.Info("user logged in successfully", "user_uuid", user.UUID, "session_uuid", sessionUUID)
+	s.logger.Info("user logged in successfully", "user_uuid", user.UUID, "session_uuid", session.UUID)
 
 	return &LoginResponse{
-		SessionUUID: sessionUUID,
+		SessionUUID: session.UUID,
+		UserUUID:    user.UUID,
+		ExpiresAt:   session.ExpiresAt,
 	}, nil
 }
 
 // WhoAmI возвращает информацию о текущем пользователе
 func (s *authService) WhoAmI(ctx context.Context, req WhoAmIRequest) (*WhoAmIResponse, error) {
+	// Пользователь может быть определен по access токену или API ключу вместо сессии
+	credentials := 0
+	for _, credential := range []string{req.SessionUUID, req.AccessToken, req.APIKey} {
+		if credential != "" {
+			credentials++
+		}
+	}
+	if credentials > 1 {
+		return nil, fmt.Errorf("%w: only one of session_uuid, access_token and api_key must be set", apperrors.ErrInvalidInput)
+	}
+	if req.AccessToken != "" {
+		return s.whoAmIByAccessToken(ctx, req.AccessToken)
+	}
+	if req.APIKey != "" {
+		return s.whoAmIByAPIKey(ctx, req.APIKey)
+	}
+
 	// Валидация входных данных
 	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
 		return nil, err
 	}
 
-	// Получаем UUID пользователя из сессии
-	userUUID, err := s.sessionRepo.GetSession(ctx, req.SessionUUID)
+	// Получаем сессию и продлеваем ее на время бездействия
+	session, err := s.sessionRepo.TouchSession(ctx, req.SessionUUID, time.Now(), s.cfg.SessionIdleTimeout)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrSessionNotFound) {
 			return nil, apperrors.ErrSessionNotFound
 		}
-		s.logger.Error("failed to get session", "error", err, "session_uuid", req.SessionUUID)
-		return nil, fmt.Errorf("failed to get session: %w", err)
+		s.logger.Error("failed to touch session", "error", err, "session_uuid", req.SessionUUID)
+		return nil, fmt.Errorf("failed to touch session: %w", err)
 	}
 
 	// Получаем пользователя
-	user, err := s.userRepo.GetUserByUUID(ctx, userUUID)
+	user, err := s.userRepo.GetUserByUUID(ctx, session.TenantID, session.UserUUID)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrUserNotFound) {
 			// Удаляем невалидную сессию
 			_ = s.sessionRepo.DeleteSession(ctx, req.SessionUUID)
 			return nil, apperrors.ErrSessionNotFound
 		}
-		s.logger.Error("failed to get user", "error", err, "user_uuid", userUUID)
+		s.logger.Error("failed to get user", "error", err, "user_uuid", session.UserUUID)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	organization, member, err := s.activeMembership(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// Роли и разрешения отдаем сразу, чтобы вызывающий сервис мог авторизовать запрос без отдельного вызова
+	access, err := s.getUserAccess(ctx, organization.ID, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &WhoAmIResponse{
-		UserUUID:  user.UUID,
-		Email:     user.Email,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
+		PrincipalType:    models.PrincipalTypeUser,
+		UserUUID:         user.UUID,
+		TenantID:         user.TenantID,
+		Email:            user.Email,
+		Username:         user.Username,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		Session:          newSessionInfo(session, session.UUID),
+		Organization:     *organization,
+		OrganizationRole: member.Role,
+		Roles:            access.Roles,
+		Permissions:      access.Permissions,
+	}, nil
+}
+
+// Logout завершает текущую сессию пользователя
+func (s *authService) Logout(ctx context.Context, req LogoutRequest) (*LogoutResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	// Проверяем, что сессия существует
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Удаляем сессию
+	if err := s.sessionRepo.DeleteSession(ctx, req.SessionUUID); err != nil {
+		s.logger.Error("failed to delete session", "error", err, "session_uuid", req.SessionUUID)
+		return nil, fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	s.logger.Info("user logged out successfully", "user_uuid", session.UserUUID, "session_uuid", req.SessionUUID)
+
+	return &LogoutResponse{}, nil
+}
+
+// LogoutAll завершает все сессии и отзывает refresh токены пользователя, которому принадлежит текущая сессия
+func (s *authService) LogoutAll(ctx context.Context, req LogoutAllRequest) (*LogoutAllResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	// Получаем сессию
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Удаляем все сессии пользователя
+	revoked, err := s.sessionRepo.DeleteUserSessions(ctx, session.UserUUID)
+	if err != nil {
+		s.logger.Error("failed to delete user sessions", "error", err, "user_uuid", session.UserUUID)
+		return nil, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	// Отзываем refresh токены пользователя, чтобы выход был полным
+	revokedFamilies, err := s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, session.UserUUID)
+	if err != nil {
+		s.logger.Error("failed to revoke refresh tokens", "error", err, "user_uuid", session.UserUUID)
+		return nil, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	s.logger.Info("user logged out from all sessions",
+		"user_uuid", session.UserUUID,
+		"revoked_sessions", revoked,
+		"revoked_token_families", revokedFamilies,
+	)
+
+	return &LogoutAllResponse{
+		RevokedSessions: revoked,
+	}, nil
+}
+
+// ListSessions возвращает активные сессии пользователя, которому принадлежит текущая сессия
+func (s *authService) ListSessions(ctx context.Context, req ListSessionsRequest) (*ListSessionsResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	// Получаем сессию
+	current, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем все сессии пользователя
+	sessions, err := s.sessionRepo.ListUserSessions(ctx, current.UserUUID)
+	if err != nil {
+		s.logger.Error("failed to list user sessions", "error", err, "user_uuid", current.UserUUID)
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, newSessionInfo(session, current.UUID))
+	}
+
+	return &ListSessionsResponse{
+		Sessions: infos,
 	}, nil
 }
+
+// RevokeSession завершает другую сессию пользователя, не трогая текущую
+func (s *authService) RevokeSession(ctx context.Context, req RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateSessionUUID(req.TargetSessionUUID); err != nil {
+		return nil, err
+	}
+	if req.SessionUUID == req.TargetSessionUUID {
+		return nil, fmt.Errorf("%w: use Logout to end the current session", apperrors.ErrInvalidInput)
+	}
+
+	// Получаем текущую сессию
+	current, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем завершаемую сессию и проверяем, что она принадлежит тому же пользователю
+	target, err := s.sessionRepo.GetSession(ctx, req.TargetSessionUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrSessionNotFound) {
+			return nil, apperrors.ErrTargetSessionNotFound
+		}
+		s.logger.Error("failed to get session", "error", err, "session_uuid", req.TargetSessionUUID)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if target.UserUUID != current.UserUUID {
+		return nil, apperrors.ErrTargetSessionNotFound
+	}
+
+	// Удаляем сессию
+	if err := s.sessionRepo.DeleteSession(ctx, req.TargetSessionUUID); err != nil {
+		s.logger.Error("failed to delete session", "error", err, "session_uuid", req.TargetSessionUUID)
+		return nil, fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	s.logger.Info("session revoked successfully", "user_uuid", current.UserUUID, "session_uuid", req.TargetSessionUUID)
+
+	return &RevokeSessionResponse{}, nil
+}
+
+// deliverInBackground выполняет выдачу и доставку токена пользователю в фоне.
+// Доставка не должна ни задерживать ответ, ни прерываться вместе с запросом
+func (s *authService) deliverInBackground(
+	ctx context.Context,
+	user *models.User,
+	what string,
+	deliver func(ctx context.Context, user *models.User) error,
+) {
+	go func() {
+		deliveryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deliveryTimeout)
+		defer cancel()
+
+		if err := deliver(deliveryCtx, user); err != nil {
+			s.logger.Error("failed to send "+what, "error", err, "user_uuid", user.UUID)
+		}
+	}()
+}
+
+// getSession получает сессию и приводит ошибки репозитория к ошибкам сервиса
+func (s *authService) getSession(ctx context.Context, sessionUUID string) (*models.Session, error) {
+	session, err := s.sessionRepo.GetSession(ctx, sessionUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrSessionNotFound) {
+			return nil, apperrors.ErrSessionNotFound
+		}
+		s.logger.Error("failed to get session", "error", err, "session_uuid", sessionUUID)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// newSessionInfo конвертирует сессию в информацию о ней для ответа клиенту
+func newSessionInfo(session *models.Session, currentSessionUUID string) SessionInfo {
+	return SessionInfo{
+		SessionUUID: session.UUID,
+		Client: ClientInfo{
+			IP:         session.IP,
+			UserAgent:  session.UserAgent,
+			ClientName: session.ClientName,
+		},
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.UUID == currentSessionUUID,
+	}
+}
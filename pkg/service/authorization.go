@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// maxPermissionChecks максимальное количество проверок в одном CheckPermissions
+const maxPermissionChecks = 100
+
+// PermissionCheck проверяемое разрешение
+type PermissionCheck struct {
+	Permission string
+	// Resource ресурс, к которому применяется разрешение. Пустой - только глобальные роли
+	Resource string
+}
+
+// PermissionDecision решение по разрешению
+type PermissionDecision struct {
+	Permission string
+	Resource   string
+	Allowed    bool
+	// Reason почему разрешение дано или нет, для журналов вызывающего сервиса
+	Reason string
+}
+
+// CheckPermissionRequest запрос на проверку разрешения пользователя.
+// Пользователь определяется по сессии, access токену или API ключу
+type CheckPermissionRequest struct {
+	SessionUUID string
+	AccessToken string
+	APIKey      string
+	PermissionCheck
+}
+
+// CheckPermissionsRequest запрос на проверку нескольких разрешений пользователя
+type CheckPermissionsRequest struct {
+	SessionUUID string
+	AccessToken string
+	APIKey      string
+	Checks      []PermissionCheck
+}
+
+// CheckPermissionsResponse решения в порядке проверок запроса
+type CheckPermissionsResponse struct {
+	Decisions []PermissionDecision
+}
+
+// CheckPermission проверяет, что роли пользователя дают разрешение на ресурс
+func (s *authService) CheckPermission(ctx context.Context, req CheckPermissionRequest) (*PermissionDecision, error) {
+	resp, err := s.CheckPermissions(ctx, CheckPermissionsRequest{
+		SessionUUID: req.SessionUUID,
+		AccessToken: req.AccessToken,
+		APIKey:      req.APIKey,
+		Checks:      []PermissionCheck{req.PermissionCheck},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Decisions[0], nil
+}
+
+// CheckPermissions проверяет несколько разрешений пользователя за один вызов
+func (s *authService) CheckPermissions(ctx context.Context, req CheckPermissionsRequest) (*CheckPermissionsResponse, error) {
+	// Валидация входных данных
+	if len(req.Checks) == 0 {
+		return nil, fmt.Errorf("%w: at least one check is required", apperrors.ErrInvalidInput)
+	}
+	if len(req.Checks) > maxPermissionChecks {
+		return nil, fmt.Errorf("%w: at most %d checks are allowed", apperrors.ErrInvalidInput, maxPermissionChecks)
+	}
+	for _, check := range req.Checks {
+		if err := validator.ValidatePermission(check.Permission); err != nil {
+			return nil, err
+		}
+		if err := validator.ValidateResource(check.Resource); err != nil {
+			return nil, err
+		}
+	}
+
+	caller, err := s.authenticateCaller(ctx, req.SessionUUID, req.AccessToken, req.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.getUserAccess(ctx, caller.organizationID, caller.UserUUID)
+	if err != nil {
+		return nil, err
+	}
+	// По API ключу действуют только разрешения из его областей, как в WhoAmI
+	if caller.apiKey != nil {
+		access = access.RestrictTo(caller.apiKey.Scopes)
+	}
+
+	decisions := make([]PermissionDecision, 0, len(req.Checks))
+	for _, check := range req.Checks {
+		decisions = append(decisions, decidePermission(access, check))
+	}
+
+	return &CheckPermissionsResponse{
+		Decisions: decisions,
+	}, nil
+}
+
+// decidePermission принимает решение по одному разрешению
+func decidePermission(access *models.UserAccess, check PermissionCheck) PermissionDecision {
+	decision := PermissionDecision{
+		Permission: check.Permission,
+		Resource:   check.Resource,
+	}
+
+	grant, found := access.FindGrant(check.Permission, check.Resource)
+	switch {
+	case !found && check.Resource == "":
+		decision.Reason = fmt.Sprintf("no role grants %s", check.Permission)
+	case !found:
+		decision.Reason = fmt.Sprintf("no role grants %s on %s", check.Permission, check.Resource)
+	case grant.Resource == "":
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("granted by role %s", grant.Role)
+	default:
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("granted by role %s on %s", grant.Role, grant.Resource)
+	}
+
+	return decision
+}
+
+// callerIdentity пользователь, выполняющий запрос, и организация, от имени которой он действует
+type callerIdentity struct {
+	models.UserRef
+	organizationID uuid.UUID
+	// apiKey ключ, которым аутентифицирован пользователь. Его области ограничивают права
+	apiKey *models.APIKey
+}
+
+// authenticateCaller определяет пользователя по сессии, access токену входа в сервис или API ключу.
+// По сессии действует ее активная организация, по access токену и API ключу - организация пользователя.
+// В отличие от WhoAmI, сессия при этом не продлевается
+func (s *authService) authenticateCaller(ctx context.Context, sessionUUID, accessToken, apiKey string) (callerIdentity, error) {
+	credentials := 0
+	for _, credential := range []string{sessionUUID, accessToken, apiKey} {
+		if credential != "" {
+			credentials++
+		}
+	}
+	if credentials > 1 {
+		return callerIdentity{}, fmt.Errorf("%w: only one of session_uuid, access_token and api_key must be set", apperrors.ErrInvalidInput)
+	}
+
+	if apiKey != "" {
+		authenticated, err := s.authenticateAPIKey(ctx, apiKey)
+		if err != nil {
+			return callerIdentity{}, err
+		}
+		// У сервисных аккаунтов нет ролей, их области проверяет вызывающий сервис
+		if authenticated.User == nil {
+			return callerIdentity{}, apperrors.ErrInvalidAPIKey
+		}
+		return callerIdentity{
+			UserRef:        models.UserRef{TenantID: authenticated.User.TenantID, UserUUID: authenticated.User.UUID},
+			organizationID: authenticated.User.TenantID,
+			apiKey:         authenticated.APIKey,
+		}, nil
+	}
+
+	if accessToken != "" {
+		// Токены OAuth клиентов, в том числе выданные сервисным аккаунтам, здесь не принимаются
+		claims, err := s.parseFirstPartyAccessToken(accessToken)
+		if err != nil {
+			return callerIdentity{}, err
+		}
+		userUUID, err := claims.UserUUID()
+		if err != nil {
+			return callerIdentity{}, err
+		}
+		tenantID, err := claims.Tenant()
+		if err != nil {
+			return callerIdentity{}, err
+		}
+		return callerIdentity{
+			UserRef:        models.UserRef{TenantID: tenantID, UserUUID: userUUID},
+			organizationID: tenantID,
+		}, nil
+	}
+
+	if err := validator.ValidateSessionUUID(sessionUUID); err != nil {
+		return callerIdentity{}, err
+	}
+
+	session, err := s.getSession(ctx, sessionUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrSessionNotFound) {
+			return callerIdentity{}, apperrors.ErrSessionNotFound
+		}
+		return callerIdentity{}, err
+	}
+
+	return callerIdentity{
+		UserRef:        models.UserRef{TenantID: session.TenantID, UserUUID: session.UserUUID},
+		organizationID: session.ActiveOrganizationID,
+	}, nil
+}
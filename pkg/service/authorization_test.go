@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+)
+
+func TestDecidePermission(t *testing.T) {
+	access := &models.UserAccess{
+		Grants: []models.RoleGrant{
+			{Role: "editor", Resource: "docs/1", Permissions: []string{"docs:write"}},
+			{Role: "viewer", Permissions: []string{"docs:read"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		check PermissionCheck
+		want  PermissionDecision
+	}{
+		{
+			name:  "глобальная роль",
+			check: PermissionCheck{Permission: "docs:read", Resource: "docs/1"},
+			want: PermissionDecision{
+				Permission: "docs:read",
+				Resource:   "docs/1",
+				Allowed:    true,
+				Reason:     "granted by role viewer",
+			},
+		},
+		{
+			name:  "роль на ресурс",
+			check: PermissionCheck{Permission: "docs:write", Resource: "docs/1"},
+			want: PermissionDecision{
+				Permission: "docs:write",
+				Resource:   "docs/1",
+				Allowed:    true,
+				Reason:     "granted by role editor on docs/1",
+			},
+		},
+		{
+			name:  "роль на другой ресурс",
+			check: PermissionCheck{Permission: "docs:write", Resource: "docs/2"},
+			want: PermissionDecision{
+				Permission: "docs:write",
+				Resource:   "docs/2",
+				Reason:     "no role grants docs:write on docs/2",
+			},
+		},
+		{
+			name:  "без ресурса роли на ресурс не учитываются",
+			check: PermissionCheck{Permission: "docs:write"},
+			want: PermissionDecision{
+				Permission: "docs:write",
+				Reason:     "no role grants docs:write",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decidePermission(access, tt.check); got != tt.want {
+				t.Errorf("decidePermission() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPermissionsCredentials(t *testing.T) {
+	organization := newTestOrganization("acme")
+	user := &models.User{UUID: uuid.New(), TenantID: organization.ID}
+	serviceAccount := &models.ServiceAccount{ID: uuid.New(), TenantID: organization.ID}
+
+	newAPIKey := func(t *testing.T, principalType models.PrincipalType, principalID uuid.UUID, scopes []string) (string, *models.APIKey) {
+		t.Helper()
+		rawAPIKey, prefix, err := token.GenerateAPIKey()
+		if err != nil {
+			t.Fatalf("GenerateAPIKey() unexpected error: %v", err)
+		}
+		return rawAPIKey, &models.APIKey{
+			ID:            uuid.New(),
+			TenantID:      organization.ID,
+			PrincipalType: principalType,
+			PrincipalID:   principalID,
+			Prefix:        prefix,
+			KeyHash:       token.HashOpaqueToken(rawAPIKey),
+			Scopes:        scopes,
+		}
+	}
+	readKey, readAPIKey := newAPIKey(t, models.PrincipalTypeUser, user.UUID, []string{"docs:read"})
+	unscopedKey, unscopedAPIKey := newAPIKey(t, models.PrincipalTypeUser, user.UUID, nil)
+	serviceAccountKey, serviceAccountAPIKey := newAPIKey(t, models.PrincipalTypeServiceAccount, serviceAccount.ID, []string{"docs:read"})
+
+	s := newTestAuthService()
+	s.userRepo = &stubUserRepository{users: []*models.User{user}}
+	s.serviceAccountRepo = &stubServiceAccountRepository{serviceAccounts: []*models.ServiceAccount{serviceAccount}}
+	s.apiKeyRepo = &stubAPIKeyRepository{apiKeys: []*models.APIKey{readAPIKey, unscopedAPIKey, serviceAccountAPIKey}}
+	s.roleRepo = &stubRoleRepository{access: map[uuid.UUID]*models.UserAccess{
+		organization.ID: {Grants: []models.RoleGrant{
+			{Role: "editor", Permissions: []string{"docs:read", "docs:write"}},
+		}},
+	}}
+	s.tokenManager = &stubTokenManager{claims: map[string]*token.Claims{
+		"first-party": {
+			RegisteredClaims: jwt.RegisteredClaims{Subject: user.UUID.String()},
+			TenantID:         organization.ID.String(),
+		},
+	}}
+
+	checks := []PermissionCheck{{Permission: "docs:read"}, {Permission: "docs:write"}}
+
+	tests := []struct {
+		name        string
+		accessToken string
+		apiKey      string
+		want        []bool
+		wantErr     error
+	}{
+		{name: "access токен", accessToken: "first-party", want: []bool{true, true}},
+		{name: "API ключ ограничен своими областями", apiKey: readKey, want: []bool{true, false}},
+		{name: "API ключ без областей", apiKey: unscopedKey, want: []bool{false, false}},
+		{name: "API ключ сервисного аккаунта", apiKey: serviceAccountKey, wantErr: apperrors.ErrInvalidAPIKey},
+		{name: "неизвестный API ключ", apiKey: "ak_unknown", wantErr: apperrors.ErrInvalidAPIKey},
+		{name: "access токен вместе с API ключом", accessToken: "first-party", apiKey: readKey, wantErr: apperrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.CheckPermissions(context.Background(), CheckPermissionsRequest{
+				AccessToken: tt.accessToken,
+				APIKey:      tt.apiKey,
+				Checks:      checks,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckPermissions() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			got := make([]bool, 0, len(resp.Decisions))
+			for _, decision := range resp.Decisions {
+				got = append(got, decision.Allowed)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckPermissions() allowed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/notifier"
+	"github.com/olezhek28/auth-service/pkg/token"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// VerifyEmailRequest запрос на подтверждение email
+type VerifyEmailRequest struct {
+	Token string
+}
+
+// VerifyEmailResponse ответ на подтверждение email
+type VerifyEmailResponse struct{}
+
+// ResendVerificationRequest запрос на повторную отправку токена подтверждения email
+type ResendVerificationRequest struct {
+	// Tenant slug организации пользователя
+	Tenant string
+	Email  string
+}
+
+// ResendVerificationResponse ответ на повторную отправку токена подтверждения email.
+// Одинаков для существующих, несуществующих и уже подтвержденных email
+type ResendVerificationResponse struct{}
+
+// VerifyEmail подтверждает email пользователя по токену
+func (s *authService) VerifyEmail(ctx context.Context, req VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateEmailVerificationToken(req.Token); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// Используем токен
+	verified, err := s.emailVerifyRepo.ConsumeEmailVerificationToken(ctx, token.HashOpaqueToken(req.Token), now)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidEmailVerificationToken) {
+			return nil, apperrors.ErrInvalidEmailVerificationToken
+		}
+		s.logger.Error("failed to consume email verification token", "error", err)
+		return nil, fmt.Errorf("failed to consume email verification token: %w", err)
+	}
+
+	if err := s.userRepo.MarkEmailVerified(ctx, verified.TenantID, verified.UserUUID, now); err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidEmailVerificationToken
+		}
+		s.logger.Error("failed to mark email verified", "error", err, "user_uuid", verified.UserUUID)
+		return nil, fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	s.logger.Info("email verified successfully", "user_uuid", verified.UserUUID)
+
+	return &VerifyEmailResponse{}, nil
+}
+
+// ResendVerification отправляет новый токен подтверждения email.
+// Как и RequestPasswordReset, не раскрывает, зарегистрирован ли email
+func (s *authService) ResendVerification(ctx context.Context, req ResendVerificationRequest) (*ResendVerificationResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.lookupTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return &ResendVerificationResponse{}, nil
+	}
+
+	// Получаем пользователя по email
+	user, err := s.userRepo.GetUserByEmail(ctx, organization.ID, req.Email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return &ResendVerificationResponse{}, nil
+		}
+		s.logger.Error("failed to get user", "error", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.EmailVerifiedAt == nil {
+		s.deliverInBackground(ctx, user, "email verification token", s.sendEmailVerificationToken)
+	}
+
+	return &ResendVerificationResponse{}, nil
+}
+
+// sendEmailVerificationToken выдает пользователю новый токен подтверждения email и доставляет его
+func (s *authService) sendEmailVerificationToken(ctx context.Context, user *models.User) error {
+	verificationToken, err := token.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stored := &models.EmailVerificationToken{
+		TokenHash: token.HashOpaqueToken(verificationToken),
+		UserUUID:  user.UUID,
+		TenantID:  user.TenantID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.cfg.EmailVerificationTTL),
+	}
+	if err := s.emailVerifyRepo.CreateEmailVerificationToken(ctx, stored); err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	err = s.notifier.Notify(ctx, notifier.Message{
+		To:      user.Email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf(
+			"Use this token to confirm your email: %s\nIt expires at %s.",
+			verificationToken,
+			stored.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver email verification token: %w", err)
+	}
+
+	s.logger.Info("email verification token sent", "user_uuid", user.UUID)
+
+	return nil
+}
@@ -0,0 +1,686 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/logger"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/notifier"
+	"github.com/olezhek28/auth-service/pkg/password"
+	"github.com/olezhek28/auth-service/pkg/repository"
+	"github.com/olezhek28/auth-service/pkg/token"
+)
+
+// newTestAuthService создает сервис только с логгером, пустым кешем прав и счетчиком попыток входа
+// в памяти. Тесты подставляют нужные им репозитории
+func newTestAuthService() *authService {
+	return &authService{
+		accessCacheRepo:  stubAccessCacheRepository{},
+		loginAttemptRepo: newMemoryLoginAttemptRepository(),
+		logger:           logger.New(slog.LevelError),
+	}
+}
+
+// newTestPasswordHasher создает быстрый bcrypt хешер, чтобы тесты не тратили время на хеширование
+func newTestPasswordHasher(tb testing.TB) password.Hasher {
+	tb.Helper()
+
+	cfg := timingHasherConfig
+	cfg.Algorithm = password.AlgorithmBcrypt
+	cfg.BcryptCost = bcrypt.MinCost
+
+	hasher, err := password.NewHasher(cfg)
+	if err != nil {
+		tb.Fatalf("failed to create password hasher: %v", err)
+	}
+	return hasher
+}
+
+// newTestOrganization создает организацию с новым идентификатором
+func newTestOrganization(slug string) *models.Organization {
+	return &models.Organization{ID: uuid.New(), Slug: slug}
+}
+
+// stubUserRepository находит только заданных пользователей и меняет их пароли.
+// Остальные методы не реализованы
+type stubUserRepository struct {
+	repository.UserRepository
+
+	users []*models.User
+	// passwordHistory прежние хеши паролей по UUID пользователя, последний в начале
+	passwordHistory map[uuid.UUID][]string
+}
+
+func (r *stubUserRepository) GetUserByEmail(_ context.Context, tenantID uuid.UUID, email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.TenantID == tenantID && user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, apperrors.ErrUserNotFound
+}
+
+func (r *stubUserRepository) GetUserByUUID(_ context.Context, tenantID, userUUID uuid.UUID) (*models.User, error) {
+	for _, user := range r.users {
+		if user.TenantID == tenantID && user.UUID == userUUID {
+			return user, nil
+		}
+	}
+	return nil, apperrors.ErrUserNotFound
+}
+
+func (r *stubUserRepository) UpdatePassword(_ context.Context, tenantID, userUUID uuid.UUID, passwordHash string, _ int) error {
+	user, err := r.GetUserByUUID(context.Background(), tenantID, userUUID)
+	if err != nil {
+		return err
+	}
+	if r.passwordHistory == nil {
+		r.passwordHistory = make(map[uuid.UUID][]string)
+	}
+	r.passwordHistory[userUUID] = append([]string{user.PasswordHash}, r.passwordHistory[userUUID]...)
+	user.PasswordHash = passwordHash
+	return nil
+}
+
+func (r *stubUserRepository) GetPasswordHistory(_ context.Context, _, userUUID uuid.UUID, limit int) ([]string, error) {
+	history := r.passwordHistory[userUUID]
+	return history[:min(limit, len(history))], nil
+}
+
+// stubOrganizationRepository находит только заданные организации. Остальные методы не реализованы
+type stubOrganizationRepository struct {
+	repository.OrganizationRepository
+
+	organizations []*models.Organization
+}
+
+func (r *stubOrganizationRepository) GetOrganizationBySlug(_ context.Context, slug string) (*models.Organization, error) {
+	for _, organization := range r.organizations {
+		if slug == organization.Slug {
+			return organization, nil
+		}
+	}
+	return nil, apperrors.ErrOrganizationNotFound
+}
+
+func (r *stubOrganizationRepository) GetOrganizationByID(_ context.Context, id uuid.UUID) (*models.Organization, error) {
+	for _, organization := range r.organizations {
+		if id == organization.ID {
+			return organization, nil
+		}
+	}
+	return nil, apperrors.ErrOrganizationNotFound
+}
+
+// stubMembershipRepository находит только заданных участников. Остальные методы не реализованы
+type stubMembershipRepository struct {
+	repository.MembershipRepository
+
+	members []*models.Member
+}
+
+func (r *stubMembershipRepository) GetMember(_ context.Context, organizationID, userUUID uuid.UUID) (*models.Member, error) {
+	for _, member := range r.members {
+		if member.OrganizationID == organizationID && member.UserUUID == userUUID {
+			return member, nil
+		}
+	}
+	return nil, apperrors.ErrMemberNotFound
+}
+
+// stubRoleRepository выдает роли без проверки, находит только заданные роли
+// и возвращает заданные права по организациям. Остальные методы не реализованы
+type stubRoleRepository struct {
+	repository.RoleRepository
+
+	roles []*models.Role
+	// access права пользователя по идентификатору организации
+	access map[uuid.UUID]*models.UserAccess
+	// grantedTenants организации, в которых выдавались роли
+	grantedTenants []uuid.UUID
+}
+
+func (r *stubRoleRepository) GrantRole(_ context.Context, tenantID, _ uuid.UUID, _, _ string) (bool, error) {
+	r.grantedTenants = append(r.grantedTenants, tenantID)
+	return true, nil
+}
+
+func (r *stubRoleRepository) GetRolePermissions(_ context.Context, tenantID uuid.UUID, roleName string) ([]string, error) {
+	for _, role := range r.roles {
+		if role.TenantID == tenantID && role.Name == roleName {
+			return role.Permissions, nil
+		}
+	}
+	return nil, apperrors.ErrRoleNotFound
+}
+
+func (r *stubRoleRepository) GetUserAccess(_ context.Context, organizationID, _ uuid.UUID) (*models.UserAccess, error) {
+	if access, ok := r.access[organizationID]; ok {
+		return access, nil
+	}
+	return &models.UserAccess{}, nil
+}
+
+// stubAccessCacheRepository кеш, в котором никогда ничего нет
+type stubAccessCacheRepository struct{}
+
+func (stubAccessCacheRepository) GetUserAccess(context.Context, uuid.UUID, uuid.UUID) (*models.UserAccess, int64, error) {
+	return nil, 0, nil
+}
+
+func (stubAccessCacheRepository) SetUserAccess(
+	context.Context,
+	uuid.UUID,
+	uuid.UUID,
+	int64,
+	*models.UserAccess,
+	time.Duration,
+) error {
+	return nil
+}
+
+func (stubAccessCacheRepository) InvalidateUserAccess(context.Context, uuid.UUID) error {
+	return nil
+}
+
+// stubServiceAccountRepository находит только заданные сервисные аккаунты. Остальные методы не реализованы
+type stubServiceAccountRepository struct {
+	repository.ServiceAccountRepository
+
+	serviceAccounts []*models.ServiceAccount
+}
+
+func (r *stubServiceAccountRepository) GetServiceAccount(
+	_ context.Context,
+	tenantID uuid.UUID,
+	serviceAccountID uuid.UUID,
+) (*models.ServiceAccount, error) {
+	for _, serviceAccount := range r.serviceAccounts {
+		if serviceAccount.TenantID == tenantID && serviceAccount.ID == serviceAccountID {
+			return serviceAccount, nil
+		}
+	}
+	return nil, apperrors.ErrServiceAccountNotFound
+}
+
+// stubAPIKeyRepository находит только заданные ключи по хешу. Остальные методы не реализованы
+type stubAPIKeyRepository struct {
+	repository.APIKeyRepository
+
+	apiKeys []*models.APIKey
+}
+
+func (r *stubAPIKeyRepository) GetActiveAPIKeyByHash(_ context.Context, keyHash string, _ time.Time) (*models.APIKey, error) {
+	for _, apiKey := range r.apiKeys {
+		if apiKey.KeyHash == keyHash {
+			return apiKey, nil
+		}
+	}
+	return nil, apperrors.ErrInvalidAPIKey
+}
+
+func (r *stubAPIKeyRepository) TouchAPIKey(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+// stubOAuthRepository хранит только согласия пользователей. Остальные методы не реализованы
+type stubOAuthRepository struct {
+	repository.OAuthRepository
+
+	consents []*models.OAuthConsent
+}
+
+func (r *stubOAuthRepository) DeleteOAuthConsent(_ context.Context, userUUID, clientID uuid.UUID) (bool, error) {
+	for i, consent := range r.consents {
+		if consent.UserUUID == userUUID && consent.ClientID == clientID {
+			r.consents = slices.Delete(r.consents, i, i+1)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// stubSessionRepository находит только заданные сессии и запоминает, чьи сессии завершались.
+// Остальные методы не реализованы
+type stubSessionRepository struct {
+	repository.SessionRepository
+
+	sessions     []*models.Session
+	deletedUsers []uuid.UUID
+}
+
+func (r *stubSessionRepository) GetSession(_ context.Context, sessionUUID string) (*models.Session, error) {
+	for _, session := range r.sessions {
+		if session.UUID == sessionUUID {
+			return session, nil
+		}
+	}
+	return nil, apperrors.ErrSessionNotFound
+}
+
+func (r *stubSessionRepository) DeleteUserSessions(_ context.Context, userUUID uuid.UUID) (int, error) {
+	r.deletedUsers = append(r.deletedUsers, userUUID)
+	return 1, nil
+}
+
+func (r *stubSessionRepository) DeleteOtherUserSessions(_ context.Context, userUUID uuid.UUID, _ string) (int, error) {
+	r.deletedUsers = append(r.deletedUsers, userUUID)
+	return 1, nil
+}
+
+// memoryOneTimeToken одноразовый токен в памяти
+type memoryOneTimeToken struct {
+	user      models.UserRef
+	expiresAt time.Time
+	used      bool
+}
+
+// memoryPasswordResetRepository токены сброса пароля в памяти
+type memoryPasswordResetRepository struct {
+	mu     sync.Mutex
+	tokens map[string]*memoryOneTimeToken
+}
+
+func newMemoryPasswordResetRepository() *memoryPasswordResetRepository {
+	return &memoryPasswordResetRepository{tokens: make(map[string]*memoryOneTimeToken)}
+}
+
+func (r *memoryPasswordResetRepository) CreatePasswordResetToken(_ context.Context, token *models.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.TokenHash] = &memoryOneTimeToken{
+		user:      models.UserRef{TenantID: token.TenantID, UserUUID: token.UserUUID},
+		expiresAt: token.ExpiresAt,
+	}
+	return nil
+}
+
+func (r *memoryPasswordResetRepository) GetPasswordResetTokenUser(_ context.Context, tokenHash string, now time.Time) (models.UserRef, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[tokenHash]
+	if !ok || stored.used || !now.Before(stored.expiresAt) {
+		return models.UserRef{}, apperrors.ErrInvalidPasswordResetToken
+	}
+	return stored.user, nil
+}
+
+func (r *memoryPasswordResetRepository) ConsumePasswordResetToken(_ context.Context, tokenHash string, now time.Time) (models.UserRef, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[tokenHash]
+	if !ok || stored.used || !now.Before(stored.expiresAt) {
+		return models.UserRef{}, apperrors.ErrInvalidPasswordResetToken
+	}
+	stored.used = true
+	return stored.user, nil
+}
+
+// recordingNotifier запоминает отправленные сообщения
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []notifier.Message
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, msg notifier.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// stubTokenManager возвращает заранее заданные claims по строке токена. Остальные методы не реализованы
+type stubTokenManager struct {
+	token.Manager
+
+	claims map[string]*token.Claims
+}
+
+func (m *stubTokenManager) ParseAccessToken(accessToken string) (*token.Claims, error) {
+	if claims, ok := m.claims[accessToken]; ok {
+		return claims, nil
+	}
+	return nil, apperrors.ErrInvalidAccessToken
+}
+
+// memoryLoginAttempts счетчик неудач и блокировка одного объекта
+type memoryLoginAttempts struct {
+	failures     int
+	windowEnds   time.Time
+	block        repository.LoginBlockKind
+	blockedUntil time.Time
+}
+
+// memoryLoginAttemptRepository счетчик попыток входа в памяти с теми же правилами, что и скрипт Redis.
+// С нулевыми правилами никогда не блокирует
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*memoryLoginAttempts
+}
+
+func newMemoryLoginAttemptRepository() *memoryLoginAttemptRepository {
+	return &memoryLoginAttemptRepository{attempts: make(map[string]*memoryLoginAttempts)}
+}
+
+func (r *memoryLoginAttemptRepository) ReserveLoginAttempt(
+	_ context.Context,
+	scope repository.LoginScope,
+	id string,
+	policy repository.LoginThrottlePolicy,
+) (repository.LoginBlock, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	attempts := r.get(scope, id, now)
+	if attempts.block != repository.LoginBlockNone && now.Before(attempts.blockedUntil) {
+		return repository.LoginBlock{Kind: attempts.block, RetryAfter: attempts.blockedUntil.Sub(now)}, false, nil
+	}
+
+	attempts.failures++
+	if attempts.failures == 1 {
+		attempts.windowEnds = now.Add(policy.Window)
+	}
+
+	block := repository.LoginBlock{}
+	switch {
+	case policy.LockoutAfter > 0 && attempts.failures >= policy.LockoutAfter:
+		block = repository.LoginBlock{Kind: repository.LoginBlockLockout, RetryAfter: policy.LockoutDuration}
+	case policy.BackoffAfter > 0 && attempts.failures >= policy.BackoffAfter:
+		delay := min(policy.BackoffBase<<(attempts.failures-policy.BackoffAfter), policy.BackoffMax)
+		block = repository.LoginBlock{Kind: repository.LoginBlockBackoff, RetryAfter: delay}
+	}
+	if block.RetryAfter > 0 {
+		attempts.block = block.Kind
+		attempts.blockedUntil = now.Add(block.RetryAfter)
+	}
+
+	return block, true, nil
+}
+
+func (r *memoryLoginAttemptRepository) ReleaseLoginAttempt(_ context.Context, scope repository.LoginScope, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts := r.get(scope, id, time.Now())
+	if attempts.failures > 0 {
+		attempts.failures--
+	}
+	if scope == repository.LoginScopeAccount {
+		attempts.block = repository.LoginBlockNone
+	}
+
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) ResetLoginFailures(_ context.Context, scope repository.LoginScope, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := string(scope) + ":" + id
+	_, ok := r.attempts[key]
+	delete(r.attempts, key)
+
+	return ok, nil
+}
+
+// get возвращает попытки объекта, сбрасывая счетчик после окна
+func (r *memoryLoginAttemptRepository) get(scope repository.LoginScope, id string, now time.Time) *memoryLoginAttempts {
+	key := string(scope) + ":" + id
+	attempts, ok := r.attempts[key]
+	if !ok {
+		attempts = &memoryLoginAttempts{}
+		r.attempts[key] = attempts
+	}
+	if attempts.failures > 0 && !now.Before(attempts.windowEnds) {
+		attempts.failures = 0
+	}
+	return attempts
+}
+
+// IssueAccessToken выпускает токен, по которому видно, кому он выдан
+func (m *stubTokenManager) IssueAccessToken(userUUID, tenantID uuid.UUID, now time.Time) (string, time.Time, error) {
+	return "access:" + userUUID.String() + ":" + tenantID.String(), now.Add(time.Minute), nil
+}
+
+// memoryRefreshToken refresh токен в памяти и признак его использования
+type memoryRefreshToken struct {
+	token models.RefreshToken
+	used  bool
+}
+
+// memoryRefreshTokenRepository refresh токены в памяти с той же ротацией, что и в Redis
+type memoryRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[string]*memoryRefreshToken
+	// families действующие семейства
+	families map[uuid.UUID]models.RefreshToken
+}
+
+func newMemoryRefreshTokenRepository() *memoryRefreshTokenRepository {
+	return &memoryRefreshTokenRepository{
+		tokens:   make(map[string]*memoryRefreshToken),
+		families: make(map[uuid.UUID]models.RefreshToken),
+	}
+}
+
+func (r *memoryRefreshTokenRepository) CreateRefreshToken(_ context.Context, token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.TokenHash] = &memoryRefreshToken{token: *token}
+	r.families[token.FamilyID] = *token
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RotateRefreshToken(
+	_ context.Context,
+	oldTokenHash string,
+	clientID uuid.UUID,
+	newToken *models.RefreshToken,
+	now time.Time,
+	maxFamilyLifetime time.Duration,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.tokens[oldTokenHash]
+	if !ok || old.token.ClientID != clientID || !now.Before(old.token.ExpiresAt) {
+		return apperrors.ErrInvalidRefreshToken
+	}
+	if _, ok := r.families[old.token.FamilyID]; !ok {
+		return apperrors.ErrInvalidRefreshToken
+	}
+	if old.used {
+		delete(r.families, old.token.FamilyID)
+		return apperrors.ErrRefreshTokenReused
+	}
+
+	familyExpiresAt := old.token.FamilyCreatedAt.Add(maxFamilyLifetime)
+	if !familyExpiresAt.After(now) {
+		return apperrors.ErrInvalidRefreshToken
+	}
+
+	newToken.UserUUID = old.token.UserUUID
+	newToken.TenantID = old.token.TenantID
+	newToken.FamilyID = old.token.FamilyID
+	newToken.FamilyCreatedAt = old.token.FamilyCreatedAt
+	newToken.ClientID = old.token.ClientID
+	newToken.Scopes = old.token.Scopes
+	if newToken.ExpiresAt.After(familyExpiresAt) {
+		newToken.ExpiresAt = familyExpiresAt
+	}
+
+	old.used = true
+	r.tokens[newToken.TokenHash] = &memoryRefreshToken{token: *newToken}
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeRefreshToken(_ context.Context, tokenHash string, clientID uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[tokenHash]
+	if !ok || stored.token.ClientID != clientID {
+		return false, nil
+	}
+	if _, ok := r.families[stored.token.FamilyID]; !ok {
+		return false, nil
+	}
+	delete(r.families, stored.token.FamilyID)
+	return true, nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeUserRefreshTokens(_ context.Context, userUUID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revoked := 0
+	for familyID, family := range r.families {
+		if family.UserUUID == userUUID {
+			delete(r.families, familyID)
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeUserClientRefreshTokens(_ context.Context, userUUID, clientID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revoked := 0
+	for familyID, family := range r.families {
+		if family.UserUUID == userUUID && family.ClientID == clientID {
+			delete(r.families, familyID)
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+// memoryMFARepository подтвержденные TOTP и коды восстановления в памяти
+type memoryMFARepository struct {
+	repository.MFARepository
+
+	mu    sync.Mutex
+	totps map[uuid.UUID]*models.TOTP
+	// recoveryCodes хеши неиспользованных кодов восстановления по UUID пользователя
+	recoveryCodes map[uuid.UUID]map[string]struct{}
+}
+
+func newMemoryMFARepository() *memoryMFARepository {
+	return &memoryMFARepository{
+		totps:         make(map[uuid.UUID]*models.TOTP),
+		recoveryCodes: make(map[uuid.UUID]map[string]struct{}),
+	}
+}
+
+func (r *memoryMFARepository) GetTOTP(_ context.Context, userUUID uuid.UUID) (*models.TOTP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.totps[userUUID]
+	if !ok {
+		return nil, apperrors.ErrTOTPNotEnabled
+	}
+	totp := *stored
+	return &totp, nil
+}
+
+func (r *memoryMFARepository) UseTOTPStep(_ context.Context, userUUID uuid.UUID, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.totps[userUUID]
+	if !ok || step <= stored.LastUsedStep {
+		return false, nil
+	}
+	stored.LastUsedStep = step
+	return true, nil
+}
+
+func (r *memoryMFARepository) UseRecoveryCode(_ context.Context, userUUID uuid.UUID, codeHash string, _ time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.recoveryCodes[userUUID][codeHash]; !ok {
+		return false, nil
+	}
+	delete(r.recoveryCodes[userUUID], codeHash)
+	return true, nil
+}
+
+// memoryMFAChallenge challenge в памяти и количество неверных кодов
+type memoryMFAChallenge struct {
+	challenge models.MFAChallenge
+	failures  int
+}
+
+// memoryMFAChallengeRepository challenge второго фактора в памяти
+type memoryMFAChallengeRepository struct {
+	mu         sync.Mutex
+	challenges map[string]*memoryMFAChallenge
+}
+
+func newMemoryMFAChallengeRepository() *memoryMFAChallengeRepository {
+	return &memoryMFAChallengeRepository{challenges: make(map[string]*memoryMFAChallenge)}
+}
+
+func (r *memoryMFAChallengeRepository) CreateMFAChallenge(_ context.Context, challenge *models.MFAChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	challenge.ID = uuid.NewString()
+	r.challenges[challenge.ID] = &memoryMFAChallenge{challenge: *challenge}
+	return nil
+}
+
+func (r *memoryMFAChallengeRepository) GetMFAChallenge(_ context.Context, challengeID string) (*models.MFAChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.challenges[challengeID]
+	if !ok || !time.Now().Before(stored.challenge.ExpiresAt) {
+		return nil, apperrors.ErrMFAChallengeNotFound
+	}
+	challenge := stored.challenge
+	return &challenge, nil
+}
+
+func (r *memoryMFAChallengeRepository) RecordMFAFailure(_ context.Context, challengeID string, maxAttempts int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.challenges[challengeID]
+	if !ok {
+		return nil
+	}
+	stored.failures++
+	if stored.failures >= maxAttempts {
+		delete(r.challenges, challengeID)
+	}
+	return nil
+}
+
+func (r *memoryMFAChallengeRepository) ConsumeMFAChallenge(_ context.Context, challengeID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.challenges[challengeID]
+	delete(r.challenges, challengeID)
+	return ok, nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/olezhek28/auth-service/pkg/config"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/repository"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// UnlockUserRequest запрос на снятие блокировки входа в аккаунт
+type UnlockUserRequest struct {
+	// Tenant slug организации пользователя
+	Tenant string
+	Email  string
+}
+
+// UnlockUserResponse ответ на снятие блокировки входа в аккаунт
+type UnlockUserResponse struct {
+	// Unlocked у аккаунта были неудачные попытки или блокировка
+	Unlocked bool
+}
+
+// UnlockUser сбрасывает неудачные попытки входа в аккаунт и снимает блокировку.
+// Ограничение по IP не снимается, оно общее для всех аккаунтов
+func (s *authService) UnlockUser(ctx context.Context, req UnlockUserRequest) (*UnlockUserResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.resolveTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked, err := s.loginAttemptRepo.ResetLoginFailures(ctx,
+		repository.LoginScopeAccount, accountThrottleKey(organization.ID, req.Email))
+	if err != nil {
+		s.logger.Error("failed to reset login failures", "error", err)
+		return nil, fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	s.logger.Info("user unlocked by admin", "tenant", organization.Slug, "email", req.Email, "unlocked", unlocked)
+
+	return &UnlockUserResponse{
+		Unlocked: unlocked,
+	}, nil
+}
+
+// reserveLoginAttempt резервирует попытку входа в аккаунт и с IP до проверки пароля или кода.
+// Зарезервированная попытка сразу считается неудачной, поэтому параллельные запросы
+// не проходят проверку блокировки все разом. Если попытка запрещена, возвращает ошибку
+// с временем до повтора. Счетчики ведутся по email, а не по пользователю,
+// поэтому несуществующие email блокируются так же, как существующие
+func (s *authService) reserveLoginAttempt(ctx context.Context, tenantID uuid.UUID, email, ip string) error {
+	accountKey := accountThrottleKey(tenantID, email)
+	block, reserved, err := s.loginAttemptRepo.ReserveLoginAttempt(ctx,
+		repository.LoginScopeAccount, accountKey, throttlePolicy(s.cfg.AccountLoginThrottle))
+	if err != nil {
+		s.logger.Error("failed to reserve account login attempt", "error", err)
+		return fmt.Errorf("failed to reserve account login attempt: %w", err)
+	}
+	if !reserved {
+		return loginBlockError(repository.LoginScopeAccount, block)
+	}
+	if block.Kind == repository.LoginBlockLockout {
+		s.logger.Warn("account login locked", "tenant_id", tenantID, "email", email, "retry_after", block.RetryAfter)
+	}
+
+	if ip == "" {
+		return nil
+	}
+
+	block, reserved, err = s.loginAttemptRepo.ReserveLoginAttempt(ctx,
+		repository.LoginScopeIP, ip, throttlePolicy(s.cfg.IPLoginThrottle))
+	if err != nil || !reserved {
+		// Попытка не состоялась, поэтому не должна учитываться для аккаунта
+		if releaseErr := s.loginAttemptRepo.ReleaseLoginAttempt(ctx, repository.LoginScopeAccount, accountKey); releaseErr != nil {
+			s.logger.Error("failed to release account login attempt", "error", releaseErr)
+		}
+	}
+	if err != nil {
+		s.logger.Error("failed to reserve IP login attempt", "error", err)
+		return fmt.Errorf("failed to reserve IP login attempt: %w", err)
+	}
+	if !reserved {
+		return loginBlockError(repository.LoginScopeIP, block)
+	}
+	if block.Kind == repository.LoginBlockLockout {
+		s.logger.Warn("IP login locked", "ip", ip, "retry_after", block.RetryAfter)
+	}
+
+	return nil
+}
+
+// releaseLoginAttempt возвращает попытку, зарезервированную reserveLoginAttempt, если пароль
+// или код оказались верными, или проверка не состоялась.
+// Ошибки только логируются, чтобы не ломать уже прошедший вход
+func (s *authService) releaseLoginAttempt(ctx context.Context, tenantID uuid.UUID, email, ip string) {
+	err := s.loginAttemptRepo.ReleaseLoginAttempt(ctx, repository.LoginScopeAccount, accountThrottleKey(tenantID, email))
+	if err != nil {
+		s.logger.Error("failed to release account login attempt", "error", err)
+	}
+
+	if ip == "" {
+		return
+	}
+
+	if err := s.loginAttemptRepo.ReleaseLoginAttempt(ctx, repository.LoginScopeIP, ip); err != nil {
+		s.logger.Error("failed to release IP login attempt", "error", err)
+	}
+}
+
+// resetAccountLoginFailures сбрасывает неудачные попытки входа в аккаунт после успешного входа.
+// Счетчик IP не сбрасывается, иначе собственный аккаунт позволял бы перебирать чужие
+func (s *authService) resetAccountLoginFailures(ctx context.Context, tenantID uuid.UUID, email string) {
+	_, err := s.loginAttemptRepo.ResetLoginFailures(ctx, repository.LoginScopeAccount, accountThrottleKey(tenantID, email))
+	if err != nil {
+		s.logger.Error("failed to reset account login failures", "error", err)
+	}
+}
+
+// accountThrottleKey идентификатор аккаунта в счетчиках попыток входа.
+// Один и тот же email в разных организациях - разные аккаунты
+func accountThrottleKey(tenantID uuid.UUID, email string) string {
+	return tenantID.String() + ":" + email
+}
+
+// loginBlockError конвертирует блокировку в ошибку сервиса
+func loginBlockError(scope repository.LoginScope, block repository.LoginBlock) error {
+	if scope == repository.LoginScopeAccount && block.Kind == repository.LoginBlockLockout {
+		return apperrors.WithRetryAfter(apperrors.ErrAccountLocked, block.RetryAfter)
+	}
+	return apperrors.WithRetryAfter(apperrors.ErrTooManyLoginAttempts, block.RetryAfter)
+}
+
+// throttlePolicy конвертирует конфигурацию в правила блокировки репозитория
+func throttlePolicy(cfg config.LoginThrottleConfig) repository.LoginThrottlePolicy {
+	return repository.LoginThrottlePolicy{
+		Window:          cfg.Window,
+		BackoffAfter:    cfg.BackoffAfter,
+		BackoffBase:     cfg.BackoffBase,
+		BackoffMax:      cfg.BackoffMax,
+		LockoutAfter:    cfg.LockoutAfter,
+		LockoutDuration: cfg.LockoutDuration,
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/olezhek28/auth-service/pkg/config"
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/password"
+)
+
+const throttleLockoutAfter = 3
+
+// newThrottleAuthService создает сервис с одним пользователем и блокировкой аккаунта
+// после throttleLockoutAfter неудач
+func newThrottleAuthService(t *testing.T) (*authService, *models.User, *models.Organization) {
+	t.Helper()
+
+	organization := newTestOrganization("acme")
+	hasher := newTestPasswordHasher(t)
+
+	passwordHash, err := hasher.Hash("correct-password")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	user := &models.User{
+		UUID:         uuid.New(),
+		TenantID:     organization.ID,
+		Email:        "user@acme.example.com",
+		PasswordHash: passwordHash,
+	}
+
+	s := newTestAuthService()
+	s.userRepo = &stubUserRepository{users: []*models.User{user}}
+	s.organizationRepo = &stubOrganizationRepository{organizations: []*models.Organization{organization}}
+	s.passwordHasher = hasher
+	s.dummyPasswordHash = newDummyPasswordHash(hasher)
+	s.cfg.AccountLoginThrottle = config.LoginThrottleConfig{
+		Window:          time.Hour,
+		LockoutAfter:    throttleLockoutAfter,
+		LockoutDuration: time.Hour,
+	}
+
+	return s, user, organization
+}
+
+// TestLoginThrottleConcurrentFailures проверяет, что параллельные попытки с неверным паролем
+// не проходят проверку блокировки все разом: пароль проверяется не больше порога раз
+func TestLoginThrottleConcurrentFailures(t *testing.T) {
+	s, user, organization := newThrottleAuthService(t)
+
+	const attempts = 20
+
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Login(context.Background(), LoginRequest{
+				Tenant:   organization.Slug,
+				Email:    user.Email,
+				Password: "wrong-password",
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var invalid, locked int
+	for err := range errs {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidCredentials):
+			invalid++
+		case errors.Is(err, apperrors.ErrAccountLocked):
+			locked++
+		default:
+			t.Errorf("Login() error = %v, want %v or %v", err, apperrors.ErrInvalidCredentials, apperrors.ErrAccountLocked)
+		}
+	}
+
+	if invalid != throttleLockoutAfter {
+		t.Errorf("password checked %d times, want %d", invalid, throttleLockoutAfter)
+	}
+	if locked != attempts-throttleLockoutAfter {
+		t.Errorf("locked %d attempts, want %d", locked, attempts-throttleLockoutAfter)
+	}
+}
+
+// TestLoginThrottleReleasesCorrectPassword проверяет, что попытка с верным паролем
+// не учитывается как неудачная, даже если вход не завершился
+func TestLoginThrottleReleasesCorrectPassword(t *testing.T) {
+	s, user, organization := newThrottleAuthService(t)
+	// Вход с верным паролем останавливается на неподтвержденном email, не создавая сессию
+	s.cfg.RequireVerifiedEmail = true
+
+	login := func(password string) error {
+		_, err := s.Login(context.Background(), LoginRequest{
+			Tenant:   organization.Slug,
+			Email:    user.Email,
+			Password: password,
+		})
+		return err
+	}
+
+	for range throttleLockoutAfter - 1 {
+		if err := login("wrong-password"); !errors.Is(err, apperrors.ErrInvalidCredentials) {
+			t.Fatalf("Login() error = %v, want %v", err, apperrors.ErrInvalidCredentials)
+		}
+	}
+	for range throttleLockoutAfter {
+		if err := login("correct-password"); !errors.Is(err, apperrors.ErrEmailNotVerified) {
+			t.Fatalf("Login() error = %v, want %v", err, apperrors.ErrEmailNotVerified)
+		}
+	}
+
+	if err := login("wrong-password"); !errors.Is(err, apperrors.ErrInvalidCredentials) {
+		t.Errorf("Login() error = %v, want %v", err, apperrors.ErrInvalidCredentials)
+	}
+	if err := login("wrong-password"); !errors.Is(err, apperrors.ErrAccountLocked) {
+		t.Errorf("Login() error = %v, want %v", err, apperrors.ErrAccountLocked)
+	}
+}
+
+// TestChangePasswordThrottle проверяет, что неверный старый пароль учитывается в тех же попытках
+// аккаунта, что и вход, и украденная сессия не позволяет перебирать пароль
+func TestChangePasswordThrottle(t *testing.T) {
+	s, user, organization := newThrottleAuthService(t)
+	s.passwordPolicy = password.NewPolicy(password.PolicyConfig{}, nil)
+	s.refreshTokenRepo = newMemoryRefreshTokenRepository()
+
+	session := &models.Session{UUID: uuid.NewString(), UserUUID: user.UUID, TenantID: organization.ID}
+	s.sessionRepo = &stubSessionRepository{sessions: []*models.Session{session}}
+
+	changePassword := func(oldPassword string) error {
+		_, err := s.ChangePassword(context.Background(), ChangePasswordRequest{
+			SessionUUID: session.UUID,
+			OldPassword: oldPassword,
+			NewPassword: "new-password",
+		})
+		return err
+	}
+
+	for range throttleLockoutAfter {
+		if err := changePassword("wrong-password"); !errors.Is(err, apperrors.ErrInvalidCredentials) {
+			t.Fatalf("ChangePassword() error = %v, want %v", err, apperrors.ErrInvalidCredentials)
+		}
+	}
+
+	// Верный пароль уже не проверяется, как и при входе
+	if err := changePassword("correct-password"); !errors.Is(err, apperrors.ErrAccountLocked) {
+		t.Errorf("ChangePassword() error = %v, want %v", err, apperrors.ErrAccountLocked)
+	}
+	_, err := s.Login(context.Background(), LoginRequest{
+		Tenant:   organization.Slug,
+		Email:    user.Email,
+		Password: "correct-password",
+	})
+	if !errors.Is(err, apperrors.ErrAccountLocked) {
+		t.Errorf("Login() error = %v, want %v", err, apperrors.ErrAccountLocked)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/password"
+)
+
+const (
+	timingTenant        = "acme"
+	timingExistingEmail = "existing@example.com"
+	timingMissingEmail  = "missing@example.com"
+	timingWrongPassword = "wrong-password"
+
+	// timingSamples количество замеров входа на каждый вариант
+	timingSamples = 15
+	// timingTolerance допустимое относительное расхождение медиан времени входа
+	timingTolerance = 0.2
+)
+
+// timingHasherConfig параметры хеширования по умолчанию из конфигурации сервиса
+var timingHasherConfig = password.Config{
+	Algorithm:  password.AlgorithmArgon2id,
+	BcryptCost: 10,
+	Argon2: password.Argon2Params{
+		Memory:      19 * 1024,
+		Iterations:  2,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	},
+}
+
+// newTimingAuthService создает сервис, которому для неудачного входа не нужны внешние зависимости
+func newTimingAuthService(tb testing.TB) *authService {
+	tb.Helper()
+
+	hasher, err := password.NewHasher(timingHasherConfig)
+	if err != nil {
+		tb.Fatalf("failed to create password hasher: %v", err)
+	}
+
+	passwordHash, err := hasher.Hash("correct-password")
+	if err != nil {
+		tb.Fatalf("failed to hash password: %v", err)
+	}
+
+	organization := newTestOrganization(timingTenant)
+
+	s := newTestAuthService()
+	s.userRepo = &stubUserRepository{users: []*models.User{{
+		UUID:         uuid.New(),
+		TenantID:     organization.ID,
+		Email:        timingExistingEmail,
+		PasswordHash: passwordHash,
+	}}}
+	s.organizationRepo = &stubOrganizationRepository{organizations: []*models.Organization{organization}}
+	s.passwordHasher = hasher
+	s.dummyPasswordHash = newDummyPasswordHash(hasher)
+
+	return s
+}
+
+// measureFailedLogin замеряет один неудачный вход
+func measureFailedLogin(tb testing.TB, s *authService, email string) time.Duration {
+	tb.Helper()
+
+	start := time.Now()
+	_, err := s.Login(context.Background(), LoginRequest{
+		Tenant:   timingTenant,
+		Email:    email,
+		Password: timingWrongPassword,
+	})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, apperrors.ErrInvalidCredentials) {
+		tb.Fatalf("Login(%s) error = %v, want %v", email, err, apperrors.ErrInvalidCredentials)
+	}
+
+	return elapsed
+}
+
+func median(samples []time.Duration) time.Duration {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	return sorted[len(sorted)/2]
+}
+
+// TestLoginTimingUnknownEmail проверяет, что по времени ответа Login нельзя отличить
+// существующий email с неверным паролем от незарегистрированного
+func TestLoginTimingUnknownEmail(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test hashes passwords with production cost")
+	}
+
+	s := newTimingAuthService(t)
+
+	// Прогреваем фиктивный хеш, чтобы его генерация не попала в замеры
+	measureFailedLogin(t, s, timingMissingEmail)
+
+	// Замеры чередуются, чтобы фоновая нагрузка одинаково влияла на оба варианта
+	existing := make([]time.Duration, 0, timingSamples)
+	missing := make([]time.Duration, 0, timingSamples)
+	for range timingSamples {
+		existing = append(existing, measureFailedLogin(t, s, timingExistingEmail))
+		missing = append(missing, measureFailedLogin(t, s, timingMissingEmail))
+	}
+
+	existingMedian := median(existing)
+	missingMedian := median(missing)
+
+	diff := existingMedian - missingMedian
+	if diff < 0 {
+		diff = -diff
+	}
+	limit := time.Duration(float64(max(existingMedian, missingMedian)) * timingTolerance)
+
+	t.Logf("median login time: existing %s, missing %s", existingMedian, missingMedian)
+
+	if diff > limit {
+		t.Errorf("login timing differs by %s (existing %s, missing %s), want at most %s",
+			diff, existingMedian, missingMedian, limit)
+	}
+}
+
+// TestLoginUnknownTenant проверяет, что вход в несуществующую организацию не отличается
+// от входа с неверным паролем и не выдает, какие организации существуют
+func TestLoginUnknownTenant(t *testing.T) {
+	s := newTimingAuthService(t)
+
+	_, err := s.Login(context.Background(), LoginRequest{
+		Tenant:   "unknown",
+		Email:    timingExistingEmail,
+		Password: "correct-password",
+	})
+	if !errors.Is(err, apperrors.ErrInvalidCredentials) {
+		t.Errorf("Login() error = %v, want %v", err, apperrors.ErrInvalidCredentials)
+	}
+}
+
+func BenchmarkLoginExistingEmail(b *testing.B) {
+	s := newTimingAuthService(b)
+
+	b.ResetTimer()
+	for range b.N {
+		measureFailedLogin(b, s, timingExistingEmail)
+	}
+}
+
+func BenchmarkLoginMissingEmail(b *testing.B) {
+	s := newTimingAuthService(b)
+	measureFailedLogin(b, s, timingMissingEmail)
+
+	b.ResetTimer()
+	for range b.N {
+		measureFailedLogin(b, s, timingMissingEmail)
+	}
+}
@@ -0,0 +1,553 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/notifier"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+const (
+	// membersReadPermission разрешение на просмотр участников активной организации
+	membersReadPermission = "members:read"
+	// membersManagePermission разрешение приглашать и исключать участников активной организации
+	membersManagePermission = "members:manage"
+)
+
+// CreateInvitationRequest запрос на приглашение в активную организацию сессии
+type CreateInvitationRequest struct {
+	SessionUUID string
+	Email       string
+	// Role роль организации, которую получит принявший приглашение. Пустая - участник без роли
+	Role string
+}
+
+// CreateInvitationResponse ответ на приглашение. Сам токен приглашения получает только адресат
+type CreateInvitationResponse struct {
+	InvitationID uuid.UUID
+	Email        string
+	Role         string
+	ExpiresAt    time.Time
+}
+
+// AcceptInvitationRequest запрос на принятие приглашения.
+// Приглашение принимает существующий пользователь по сессии или, если сессии нет,
+// новый пользователь, который регистрируется в организации приглашения
+type AcceptInvitationRequest struct {
+	InvitationToken string
+	SessionUUID     string
+	Username        string
+	Password        string
+}
+
+// AcceptInvitationResponse ответ на принятие приглашения
+type AcceptInvitationResponse struct {
+	Organization models.Organization
+	UserUUID     uuid.UUID
+	Role         string
+	// Registered приглашение принято новым пользователем
+	Registered bool
+}
+
+// ListMembersRequest запрос участников активной организации сессии
+type ListMembersRequest struct {
+	SessionUUID string
+}
+
+// ListMembersResponse участники организации в порядке вступления
+type ListMembersResponse struct {
+	Members []*models.Member
+}
+
+// RemoveMemberRequest запрос на исключение участника из активной организации сессии
+type RemoveMemberRequest struct {
+	SessionUUID string
+	UserUUID    string
+}
+
+// RemoveMemberResponse ответ на исключение участника
+type RemoveMemberResponse struct {
+	// Removed пользователь был участником
+	Removed bool
+}
+
+// SwitchOrganizationRequest запрос на смену активной организации сессии
+type SwitchOrganizationRequest struct {
+	SessionUUID string
+	// Organization slug организации, в которой пользователь состоит
+	Organization string
+}
+
+// SwitchOrganizationResponse новая активная организация и роль пользователя в ней
+type SwitchOrganizationResponse struct {
+	Organization models.Organization
+	Role         string
+}
+
+// CreateInvitation приглашает пользователя по email в активную организацию сессии.
+// Токен приглашения отправляется на email, приглашающему нужно разрешение members:manage
+// и все разрешения роли, с которой он приглашает
+func (s *authService) CreateInvitation(ctx context.Context, req CreateInvitationRequest) (*CreateInvitationResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return nil, err
+	}
+	if req.Role != "" {
+		if err := validator.ValidateRoleName(req.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := s.requireMemberPermission(ctx, req.SessionUUID, membersManagePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Role != "" {
+		if err := s.requireRoleDelegation(ctx, session, req.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	organization, err := s.getOrganization(ctx, session.ActiveOrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation := &models.Invitation{
+		ID:             uuid.New(),
+		OrganizationID: organization.ID,
+		Email:          req.Email,
+		Role:           req.Role,
+		InvitedBy:      session.UserUUID,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(s.cfg.InvitationTTL),
+	}
+	if err := s.membershipRepo.CreateInvitation(ctx, invitation); err != nil {
+		if errors.Is(err, apperrors.ErrRoleNotFound) {
+			return nil, apperrors.ErrRoleNotFound
+		}
+		s.logger.Error("failed to create invitation", "error", err, "tenant", organization.Slug)
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	invitationToken, err := s.tokenManager.IssueInvitationToken(invitation.ID, now, invitation.ExpiresAt)
+	if err != nil {
+		s.logger.Error("failed to issue invitation token", "error", err, "invitation_id", invitation.ID)
+		return nil, fmt.Errorf("failed to issue invitation token: %w", err)
+	}
+
+	// Приглашение доставляется синхронно: отправитель должен узнать, что оно не ушло
+	err = s.notifier.Notify(ctx, notifier.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("Invitation to %s", organization.Name),
+		Body: fmt.Sprintf(
+			"You are invited to join %s. Use this token to accept the invitation: %s\nIt expires at %s.",
+			organization.Name,
+			invitationToken,
+			invitation.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		s.logger.Error("failed to deliver invitation", "error", err, "invitation_id", invitation.ID)
+
+		// Недоставленное приглашение удаляем, чтобы отправитель мог повторить его с новым токеном
+		if err := s.membershipRepo.DeleteInvitation(ctx, invitation.ID); err != nil {
+			s.logger.Error("failed to delete undelivered invitation", "error", err, "invitation_id", invitation.ID)
+		}
+		return nil, fmt.Errorf("failed to deliver invitation: %w", err)
+	}
+
+	s.logger.Info("invitation sent",
+		"tenant", organization.Slug,
+		"invitation_id", invitation.ID,
+		"invited_by", session.UserUUID,
+		"role", invitation.Role,
+	)
+
+	return &CreateInvitationResponse{
+		InvitationID: invitation.ID,
+		Email:        invitation.Email,
+		Role:         invitation.Role,
+		ExpiresAt:    invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation принимает приглашение в организацию от имени пользователя сессии
+// или регистрирует нового пользователя в организации приглашения
+func (s *authService) AcceptInvitation(ctx context.Context, req AcceptInvitationRequest) (*AcceptInvitationResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateInvitationToken(req.InvitationToken); err != nil {
+		return nil, err
+	}
+	if req.SessionUUID != "" && (req.Username != "" || req.Password != "") {
+		return nil, fmt.Errorf("%w: either session_uuid or username and password must be set", apperrors.ErrInvalidInput)
+	}
+
+	invitationID, err := s.tokenManager.ParseInvitationToken(req.InvitationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation, err := s.membershipRepo.GetInvitation(ctx, invitationID, now)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidInvitation) {
+			return nil, apperrors.ErrInvalidInvitation
+		}
+		s.logger.Error("failed to get invitation", "error", err, "invitation_id", invitationID)
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	var userUUID uuid.UUID
+	registered := req.SessionUUID == ""
+	if registered {
+		userUUID, err = s.registerInvitedUser(ctx, invitation, req.Username, req.Password, now)
+	} else {
+		userUUID, err = s.invitedSessionUser(ctx, invitation, req.SessionUUID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.membershipRepo.AcceptInvitation(ctx, invitation.ID, userUUID, now); err != nil {
+		if errors.Is(err, apperrors.ErrInvalidInvitation) {
+			return nil, apperrors.ErrInvalidInvitation
+		}
+		s.logger.Error("failed to accept invitation", "error", err, "invitation_id", invitation.ID)
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	// Роль участника входит в права пользователя в организации
+	if err := s.invalidateUserAccess(ctx, userUUID); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.getOrganization(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("invitation accepted",
+		"tenant", organization.Slug,
+		"invitation_id", invitation.ID,
+		"user_uuid", userUUID,
+		"registered", registered,
+	)
+
+	return &AcceptInvitationResponse{
+		Organization: *organization,
+		UserUUID:     userUUID,
+		Role:         invitation.Role,
+		Registered:   registered,
+	}, nil
+}
+
+// invitedSessionUser возвращает пользователя сессии, если приглашение адресовано его email
+func (s *authService) invitedSessionUser(
+	ctx context.Context,
+	invitation *models.Invitation,
+	sessionUUID string,
+) (uuid.UUID, error) {
+	if err := validator.ValidateSessionUUID(sessionUUID); err != nil {
+		return uuid.Nil, err
+	}
+
+	session, err := s.getSession(ctx, sessionUUID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	user, err := s.getUser(ctx, session.TenantID, session.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return uuid.Nil, apperrors.ErrSessionNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	// Токен мог попасть к другому человеку, поэтому принять приглашение может только владелец email
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return uuid.Nil, apperrors.ErrInvitationEmailMismatch
+	}
+
+	return user.UUID, nil
+}
+
+// registerInvitedUser регистрирует пользователя в организации приглашения.
+// Email считается подтвержденным: токен приглашения пришел на него
+func (s *authService) registerInvitedUser(
+	ctx context.Context,
+	invitation *models.Invitation,
+	username string,
+	plainPassword string,
+	now time.Time,
+) (uuid.UUID, error) {
+	if err := validator.ValidateUsername(username); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.checkPasswordPolicy(passwordField, plainPassword, invitation.Email, username); err != nil {
+		return uuid.Nil, err
+	}
+
+	user := &models.User{
+		UUID:            uuid.New(),
+		TenantID:        invitation.OrganizationID,
+		Email:           invitation.Email,
+		Username:        username,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.createUser(ctx, user, plainPassword); err != nil {
+		return uuid.Nil, err
+	}
+
+	s.logger.Info("user registered by invitation", "user_uuid", user.UUID, "invitation_id", invitation.ID)
+
+	return user.UUID, nil
+}
+
+// ListMembers возвращает участников активной организации сессии.
+// Нужно разрешение members:read
+func (s *authService) ListMembers(ctx context.Context, req ListMembersRequest) (*ListMembersResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.requireMemberPermission(ctx, req.SessionUUID, membersReadPermission)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.membershipRepo.ListMembers(ctx, session.ActiveOrganizationID)
+	if err != nil {
+		s.logger.Error("failed to list members", "error", err, "tenant_id", session.ActiveOrganizationID)
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	return &ListMembersResponse{
+		Members: members,
+	}, nil
+}
+
+// RemoveMember исключает участника из активной организации сессии вместе с его ролями в ней
+// и его сервисными аккаунтами, чтобы их API ключи перестали действовать.
+// Нужно разрешение members:manage. Из организации, в которой пользователь зарегистрирован, исключить нельзя
+func (s *authService) RemoveMember(ctx context.Context, req RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateUserUUID(req.UserUUID); err != nil {
+		return nil, err
+	}
+	userUUID := uuid.MustParse(req.UserUUID)
+
+	session, err := s.requireMemberPermission(ctx, req.SessionUUID, membersManagePermission)
+	if err != nil {
+		return nil, err
+	}
+	organizationID := session.ActiveOrganizationID
+
+	member, err := s.getMember(ctx, organizationID, userUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrMemberNotFound) {
+			return &RemoveMemberResponse{}, nil
+		}
+		return nil, err
+	}
+	if member.TenantID == organizationID {
+		return nil, apperrors.ErrCannotRemoveHomeMember
+	}
+
+	removed, err := s.membershipRepo.RemoveMember(ctx, organizationID, userUUID)
+	if err != nil {
+		s.logger.Error("failed to remove member", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	if err := s.invalidateUserAccess(ctx, userUUID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("member removed",
+		"tenant_id", organizationID,
+		"user_uuid", userUUID,
+		"removed_by", session.UserUUID,
+		"removed", removed,
+	)
+
+	return &RemoveMemberResponse{
+		Removed: removed,
+	}, nil
+}
+
+// SwitchOrganization делает активной другую организацию, в которой состоит пользователь сессии
+func (s *authService) SwitchOrganization(
+	ctx context.Context,
+	req SwitchOrganizationRequest,
+) (*SwitchOrganizationResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.resolveTenant(ctx, req.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.getMember(ctx, organization.ID, session.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrMemberNotFound) {
+			return nil, apperrors.ErrNotOrganizationMember
+		}
+		return nil, err
+	}
+
+	if err := s.sessionRepo.SetActiveOrganization(ctx, session.UUID, organization.ID); err != nil {
+		if errors.Is(err, apperrors.ErrSessionNotFound) {
+			return nil, apperrors.ErrSessionNotFound
+		}
+		s.logger.Error("failed to set active organization", "error", err, "session_uuid", session.UUID)
+		return nil, fmt.Errorf("failed to set active organization: %w", err)
+	}
+
+	s.logger.Info("active organization switched",
+		"user_uuid", session.UserUUID,
+		"session_uuid", session.UUID,
+		"tenant", organization.Slug,
+	)
+
+	return &SwitchOrganizationResponse{
+		Organization: *organization,
+		Role:         member.Role,
+	}, nil
+}
+
+// activeMembership возвращает активную организацию сессии и участие в ней пользователя.
+// Если пользователя исключили из активной организации, сессия возвращается в его собственную
+func (s *authService) activeMembership(
+	ctx context.Context,
+	session *models.Session,
+) (*models.Organization, *models.Member, error) {
+	member, err := s.getMember(ctx, session.ActiveOrganizationID, session.UserUUID)
+	if errors.Is(err, apperrors.ErrMemberNotFound) && session.ActiveOrganizationID != session.TenantID {
+		if err := s.sessionRepo.SetActiveOrganization(ctx, session.UUID, session.TenantID); err != nil {
+			if errors.Is(err, apperrors.ErrSessionNotFound) {
+				return nil, nil, apperrors.ErrSessionNotFound
+			}
+			s.logger.Error("failed to set active organization", "error", err, "session_uuid", session.UUID)
+			return nil, nil, fmt.Errorf("failed to set active organization: %w", err)
+		}
+		session.ActiveOrganizationID = session.TenantID
+
+		member, err = s.getMember(ctx, session.ActiveOrganizationID, session.UserUUID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	organization, err := s.getOrganization(ctx, session.ActiveOrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return organization, member, nil
+}
+
+// requireMemberPermission проверяет, что роли пользователя сессии в ее активной организации дают разрешение
+func (s *authService) requireMemberPermission(
+	ctx context.Context,
+	sessionUUID string,
+	permission string,
+) (*models.Session, error) {
+	session, err := s.getSession(ctx, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.getUserAccess(ctx, session.ActiveOrganizationID, session.UserUUID)
+	if err != nil {
+		return nil, err
+	}
+	if _, found := access.FindGrant(permission, ""); !found {
+		return nil, apperrors.ErrPermissionDenied
+	}
+
+	return session, nil
+}
+
+// requireRoleDelegation проверяет, что пользователь сессии может выдать роль активной организации:
+// все разрешения роли должны быть у него самого. Иначе members:manage позволял бы получить
+// любые права, пригласив с нужной ролью собственный второй аккаунт
+func (s *authService) requireRoleDelegation(ctx context.Context, session *models.Session, role string) error {
+	permissions, err := s.roleRepo.GetRolePermissions(ctx, session.ActiveOrganizationID, role)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRoleNotFound) {
+			return apperrors.ErrRoleNotFound
+		}
+		s.logger.Error("failed to get role permissions", "error", err, "role", role)
+		return fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	access, err := s.getUserAccess(ctx, session.ActiveOrganizationID, session.UserUUID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(access.Permissions, permission) {
+			return apperrors.ErrPermissionDenied
+		}
+	}
+
+	return nil
+}
+
+// getMember возвращает участника организации
+func (s *authService) getMember(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.Member, error) {
+	member, err := s.membershipRepo.GetMember(ctx, organizationID, userUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrMemberNotFound) {
+			return nil, apperrors.ErrMemberNotFound
+		}
+		s.logger.Error("failed to get member", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	return member, nil
+}
+
+// getOrganization возвращает организацию по идентификатору
+func (s *authService) getOrganization(ctx context.Context, organizationID uuid.UUID) (*models.Organization, error) {
+	organization, err := s.organizationRepo.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrOrganizationNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		s.logger.Error("failed to get organization", "error", err, "tenant_id", organizationID)
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return organization, nil
+}
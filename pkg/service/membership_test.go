@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+func TestRequireRoleDelegation(t *testing.T) {
+	acmeID := uuid.New()
+	globexID := uuid.New()
+
+	s := newTestAuthService()
+	s.roleRepo = &stubRoleRepository{
+		roles: []*models.Role{
+			{TenantID: acmeID, Name: "viewer", Permissions: []string{"docs:read"}},
+			{TenantID: acmeID, Name: "editor", Permissions: []string{"docs:read", "docs:write"}},
+			{TenantID: acmeID, Name: "admin", Permissions: []string{"docs:read", "members:manage"}},
+			{TenantID: acmeID, Name: "member"},
+			{TenantID: globexID, Name: "auditor", Permissions: []string{"docs:read"}},
+		},
+		access: map[uuid.UUID]*models.UserAccess{
+			acmeID: {
+				Roles:       []string{"inviter"},
+				Permissions: []string{"docs:read", "members:manage"},
+				Grants: []models.RoleGrant{
+					{Role: "inviter", Permissions: []string{"docs:read", "members:manage"}},
+					{Role: "editor", Resource: "docs/1", Permissions: []string{"docs:write"}},
+				},
+			},
+		},
+	}
+	session := &models.Session{
+		UUID:                 uuid.NewString(),
+		UserUUID:             uuid.New(),
+		TenantID:             acmeID,
+		ActiveOrganizationID: acmeID,
+	}
+
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "роль с частью разрешений пригласившего", role: "viewer"},
+		{name: "роль со всеми разрешениями пригласившего", role: "admin"},
+		{name: "роль без разрешений", role: "member"},
+		{
+			name:    "роль с разрешением, которого нет у пригласившего",
+			role:    "editor",
+			wantErr: apperrors.ErrPermissionDenied,
+		},
+		{
+			name:    "роль другой организации",
+			role:    "auditor",
+			wantErr: apperrors.ErrRoleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.requireRoleDelegation(context.Background(), session, tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("requireRoleDelegation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
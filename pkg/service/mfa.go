@@ -0,0 +1,532 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+const (
+	// totpPeriod длительность временного шага TOTP
+	totpPeriod = 30
+	// totpSkew количество соседних шагов, коды которых тоже принимаются
+	totpSkew = 1
+	// recoveryCodesCount количество кодов восстановления, выдаваемых за раз
+	recoveryCodesCount = 10
+	// recoveryCodeSize количество случайных байт в коде восстановления
+	recoveryCodeSize = 10
+)
+
+// totpOpts параметры TOTP, совместимые с Google Authenticator и аналогами
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// recoveryCodeEncoding кодировка кодов восстановления без неоднозначных символов
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTOTPRequest запрос на подключение TOTP
+type EnrollTOTPRequest struct {
+	SessionUUID string
+}
+
+// EnrollTOTPResponse данные для добавления аккаунта в приложение-аутентификатор
+type EnrollTOTPResponse struct {
+	// Secret секрет в base32 для ручного ввода
+	Secret string
+	// URI otpauth:// URI для QR кода
+	URI string
+}
+
+// ConfirmTOTPRequest запрос на подтверждение подключения TOTP
+type ConfirmTOTPRequest struct {
+	SessionUUID string
+	Code        string
+}
+
+// ConfirmTOTPResponse ответ на подтверждение подключения TOTP
+type ConfirmTOTPResponse struct {
+	// RecoveryCodes одноразовые коды восстановления, показываются пользователю только один раз
+	RecoveryCodes []string
+}
+
+// DisableTOTPRequest запрос на отключение TOTP.
+// Нужен код из приложения или код восстановления
+type DisableTOTPRequest struct {
+	SessionUUID  string
+	Code         string
+	RecoveryCode string
+}
+
+// DisableTOTPResponse ответ на отключение TOTP
+type DisableTOTPResponse struct{}
+
+// RegenerateRecoveryCodesRequest запрос на выпуск новых кодов восстановления
+type RegenerateRecoveryCodesRequest struct {
+	SessionUUID string
+	Code        string
+}
+
+// RegenerateRecoveryCodesResponse новые коды восстановления. Предыдущие становятся недействительными
+type RegenerateRecoveryCodesResponse struct {
+	RecoveryCodes []string
+}
+
+// CompleteMFARequest запрос на завершение входа вторым фактором.
+// Должен быть заполнен ровно один из Code и RecoveryCode
+type CompleteMFARequest struct {
+	ChallengeID  string
+	Code         string
+	RecoveryCode string
+	Client       ClientInfo
+}
+
+// EnrollTOTP создает новый секрет TOTP. До подтверждения кодом TOTP не требуется при входе
+func (s *authService) EnrollTOTP(ctx context.Context, req EnrollTOTPRequest) (*EnrollTOTPResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getSessionUser(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.cfg.TOTPIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		s.logger.Error("failed to generate TOTP secret", "error", err)
+		return nil, fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+
+	secretEncrypted, err := s.secretCipher.Encrypt([]byte(key.Secret()), totpAdditionalData(user.UUID))
+	if err != nil {
+		s.logger.Error("failed to encrypt TOTP secret", "error", err)
+		return nil, fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+
+	err = s.mfaRepo.SaveUnconfirmedTOTP(ctx, &models.TOTP{
+		UserUUID:        user.UUID,
+		SecretEncrypted: secretEncrypted,
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTOTPAlreadyEnabled) {
+			return nil, apperrors.ErrTOTPAlreadyEnabled
+		}
+		s.logger.Error("failed to save TOTP", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to save TOTP: %w", err)
+	}
+
+	s.logger.Info("TOTP enrollment started", "user_uuid", user.UUID)
+
+	return &EnrollTOTPResponse{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+// ConfirmTOTP включает TOTP после проверки первого кода из приложения и выдает коды восстановления
+func (s *authService) ConfirmTOTP(ctx context.Context, req ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateTOTPCode(req.Code); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getSessionUser(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.getTOTP(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if secret.ConfirmedAt != nil {
+		return nil, apperrors.ErrTOTPAlreadyEnabled
+	}
+
+	now := time.Now()
+
+	step, ok := matchTOTPCode(secret.Secret, req.Code, now)
+	if !ok {
+		return nil, apperrors.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Error("failed to generate recovery codes", "error", err)
+		return nil, err
+	}
+
+	if err := s.mfaRepo.ConfirmTOTP(ctx, user.UUID, now, step, hashes); err != nil {
+		if errors.Is(err, apperrors.ErrTOTPAlreadyEnabled) {
+			return nil, apperrors.ErrTOTPAlreadyEnabled
+		}
+		s.logger.Error("failed to confirm TOTP", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to confirm TOTP: %w", err)
+	}
+
+	s.logger.Info("TOTP enabled", "user_uuid", user.UUID)
+
+	return &ConfirmTOTPResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableTOTP отключает TOTP после проверки кода из приложения или кода восстановления
+func (s *authService) DisableTOTP(ctx context.Context, req DisableTOTPRequest) (*DisableTOTPResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateSecondFactor(req.Code, req.RecoveryCode); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getSessionUser(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyUserSecondFactor(ctx, user, req.Code, req.RecoveryCode, "", time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.DeleteTOTP(ctx, user.UUID); err != nil {
+		s.logger.Error("failed to delete TOTP", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to delete TOTP: %w", err)
+	}
+
+	s.logger.Info("TOTP disabled", "user_uuid", user.UUID)
+
+	return &DisableTOTPResponse{}, nil
+}
+
+// RegenerateRecoveryCodes заменяет коды восстановления новыми после проверки кода из приложения
+func (s *authService) RegenerateRecoveryCodes(
+	ctx context.Context,
+	req RegenerateRecoveryCodesRequest,
+) (*RegenerateRecoveryCodesResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateTOTPCode(req.Code); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getSessionUser(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if err := s.verifyUserSecondFactor(ctx, user, req.Code, "", "", now); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Error("failed to generate recovery codes", "error", err)
+		return nil, err
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, user.UUID, hashes, now); err != nil {
+		s.logger.Error("failed to replace recovery codes", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to replace recovery codes: %w", err)
+	}
+
+	s.logger.Info("recovery codes regenerated", "user_uuid", user.UUID)
+
+	return &RegenerateRecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// CompleteMFA завершает вход, начатый Login, кодом из приложения или кодом восстановления
+func (s *authService) CompleteMFA(ctx context.Context, req CompleteMFARequest) (*LoginResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateMFAChallenge(req.ChallengeID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateSecondFactor(req.Code, req.RecoveryCode); err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.mfaChallengeRepo.GetMFAChallenge(ctx, req.ChallengeID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrMFAChallengeNotFound) {
+			return nil, apperrors.ErrMFAChallengeNotFound
+		}
+		s.logger.Error("failed to get mfa challenge", "error", err)
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByUUID(ctx, challenge.TenantID, challenge.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrMFAChallengeNotFound
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", challenge.UserUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+
+	if err := s.verifyUserSecondFactor(ctx, user, req.Code, req.RecoveryCode, req.Client.IP, now); err != nil {
+		if errors.Is(err, apperrors.ErrInvalidMFACode) {
+			if err := s.mfaChallengeRepo.RecordMFAFailure(ctx, challenge.ID, s.cfg.MFAMaxAttempts); err != nil {
+				s.logger.Error("failed to record mfa failure", "error", err, "user_uuid", challenge.UserUUID)
+			}
+		}
+		return nil, err
+	}
+
+	// Challenge одноразовый: параллельный запрос с тем же challenge не получит вторую сессию
+	consumed, err := s.mfaChallengeRepo.ConsumeMFAChallenge(ctx, challenge.ID)
+	if err != nil {
+		s.logger.Error("failed to consume mfa challenge", "error", err, "user_uuid", challenge.UserUUID)
+		return nil, fmt.Errorf("failed to consume mfa challenge: %w", err)
+	}
+	if !consumed {
+		return nil, apperrors.ErrMFAChallengeNotFound
+	}
+
+	return s.startSession(ctx, user, req.Client, challenge.IssueTokens, now)
+}
+
+// isMFAEnabled проверяет, подключен ли у пользователя подтвержденный TOTP
+func (s *authService) isMFAEnabled(ctx context.Context, userUUID uuid.UUID) (bool, error) {
+	secret, err := s.mfaRepo.GetTOTP(ctx, userUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTOTPNotEnabled) {
+			return false, nil
+		}
+		s.logger.Error("failed to get TOTP", "error", err, "user_uuid", userUUID)
+		return false, fmt.Errorf("failed to get TOTP: %w", err)
+	}
+
+	return secret.ConfirmedAt != nil, nil
+}
+
+// startMFAChallenge создает challenge второго фактора вместо сессии
+func (s *authService) startMFAChallenge(
+	ctx context.Context,
+	user *models.User,
+	issueTokens bool,
+	now time.Time,
+) (*LoginResponse, error) {
+	challenge := &models.MFAChallenge{
+		UserUUID:    user.UUID,
+		TenantID:    user.TenantID,
+		IssueTokens: issueTokens,
+		ExpiresAt:   now.Add(s.cfg.MFAChallengeTTL),
+	}
+	if err := s.mfaChallengeRepo.CreateMFAChallenge(ctx, challenge); err != nil {
+		s.logger.Error("failed to create mfa challenge", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to create mfa challenge: %w", err)
+	}
+
+	s.logger.Info("user login requires second factor", "user_uuid", user.UUID)
+
+	return &LoginResponse{
+		UserUUID:       user.UUID,
+		ExpiresAt:      challenge.ExpiresAt,
+		MFAChallengeID: challenge.ID,
+	}, nil
+}
+
+// verifyUserSecondFactor проверяет второй фактор пользователя с учетом блокировки попыток входа.
+// Неверный код считается неудачной попыткой входа в аккаунт, а счетчик сбрасывается только
+// после верного кода, поэтому коды нельзя перебирать ни через новые challenge, ни через DisableTOTP
+func (s *authService) verifyUserSecondFactor(
+	ctx context.Context,
+	user *models.User,
+	code string,
+	recoveryCode string,
+	ip string,
+	now time.Time,
+) error {
+	if err := s.reserveLoginAttempt(ctx, user.TenantID, user.Email, ip); err != nil {
+		return err
+	}
+
+	if err := s.verifySecondFactor(ctx, user.UUID, code, recoveryCode, now); err != nil {
+		// Неверный код оставляет зарезервированную попытку неудачной
+		if !errors.Is(err, apperrors.ErrInvalidMFACode) {
+			s.releaseLoginAttempt(ctx, user.TenantID, user.Email, ip)
+		}
+		return err
+	}
+
+	s.releaseLoginAttempt(ctx, user.TenantID, user.Email, ip)
+	s.resetAccountLoginFailures(ctx, user.TenantID, user.Email)
+
+	return nil
+}
+
+// verifySecondFactor проверяет код из приложения или код восстановления подтвержденного TOTP.
+// Каждый код принимается только один раз
+func (s *authService) verifySecondFactor(
+	ctx context.Context,
+	userUUID uuid.UUID,
+	code string,
+	recoveryCode string,
+	now time.Time,
+) error {
+	secret, err := s.getTOTP(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if secret.ConfirmedAt == nil {
+		return apperrors.ErrTOTPNotEnabled
+	}
+
+	if recoveryCode != "" {
+		used, err := s.mfaRepo.UseRecoveryCode(ctx, userUUID, hashRecoveryCode(recoveryCode), now)
+		if err != nil {
+			s.logger.Error("failed to use recovery code", "error", err, "user_uuid", userUUID)
+			return fmt.Errorf("failed to use recovery code: %w", err)
+		}
+		if !used {
+			return apperrors.ErrInvalidMFACode
+		}
+
+		s.logger.Info("recovery code used", "user_uuid", userUUID)
+		return nil
+	}
+
+	step, ok := matchTOTPCode(secret.Secret, code, now)
+	if !ok {
+		return apperrors.ErrInvalidMFACode
+	}
+
+	// Запрещаем повторное использование кода, в том числе перехваченного
+	used, err := s.mfaRepo.UseTOTPStep(ctx, userUUID, step)
+	if err != nil {
+		s.logger.Error("failed to use TOTP step", "error", err, "user_uuid", userUUID)
+		return fmt.Errorf("failed to use TOTP step: %w", err)
+	}
+	if !used {
+		return apperrors.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// getTOTP получает TOTP пользователя и расшифровывает его секрет
+func (s *authService) getTOTP(ctx context.Context, userUUID uuid.UUID) (*models.TOTP, error) {
+	secret, err := s.mfaRepo.GetTOTP(ctx, userUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTOTPNotEnabled) {
+			return nil, apperrors.ErrTOTPNotEnabled
+		}
+		s.logger.Error("failed to get TOTP", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to get TOTP: %w", err)
+	}
+
+	plaintext, err := s.secretCipher.Decrypt(secret.SecretEncrypted, totpAdditionalData(userUUID))
+	if err != nil {
+		s.logger.Error("failed to decrypt TOTP secret", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	secret.Secret = string(plaintext)
+
+	return secret, nil
+}
+
+// getSessionUser получает пользователя, которому принадлежит сессия
+func (s *authService) getSessionUser(ctx context.Context, sessionUUID string) (*models.User, error) {
+	session, err := s.getSession(ctx, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByUUID(ctx, session.TenantID, session.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrSessionNotFound
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", session.UserUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// matchTOTPCode ищет временной шаг, которому соответствует код, с учетом рассинхронизации часов
+func matchTOTPCode(secret, code string, now time.Time) (int64, bool) {
+	for offset := -totpSkew; offset <= totpSkew; offset++ {
+		t := now.Add(time.Duration(offset*totpPeriod) * time.Second)
+
+		expected, err := totp.GenerateCodeCustom(secret, t, totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return t.Unix() / totpPeriod, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateRecoveryCodes генерирует коды восстановления и их хеши для хранения
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	buf := make([]byte, recoveryCodeSize)
+	for range recoveryCodesCount {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+		code := encoded[:len(encoded)/2] + "-" + encoded[len(encoded)/2:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode возвращает хеш кода восстановления без учета регистра и разделителей
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return token.HashOpaqueToken(normalized)
+}
+
+// totpAdditionalData связывает зашифрованный секрет с пользователем,
+// чтобы его нельзя было подставить другому пользователю
+func totpAdditionalData(userUUID uuid.UUID) []byte {
+	return []byte("totp:" + userUUID.String())
+}
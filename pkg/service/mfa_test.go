@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/keys"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// testTOTPSecret секрет TOTP в base32 для тестов
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+// mfaFixture сервис с одним пользователем с подтвержденным TOTP
+type mfaFixture struct {
+	s             *authService
+	user          *models.User
+	organization  *models.Organization
+	mfaRepo       *memoryMFARepository
+	recoveryCodes []string
+}
+
+func newMFAFixture(t *testing.T) *mfaFixture {
+	t.Helper()
+
+	organization := newTestOrganization("acme")
+	hasher := newTestPasswordHasher(t)
+	passwordHash, err := hasher.Hash("correct-password")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	user := &models.User{
+		UUID:         uuid.New(),
+		TenantID:     organization.ID,
+		Email:        "user@acme.example.com",
+		PasswordHash: passwordHash,
+	}
+
+	masterKey := make([]byte, 32)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+	cipher, err := keys.NewAESCipher(base64.StdEncoding.EncodeToString(masterKey))
+	if err != nil {
+		t.Fatalf("NewAESCipher() unexpected error: %v", err)
+	}
+	secretEncrypted, err := cipher.Encrypt([]byte(testTOTPSecret), totpAdditionalData(user.UUID))
+	if err != nil {
+		t.Fatalf("Encrypt() unexpected error: %v", err)
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("generateRecoveryCodes() unexpected error: %v", err)
+	}
+
+	confirmedAt := time.Now().Add(-time.Hour)
+	mfaRepo := newMemoryMFARepository()
+	mfaRepo.totps[user.UUID] = &models.TOTP{
+		UserUUID:        user.UUID,
+		SecretEncrypted: secretEncrypted,
+		ConfirmedAt:     &confirmedAt,
+	}
+	mfaRepo.recoveryCodes[user.UUID] = make(map[string]struct{})
+	for _, codeHash := range recoveryCodeHashes {
+		mfaRepo.recoveryCodes[user.UUID][codeHash] = struct{}{}
+	}
+
+	s := newTestAuthService()
+	s.userRepo = &stubUserRepository{users: []*models.User{user}}
+	s.organizationRepo = &stubOrganizationRepository{organizations: []*models.Organization{organization}}
+	s.mfaRepo = mfaRepo
+	s.mfaChallengeRepo = newMemoryMFAChallengeRepository()
+	s.refreshTokenRepo = newMemoryRefreshTokenRepository()
+	s.tokenManager = &stubTokenManager{}
+	s.secretCipher = cipher
+	s.passwordHasher = hasher
+	s.dummyPasswordHash = newDummyPasswordHash(hasher)
+	s.cfg.MFAChallengeTTL = 5 * time.Minute
+	s.cfg.MFAMaxAttempts = 3
+	s.cfg.RefreshTokenTTL = time.Hour
+	s.cfg.RefreshTokenMaxLifetime = time.Hour
+
+	return &mfaFixture{
+		s:             s,
+		user:          user,
+		organization:  organization,
+		mfaRepo:       mfaRepo,
+		recoveryCodes: recoveryCodes,
+	}
+}
+
+func totpCode(t *testing.T, at time.Time) string {
+	t.Helper()
+
+	code, err := totp.GenerateCodeCustom(testTOTPSecret, at, totpOpts)
+	if err != nil {
+		t.Fatalf("GenerateCodeCustom() unexpected error: %v", err)
+	}
+	return code
+}
+
+func TestMatchTOTPCode(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	period := totpPeriod * time.Second
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "код текущего шага", code: totpCode(t, now), wantStep: now.Unix() / totpPeriod, wantOK: true},
+		{name: "код предыдущего шага", code: totpCode(t, now.Add(-period)), wantStep: now.Unix()/totpPeriod - 1, wantOK: true},
+		{name: "код следующего шага", code: totpCode(t, now.Add(period)), wantStep: now.Unix()/totpPeriod + 1, wantOK: true},
+		{name: "код за пределами рассинхронизации", code: totpCode(t, now.Add(-3*period))},
+		{name: "неверный код", code: "000000"},
+		{name: "пустой код", code: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := matchTOTPCode(testTOTPSecret, tt.code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("matchTOTPCode() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("matchTOTPCode() step = %d, want %d", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestVerifySecondFactor(t *testing.T) {
+	period := totpPeriod * time.Second
+
+	tests := []struct {
+		name string
+		// prepare использует коды до проверяемого и возвращает проверяемые код и код восстановления
+		prepare func(t *testing.T, f *mfaFixture, now time.Time) (string, string)
+		wantErr error
+	}{
+		{
+			name: "код из приложения",
+			prepare: func(t *testing.T, _ *mfaFixture, now time.Time) (string, string) {
+				return totpCode(t, now), ""
+			},
+		},
+		{
+			name: "повторный код из приложения",
+			prepare: func(t *testing.T, f *mfaFixture, now time.Time) (string, string) {
+				code := totpCode(t, now)
+				if err := f.s.verifySecondFactor(context.Background(), f.user.UUID, code, "", now); err != nil {
+					t.Fatalf("verifySecondFactor() unexpected error: %v", err)
+				}
+				return code, ""
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "код предыдущего шага после текущего",
+			prepare: func(t *testing.T, f *mfaFixture, now time.Time) (string, string) {
+				if err := f.s.verifySecondFactor(context.Background(), f.user.UUID, totpCode(t, now), "", now); err != nil {
+					t.Fatalf("verifySecondFactor() unexpected error: %v", err)
+				}
+				return totpCode(t, now.Add(-period)), ""
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "неверный код из приложения",
+			prepare: func(*testing.T, *mfaFixture, time.Time) (string, string) {
+				return "000000", ""
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "код восстановления",
+			prepare: func(_ *testing.T, f *mfaFixture, _ time.Time) (string, string) {
+				return "", f.recoveryCodes[0]
+			},
+		},
+		{
+			name: "код восстановления в верхнем регистре без дефиса",
+			prepare: func(_ *testing.T, f *mfaFixture, _ time.Time) (string, string) {
+				return "", strings.ToUpper(strings.ReplaceAll(f.recoveryCodes[0], "-", ""))
+			},
+		},
+		{
+			name: "повторный код восстановления",
+			prepare: func(t *testing.T, f *mfaFixture, now time.Time) (string, string) {
+				if err := f.s.verifySecondFactor(context.Background(), f.user.UUID, "", f.recoveryCodes[0], now); err != nil {
+					t.Fatalf("verifySecondFactor() unexpected error: %v", err)
+				}
+				return "", f.recoveryCodes[0]
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "неизвестный код восстановления",
+			prepare: func(*testing.T, *mfaFixture, time.Time) (string, string) {
+				return "", "aaaaaaaa-aaaaaaaa"
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "TOTP не подтвержден",
+			prepare: func(t *testing.T, f *mfaFixture, now time.Time) (string, string) {
+				f.mfaRepo.totps[f.user.UUID].ConfirmedAt = nil
+				return totpCode(t, now), ""
+			},
+			wantErr: apperrors.ErrTOTPNotEnabled,
+		},
+		{
+			name: "TOTP не подключен",
+			prepare: func(t *testing.T, f *mfaFixture, now time.Time) (string, string) {
+				delete(f.mfaRepo.totps, f.user.UUID)
+				return totpCode(t, now), ""
+			},
+			wantErr: apperrors.ErrTOTPNotEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMFAFixture(t)
+			now := time.Now()
+			code, recoveryCode := tt.prepare(t, f, now)
+
+			err := f.s.verifySecondFactor(context.Background(), f.user.UUID, code, recoveryCode, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("verifySecondFactor() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// login начинает вход пользователя и возвращает challenge второго фактора
+func (f *mfaFixture) login(t *testing.T) string {
+	t.Helper()
+
+	resp, err := f.s.Login(context.Background(), LoginRequest{
+		Tenant:      f.organization.Slug,
+		Email:       f.user.Email,
+		Password:    "correct-password",
+		IssueTokens: true,
+	})
+	if err != nil {
+		t.Fatalf("Login() unexpected error: %v", err)
+	}
+	if resp.MFAChallengeID == "" || resp.Tokens != nil {
+		t.Fatalf("Login() = %+v, want an MFA challenge without tokens", resp)
+	}
+	return resp.MFAChallengeID
+}
+
+func (f *mfaFixture) completeMFA(challengeID, code string) (*LoginResponse, error) {
+	return f.s.CompleteMFA(context.Background(), CompleteMFARequest{
+		ChallengeID: challengeID,
+		Code:        code,
+	})
+}
+
+func TestCompleteMFA(t *testing.T) {
+	f := newMFAFixture(t)
+	challengeID := f.login(t)
+
+	// Неверный код не расходует challenge
+	if _, err := f.completeMFA(challengeID, "000000"); !errors.Is(err, apperrors.ErrInvalidMFACode) {
+		t.Fatalf("CompleteMFA() error = %v, want %v", err, apperrors.ErrInvalidMFACode)
+	}
+
+	resp, err := f.completeMFA(challengeID, totpCode(t, time.Now()))
+	if err != nil {
+		t.Fatalf("CompleteMFA() unexpected error: %v", err)
+	}
+	if resp.Tokens == nil || resp.UserUUID != f.user.UUID {
+		t.Errorf("CompleteMFA() = %+v, want tokens for user %s", resp, f.user.UUID)
+	}
+
+	// Challenge одноразовый, даже с кодом восстановления
+	_, err = f.s.CompleteMFA(context.Background(), CompleteMFARequest{
+		ChallengeID:  challengeID,
+		RecoveryCode: f.recoveryCodes[0],
+	})
+	if !errors.Is(err, apperrors.ErrMFAChallengeNotFound) {
+		t.Errorf("second CompleteMFA() error = %v, want %v", err, apperrors.ErrMFAChallengeNotFound)
+	}
+}
+
+func TestCompleteMFAMaxAttempts(t *testing.T) {
+	f := newMFAFixture(t)
+	challengeID := f.login(t)
+
+	for range f.s.cfg.MFAMaxAttempts {
+		if _, err := f.completeMFA(challengeID, "000000"); !errors.Is(err, apperrors.ErrInvalidMFACode) {
+			t.Fatalf("CompleteMFA() error = %v, want %v", err, apperrors.ErrInvalidMFACode)
+		}
+	}
+
+	// После исчерпания попыток challenge удален, и верный код уже не помогает
+	if _, err := f.completeMFA(challengeID, totpCode(t, time.Now())); !errors.Is(err, apperrors.ErrMFAChallengeNotFound) {
+		t.Errorf("CompleteMFA() error = %v, want %v", err, apperrors.ErrMFAChallengeNotFound)
+	}
+}
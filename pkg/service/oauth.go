@@ -0,0 +1,1012 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+const (
+	// maxOAuthScopes максимальное количество областей действия OAuth клиента
+	maxOAuthScopes = 50
+	// maxOAuthRedirectURIs максимальное количество redirect URI OAuth клиента
+	maxOAuthRedirectURIs = 10
+	// oauthResponseTypeCode единственный поддерживаемый response_type: authorization code
+	oauthResponseTypeCode = "code"
+	// oauthTokenTypeHintAccessToken и oauthTokenTypeHintRefreshToken подсказки типа токена по RFC 7009
+	oauthTokenTypeHintAccessToken  = "access_token"
+	oauthTokenTypeHintRefreshToken = "refresh_token"
+)
+
+// CreateOAuthClientRequest запрос на регистрацию OAuth клиента
+type CreateOAuthClientRequest struct {
+	// Tenant slug организации, которой принадлежит клиент
+	Tenant       string
+	Name         string
+	RedirectURIs []string
+	GrantTypes   []string
+	Scopes       []string
+	// Confidential клиенту выдается секрет. Публичные клиенты, например SPA и мобильные приложения,
+	// подтверждают обмен кода только через PKCE
+	Confidential bool
+	// FirstParty собственное приложение, согласие пользователя не запрашивается
+	FirstParty bool
+	// ServiceAccountID сервисный аккаунт организации, от имени которого клиент
+	// получает токены по client_credentials
+	ServiceAccountID string
+}
+
+// CreateOAuthClientResponse зарегистрированный OAuth клиент
+type CreateOAuthClientResponse struct {
+	Client *models.OAuthClient
+	// ClientSecret возвращается только здесь, сервер хранит лишь его хеш. Пустой у публичного клиента
+	ClientSecret string
+}
+
+// DeleteOAuthClientRequest запрос на удаление OAuth клиента
+type DeleteOAuthClientRequest struct {
+	// Tenant slug организации клиента
+	Tenant   string
+	ClientID string
+}
+
+// DeleteOAuthClientResponse ответ на удаление OAuth клиента
+type DeleteOAuthClientResponse struct {
+	// Deleted клиент существовал
+	Deleted bool
+}
+
+// GrantOAuthConsentRequest запрос на согласие пользователя сессии на доступ OAuth клиента
+type GrantOAuthConsentRequest struct {
+	SessionUUID string
+	ClientID    string
+	Scopes      []string
+}
+
+// GrantOAuthConsentResponse согласие со всеми областями действия, на которые оно дано
+type GrantOAuthConsentResponse struct {
+	Consent *models.OAuthConsent
+}
+
+// ListOAuthConsentsRequest запрос согласий пользователя сессии
+type ListOAuthConsentsRequest struct {
+	SessionUUID string
+}
+
+// ListOAuthConsentsResponse согласия пользователя, начиная с последнего измененного
+type ListOAuthConsentsResponse struct {
+	Consents []*models.OAuthConsent
+}
+
+// RevokeOAuthConsentRequest запрос на отзыв согласия пользователя сессии
+type RevokeOAuthConsentRequest struct {
+	SessionUUID string
+	ClientID    string
+}
+
+// RevokeOAuthConsentResponse ответ на отзыв согласия
+type RevokeOAuthConsentResponse struct {
+	// Revoked согласие было
+	Revoked bool
+}
+
+// ResolveOAuthRedirectRequest запрос redirect URI клиента для ответа /oauth2/authorize
+type ResolveOAuthRedirectRequest struct {
+	ClientID string
+	// RedirectURI пустой, если клиент полагается на единственный зарегистрированный URI
+	RedirectURI string
+}
+
+// ResolveOAuthRedirectResponse redirect URI, на который можно вернуть пользователя с кодом или ошибкой
+type ResolveOAuthRedirectResponse struct {
+	RedirectURI string
+}
+
+// AuthorizeRequest запрос кода авторизации OAuth клиента от имени пользователя сессии
+type AuthorizeRequest struct {
+	SessionUUID  string
+	ClientID     string
+	RedirectURI  string
+	ResponseType string
+	// Scope области действия через пробел. Пустой - все области действия клиента
+	Scope               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	// Nonce OpenID Connect, возвращается клиенту в ID токене
+	Nonce string
+}
+
+// AuthorizeResponse выданный код авторизации
+type AuthorizeResponse struct {
+	Code string
+	// RedirectURI куда вернуть пользователя с кодом
+	RedirectURI string
+	Scopes      []string
+}
+
+// OAuthClientCredentials учетные данные клиента в запросах к /oauth2/token и /oauth2/revoke
+type OAuthClientCredentials struct {
+	ClientID string
+	// ClientSecret пустой у публичного клиента
+	ClientSecret string
+}
+
+// OAuthTokenRequest запрос к token endpoint
+type OAuthTokenRequest struct {
+	Client    OAuthClientCredentials
+	GrantType string
+	// Code, RedirectURI и CodeVerifier для гранта authorization_code
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	// RefreshToken для гранта refresh_token
+	RefreshToken string
+	// Scope области действия через пробел. Пустой - все области, доступные по гранту
+	Scope string
+}
+
+// OAuthTokenResponse токены, выданные OAuth клиенту
+type OAuthTokenResponse struct {
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+	// RefreshToken пустой для client_credentials и у клиентов без гранта refresh_token
+	RefreshToken string
+	// IDToken выдается, если среди областей действия есть openid
+	IDToken string
+	Scopes  []string
+}
+
+// RevokeOAuthTokenRequest запрос на отзыв токена по RFC 7009
+type RevokeOAuthTokenRequest struct {
+	Client        OAuthClientCredentials
+	Token         string
+	TokenTypeHint string
+}
+
+// RevokeOAuthTokenResponse ответ на отзыв токена
+type RevokeOAuthTokenResponse struct {
+	// Revoked токен был действующим refresh токеном клиента
+	Revoked bool
+}
+
+// CreateOAuthClient регистрирует OAuth клиента организации. Секрет конфиденциального клиента
+// возвращается только в ответе, в базе хранится его хеш
+func (s *authService) CreateOAuthClient(
+	ctx context.Context,
+	req CreateOAuthClientRequest,
+) (*CreateOAuthClientResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateOAuthClientName(req.Name); err != nil {
+		return nil, err
+	}
+	if len(req.RedirectURIs) > maxOAuthRedirectURIs {
+		return nil, fmt.Errorf("%w: at most %d redirect uris are allowed", apperrors.ErrInvalidInput, maxOAuthRedirectURIs)
+	}
+	for _, redirectURI := range req.RedirectURIs {
+		if err := validator.ValidateRedirectURI(redirectURI); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.Scopes) > maxOAuthScopes {
+		return nil, fmt.Errorf("%w: at most %d scopes are allowed", apperrors.ErrInvalidInput, maxOAuthScopes)
+	}
+	for _, scope := range req.Scopes {
+		if err := validator.ValidateOAuthScope(scope); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateOAuthGrantTypes(req); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.resolveTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	client := &models.OAuthClient{
+		ID:           uuid.New(),
+		TenantID:     organization.ID,
+		Name:         strings.TrimSpace(req.Name),
+		RedirectURIs: sortedUnique(req.RedirectURIs),
+		GrantTypes:   sortedUnique(req.GrantTypes),
+		Scopes:       sortedUnique(req.Scopes),
+		FirstParty:   req.FirstParty,
+		CreatedAt:    now,
+	}
+
+	if req.ServiceAccountID != "" {
+		serviceAccount, err := s.serviceAccountRepo.GetServiceAccount(
+			ctx,
+			organization.ID,
+			uuid.MustParse(req.ServiceAccountID),
+		)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrServiceAccountNotFound) {
+				return nil, apperrors.ErrServiceAccountNotFound
+			}
+			s.logger.Error("failed to get service account", "error", err, "service_account_id", req.ServiceAccountID)
+			return nil, fmt.Errorf("failed to get service account: %w", err)
+		}
+		client.ServiceAccountID = &serviceAccount.ID
+	}
+
+	var clientSecret string
+	if req.Confidential {
+		clientSecret, err = token.GenerateOpaqueToken()
+		if err != nil {
+			s.logger.Error("failed to generate client secret", "error", err)
+			return nil, err
+		}
+		client.SecretHash = token.HashOpaqueToken(clientSecret)
+	}
+
+	if err := s.oauthRepo.CreateOAuthClient(ctx, client); err != nil {
+		s.logger.Error("failed to create oauth client", "error", err, "tenant_id", organization.ID)
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+
+	s.logger.Info("oauth client created",
+		"tenant_id", client.TenantID,
+		"client_id", client.ID,
+		"grant_types", client.GrantTypes,
+		"confidential", client.Confidential(),
+	)
+
+	return &CreateOAuthClientResponse{
+		Client:       client,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// DeleteOAuthClient удаляет OAuth клиента организации. Выданные клиенту refresh токены
+// перестают приниматься, потому что клиент больше не проходит аутентификацию
+func (s *authService) DeleteOAuthClient(
+	ctx context.Context,
+	req DeleteOAuthClientRequest,
+) (*DeleteOAuthClientResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateOAuthClientID(req.ClientID); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.resolveTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := uuid.MustParse(req.ClientID)
+	deleted, err := s.oauthRepo.DeleteOAuthClient(ctx, organization.ID, clientID)
+	if err != nil {
+		s.logger.Error("failed to delete oauth client", "error", err, "client_id", clientID)
+		return nil, fmt.Errorf("failed to delete oauth client: %w", err)
+	}
+
+	s.logger.Info("oauth client deleted", "tenant_id", organization.ID, "client_id", clientID, "deleted", deleted)
+
+	return &DeleteOAuthClientResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+// GrantOAuthConsent сохраняет согласие пользователя сессии на доступ клиента.
+// Области действия добавляются к тем, на которые пользователь уже согласился
+func (s *authService) GrantOAuthConsent(
+	ctx context.Context,
+	req GrantOAuthConsentRequest,
+) (*GrantOAuthConsentResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateOAuthClientID(req.ClientID); err != nil {
+		return nil, err
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: scopes are required", apperrors.ErrInvalidInput)
+	}
+	for _, scope := range req.Scopes {
+		if err := validator.ValidateOAuthScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.oauthRepo.GetOAuthClient(ctx, uuid.MustParse(req.ClientID))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrOAuthClientNotFound) {
+			return nil, apperrors.ErrOAuthClientNotFound
+		}
+		s.logger.Error("failed to get oauth client", "error", err, "client_id", req.ClientID)
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	if !client.AllowsScopes(req.Scopes) {
+		return nil, apperrors.ErrInvalidScope
+	}
+
+	now := time.Now()
+	consent, err := s.oauthRepo.GetOAuthConsent(ctx, session.UserUUID, client.ID)
+	switch {
+	case errors.Is(err, apperrors.ErrOAuthConsentNotFound):
+		consent = &models.OAuthConsent{
+			UserUUID:  session.UserUUID,
+			ClientID:  client.ID,
+			CreatedAt: now,
+		}
+	case err != nil:
+		s.logger.Error("failed to get oauth consent", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to get oauth consent: %w", err)
+	}
+	consent.Scopes = sortedUnique(append(consent.Scopes, req.Scopes...))
+	consent.UpdatedAt = now
+
+	if err := s.oauthRepo.SaveOAuthConsent(ctx, consent); err != nil {
+		s.logger.Error("failed to save oauth consent", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to save oauth consent: %w", err)
+	}
+
+	s.logger.Info("oauth consent granted", "user_uuid", session.UserUUID, "client_id", client.ID, "scopes", consent.Scopes)
+
+	return &GrantOAuthConsentResponse{
+		Consent: consent,
+	}, nil
+}
+
+// ListOAuthConsents возвращает согласия пользователя сессии
+func (s *authService) ListOAuthConsents(
+	ctx context.Context,
+	req ListOAuthConsentsRequest,
+) (*ListOAuthConsentsResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := s.oauthRepo.ListOAuthConsents(ctx, session.UserUUID)
+	if err != nil {
+		s.logger.Error("failed to list oauth consents", "error", err, "user_uuid", session.UserUUID)
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+
+	return &ListOAuthConsentsResponse{
+		Consents: consents,
+	}, nil
+}
+
+// RevokeOAuthConsent отзывает согласие пользователя сессии и refresh токены, выданные клиенту от его имени.
+// Следующая авторизация клиента снова потребует согласия. Access токены действуют до истечения
+func (s *authService) RevokeOAuthConsent(
+	ctx context.Context,
+	req RevokeOAuthConsentRequest,
+) (*RevokeOAuthConsentResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateOAuthClientID(req.ClientID); err != nil {
+		return nil, err
+	}
+	clientID := uuid.MustParse(req.ClientID)
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.oauthRepo.DeleteOAuthConsent(ctx, session.UserUUID, clientID)
+	if err != nil {
+		s.logger.Error("failed to delete oauth consent", "error", err, "client_id", clientID)
+		return nil, fmt.Errorf("failed to delete oauth consent: %w", err)
+	}
+
+	// Без согласия клиент не должен продолжать обновлять токены пользователя
+	revokedFamilies, err := s.refreshTokenRepo.RevokeUserClientRefreshTokens(ctx, session.UserUUID, clientID)
+	if err != nil {
+		s.logger.Error("failed to revoke oauth refresh tokens", "error", err, "client_id", clientID)
+		return nil, fmt.Errorf("failed to revoke oauth refresh tokens: %w", err)
+	}
+
+	s.logger.Info("oauth consent revoked",
+		"user_uuid", session.UserUUID,
+		"client_id", clientID,
+		"revoked", revoked,
+		"revoked_refresh_families", revokedFamilies,
+	)
+
+	return &RevokeOAuthConsentResponse{
+		Revoked: revoked,
+	}, nil
+}
+
+// ResolveOAuthRedirect проверяет клиента и redirect URI запроса /oauth2/authorize.
+// Пока они не проверены, вернуть пользователя к клиенту с ошибкой нельзя
+func (s *authService) ResolveOAuthRedirect(
+	ctx context.Context,
+	req ResolveOAuthRedirectRequest,
+) (*ResolveOAuthRedirectResponse, error) {
+	client, err := s.getOAuthClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURI, err := resolveRedirectURI(client, req.RedirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResolveOAuthRedirectResponse{
+		RedirectURI: redirectURI,
+	}, nil
+}
+
+// Authorize выдает OAuth клиенту код авторизации от имени пользователя сессии, участника организации клиента.
+// Сторонним клиентам код выдается, только если пользователь согласился на все запрошенные области действия
+func (s *authService) Authorize(ctx context.Context, req AuthorizeRequest) (*AuthorizeResponse, error) {
+	client, err := s.getOAuthClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	redirectURI, err := resolveRedirectURI(client, req.RedirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Валидация входных данных
+	if req.ResponseType != oauthResponseTypeCode {
+		return nil, apperrors.ErrUnsupportedResponseType
+	}
+	if !client.AllowsGrant(models.OAuthGrantAuthorizationCode) {
+		return nil, apperrors.ErrUnauthorizedClient
+	}
+	// PKCE обязателен для всех клиентов, как рекомендует OAuth 2.1
+	if err := validator.ValidateCodeChallenge(req.CodeChallenge, req.CodeChallengeMethod); err != nil {
+		return nil, err
+	}
+	scopes, err := resolveOAuthScopes(client, req.Scope, client.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateNonce(req.Nonce); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, apperrors.ErrSessionNotFound
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Клиент организации авторизует только ее участников: иначе клиент одной организации
+	// получал бы токены пользователей другой
+	if _, err := s.getMember(ctx, client.TenantID, session.UserUUID); err != nil {
+		if errors.Is(err, apperrors.ErrMemberNotFound) {
+			return nil, apperrors.ErrNotOrganizationMember
+		}
+		return nil, err
+	}
+
+	if !client.FirstParty {
+		consent, err := s.oauthRepo.GetOAuthConsent(ctx, session.UserUUID, client.ID)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrOAuthConsentNotFound) {
+				return nil, apperrors.ErrConsentRequired
+			}
+			s.logger.Error("failed to get oauth consent", "error", err, "client_id", client.ID)
+			return nil, fmt.Errorf("failed to get oauth consent: %w", err)
+		}
+		if !consent.Covers(scopes) {
+			return nil, apperrors.ErrConsentRequired
+		}
+	}
+
+	code, err := token.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("failed to generate authorization code", "error", err)
+		return nil, err
+	}
+
+	authorizationCode := &models.OAuthAuthorizationCode{
+		CodeHash: token.HashOpaqueToken(code),
+		ClientID: client.ID,
+		// Сохраняется redirect_uri из запроса, даже пустой: при обмене он должен совпасть
+		RedirectURI:   req.RedirectURI,
+		UserUUID:      session.UserUUID,
+		TenantID:      session.TenantID,
+		Scopes:        scopes,
+		CodeChallenge: req.CodeChallenge,
+		Nonce:         req.Nonce,
+		AuthTime:      session.CreatedAt,
+		ExpiresAt:     time.Now().Add(s.cfg.OAuthCodeTTL),
+	}
+	if err := s.oauthCodeRepo.CreateAuthorizationCode(ctx, authorizationCode); err != nil {
+		s.logger.Error("failed to create authorization code", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	s.logger.Info("oauth authorization code issued",
+		"user_uuid", session.UserUUID,
+		"client_id", client.ID,
+		"scopes", scopes,
+	)
+
+	return &AuthorizeResponse{
+		Code:        code,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+	}, nil
+}
+
+// ExchangeOAuthToken выдает токены OAuth клиенту по гранту authorization_code, refresh_token
+// или client_credentials
+func (s *authService) ExchangeOAuthToken(ctx context.Context, req OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	client, err := s.authenticateOAuthClient(ctx, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case models.OAuthGrantAuthorizationCode:
+		return s.exchangeAuthorizationCode(ctx, client, req)
+	case models.OAuthGrantRefreshToken:
+		return s.exchangeOAuthRefreshToken(ctx, client, req)
+	case models.OAuthGrantClientCredentials:
+		return s.exchangeClientCredentials(ctx, client, req)
+	case "":
+		return nil, fmt.Errorf("%w: grant_type is required", apperrors.ErrInvalidInput)
+	default:
+		return nil, apperrors.ErrUnsupportedGrantType
+	}
+}
+
+// RevokeOAuthToken отзывает refresh токен клиента по RFC 7009 вместе со всем его семейством.
+// Access токены живут недолго и не отзываются: для них возвращается ErrUnsupportedTokenType.
+// Неизвестный токен не считается ошибкой, чтобы по ответу нельзя было проверять чужие токены
+func (s *authService) RevokeOAuthToken(
+	ctx context.Context,
+	req RevokeOAuthTokenRequest,
+) (*RevokeOAuthTokenResponse, error) {
+	client, err := s.authenticateOAuthClient(ctx, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	// Валидация входных данных
+	if strings.TrimSpace(req.Token) == "" {
+		return nil, fmt.Errorf("%w: token is required", apperrors.ErrInvalidInput)
+	}
+	if req.TokenTypeHint == oauthTokenTypeHintAccessToken {
+		return nil, apperrors.ErrUnsupportedTokenType
+	}
+
+	revoked, err := s.refreshTokenRepo.RevokeRefreshToken(ctx, token.HashOpaqueToken(req.Token), client.ID)
+	if err != nil {
+		s.logger.Error("failed to revoke refresh token", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if !revoked && req.TokenTypeHint != oauthTokenTypeHintRefreshToken {
+		if _, err := s.tokenManager.ParseAccessToken(req.Token); err == nil {
+			return nil, apperrors.ErrUnsupportedTokenType
+		}
+	}
+
+	s.logger.Info("oauth token revocation", "client_id", client.ID, "revoked", revoked)
+
+	return &RevokeOAuthTokenResponse{
+		Revoked: revoked,
+	}, nil
+}
+
+// exchangeAuthorizationCode обменивает код авторизации на токены пользователя, выдавшего код
+func (s *authService) exchangeAuthorizationCode(
+	ctx context.Context,
+	client *models.OAuthClient,
+	req OAuthTokenRequest,
+) (*OAuthTokenResponse, error) {
+	if !client.AllowsGrant(models.OAuthGrantAuthorizationCode) {
+		return nil, apperrors.ErrUnauthorizedClient
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, fmt.Errorf("%w: code is required", apperrors.ErrInvalidInput)
+	}
+	if err := validator.ValidateCodeVerifier(req.CodeVerifier); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	code, err := s.oauthCodeRepo.ConsumeAuthorizationCode(ctx, token.HashOpaqueToken(req.Code))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidGrant) {
+			return nil, apperrors.ErrInvalidGrant
+		}
+		s.logger.Error("failed to consume authorization code", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI || !now.Before(code.ExpiresAt) {
+		return nil, apperrors.ErrInvalidGrant
+	}
+	if !verifyCodeChallenge(req.CodeVerifier, code.CodeChallenge) {
+		return nil, apperrors.ErrInvalidGrant
+	}
+
+	user, err := s.userRepo.GetUserByUUID(ctx, code.TenantID, code.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidGrant
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", code.UserUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	resp, err := s.issueOAuthTokens(ctx, client, user, code.Scopes, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.IDToken, err = s.issueIDToken(client, user, code.Scopes, code.Nonce, code.AuthTime, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// exchangeOAuthRefreshToken ротирует refresh токен клиента так же, как Refresh.
+// Запрошенные области действия могут только сузить доступ нового access токена
+func (s *authService) exchangeOAuthRefreshToken(
+	ctx context.Context,
+	client *models.OAuthClient,
+	req OAuthTokenRequest,
+) (*OAuthTokenResponse, error) {
+	if !client.AllowsGrant(models.OAuthGrantRefreshToken) {
+		return nil, apperrors.ErrUnauthorizedClient
+	}
+	if err := validator.ValidateRefreshToken(req.RefreshToken); err != nil {
+		return nil, err
+	}
+	// Заведомо недопустимые области действия отклоняются до ротации, чтобы не сжечь токен клиента
+	if _, err := resolveOAuthScopes(client, req.Scope, client.Scopes); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	refreshToken, err := token.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("failed to generate refresh token", "error", err)
+		return nil, err
+	}
+
+	rotated := &models.RefreshToken{
+		TokenHash: token.HashOpaqueToken(refreshToken),
+		ExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+	}
+	err = s.refreshTokenRepo.RotateRefreshToken(
+		ctx,
+		token.HashOpaqueToken(req.RefreshToken),
+		client.ID,
+		rotated,
+		now,
+		s.cfg.RefreshTokenMaxLifetime,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrRefreshTokenReused):
+			s.logger.Warn("oauth refresh token reuse detected, token family revoked",
+				"client_id", client.ID,
+				"user_uuid", rotated.UserUUID,
+				"family_id", rotated.FamilyID,
+			)
+			return nil, apperrors.ErrInvalidGrant
+		case errors.Is(err, apperrors.ErrInvalidRefreshToken):
+			return nil, apperrors.ErrInvalidGrant
+		default:
+			s.logger.Error("failed to rotate refresh token", "error", err, "client_id", client.ID)
+			return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+	}
+
+	// Authorize проверяет членство в организации клиента однократно, поэтому исключенный
+	// из нее пользователь не должен сохранять доступ через обновление токенов
+	user, err := s.getRefreshTokenMember(ctx, rotated, client.TenantID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrMemberNotFound) {
+			return nil, apperrors.ErrInvalidGrant
+		}
+		return nil, err
+	}
+
+	scopes, err := resolveOAuthScopes(client, req.Scope, rotated.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, accessTokenExpiresAt, err := s.tokenManager.IssueClientAccessToken(
+		rotated.UserUUID,
+		rotated.TenantID,
+		token.ClientGrant{
+			ClientID:      client.ID,
+			Scopes:        scopes,
+			PrincipalType: models.PrincipalTypeUser,
+		},
+		now,
+	)
+	if err != nil {
+		s.logger.Error("failed to issue access token", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	resp := &OAuthTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessTokenExpiresAt,
+		RefreshToken:         refreshToken,
+		Scopes:               scopes,
+	}
+
+	// ID токен при обновлении выдается с актуальными данными пользователя, но без nonce
+	if slices.Contains(scopes, oidcScopeOpenID) {
+		resp.IDToken, err = s.issueIDToken(client, user, scopes, "", time.Time{}, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// exchangeClientCredentials выдает конфиденциальному клиенту access токен сервисного аккаунта,
+// к которому привязан клиент. Refresh токен не выдается: клиент может просто запросить новый токен
+func (s *authService) exchangeClientCredentials(
+	ctx context.Context,
+	client *models.OAuthClient,
+	req OAuthTokenRequest,
+) (*OAuthTokenResponse, error) {
+	if !client.AllowsGrant(models.OAuthGrantClientCredentials) || !client.Confidential() ||
+		client.ServiceAccountID == nil {
+		return nil, apperrors.ErrUnauthorizedClient
+	}
+
+	scopes, err := resolveOAuthScopes(client, req.Scope, client.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount, err := s.serviceAccountRepo.GetServiceAccount(ctx, client.TenantID, *client.ServiceAccountID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrServiceAccountNotFound) {
+			return nil, apperrors.ErrUnauthorizedClient
+		}
+		s.logger.Error("failed to get service account", "error", err, "service_account_id", client.ServiceAccountID)
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	accessToken, accessTokenExpiresAt, err := s.tokenManager.IssueClientAccessToken(
+		serviceAccount.ID,
+		serviceAccount.TenantID,
+		token.ClientGrant{
+			ClientID:      client.ID,
+			Scopes:        scopes,
+			PrincipalType: models.PrincipalTypeServiceAccount,
+		},
+		time.Now(),
+	)
+	if err != nil {
+		s.logger.Error("failed to issue access token", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	s.logger.Info("oauth client credentials token issued",
+		"client_id", client.ID,
+		"service_account_id", serviceAccount.ID,
+		"scopes", scopes,
+	)
+
+	return &OAuthTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessTokenExpiresAt,
+		Scopes:               scopes,
+	}, nil
+}
+
+// issueOAuthTokens выпускает OAuth клиенту access токен пользователя и, если клиенту
+// разрешен грант refresh_token, первый refresh токен нового семейства
+func (s *authService) issueOAuthTokens(
+	ctx context.Context,
+	client *models.OAuthClient,
+	user *models.User,
+	scopes []string,
+	now time.Time,
+) (*OAuthTokenResponse, error) {
+	accessToken, accessTokenExpiresAt, err := s.tokenManager.IssueClientAccessToken(
+		user.UUID,
+		user.TenantID,
+		token.ClientGrant{
+			ClientID:      client.ID,
+			Scopes:        scopes,
+			PrincipalType: models.PrincipalTypeUser,
+		},
+		now,
+	)
+	if err != nil {
+		s.logger.Error("failed to issue access token", "error", err, "client_id", client.ID)
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	resp := &OAuthTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessTokenExpiresAt,
+		Scopes:               scopes,
+	}
+	if client.AllowsGrant(models.OAuthGrantRefreshToken) {
+		refreshToken, _, err := s.createRefreshToken(ctx, user, client.ID, scopes, now)
+		if err != nil {
+			return nil, err
+		}
+		resp.RefreshToken = refreshToken
+	}
+
+	s.logger.Info("oauth tokens issued", "user_uuid", user.UUID, "client_id", client.ID, "scopes", scopes)
+
+	return resp, nil
+}
+
+// authenticateOAuthClient проверяет учетные данные клиента. Конфиденциальный клиент
+// обязан передать секрет, публичный не должен его передавать
+func (s *authService) authenticateOAuthClient(
+	ctx context.Context,
+	credentials OAuthClientCredentials,
+) (*models.OAuthClient, error) {
+	client, err := s.getOAuthClient(ctx, credentials.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.Confidential() {
+		if credentials.ClientSecret != "" {
+			return nil, apperrors.ErrInvalidClient
+		}
+		return client, nil
+	}
+
+	secretHash := token.HashOpaqueToken(credentials.ClientSecret)
+	if credentials.ClientSecret == "" || subtle.ConstantTimeCompare([]byte(secretHash), []byte(client.SecretHash)) != 1 {
+		return nil, apperrors.ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// getOAuthClient возвращает OAuth клиента по client_id. Неизвестный клиент - ErrInvalidClient
+func (s *authService) getOAuthClient(ctx context.Context, rawClientID string) (*models.OAuthClient, error) {
+	clientID, err := uuid.Parse(rawClientID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidClient
+	}
+
+	client, err := s.oauthRepo.GetOAuthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrOAuthClientNotFound) {
+			return nil, apperrors.ErrInvalidClient
+		}
+		s.logger.Error("failed to get oauth client", "error", err, "client_id", clientID)
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	return client, nil
+}
+
+// validateOAuthGrantTypes проверяет, что набор грантов клиента поддерживается и согласован с его настройками
+func validateOAuthGrantTypes(req CreateOAuthClientRequest) error {
+	if len(req.GrantTypes) == 0 {
+		return fmt.Errorf("%w: at least one grant type is required", apperrors.ErrInvalidInput)
+	}
+	for _, grantType := range req.GrantTypes {
+		switch grantType {
+		case models.OAuthGrantAuthorizationCode, models.OAuthGrantRefreshToken, models.OAuthGrantClientCredentials:
+		default:
+			return fmt.Errorf("%w: unsupported grant type %q", apperrors.ErrInvalidInput, grantType)
+		}
+	}
+
+	authorizationCode := slices.Contains(req.GrantTypes, models.OAuthGrantAuthorizationCode)
+	clientCredentials := slices.Contains(req.GrantTypes, models.OAuthGrantClientCredentials)
+
+	if authorizationCode && len(req.RedirectURIs) == 0 {
+		return fmt.Errorf("%w: authorization_code grant requires redirect uris", apperrors.ErrInvalidInput)
+	}
+	if slices.Contains(req.GrantTypes, models.OAuthGrantRefreshToken) && !authorizationCode {
+		return fmt.Errorf("%w: refresh_token grant requires authorization_code grant", apperrors.ErrInvalidInput)
+	}
+	if clientCredentials && (!req.Confidential || req.ServiceAccountID == "") {
+		return fmt.Errorf("%w: client_credentials grant requires a confidential client with a service account",
+			apperrors.ErrInvalidInput)
+	}
+	if req.ServiceAccountID != "" {
+		if !clientCredentials {
+			return fmt.Errorf("%w: service account requires client_credentials grant", apperrors.ErrInvalidInput)
+		}
+		if err := validator.ValidateServiceAccountID(req.ServiceAccountID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveRedirectURI возвращает redirect URI запроса, если он зарегистрирован у клиента.
+// Пустой URI допустим, только если у клиента зарегистрирован ровно один
+func resolveRedirectURI(client *models.OAuthClient, redirectURI string) (string, error) {
+	if redirectURI == "" {
+		if len(client.RedirectURIs) != 1 {
+			return "", apperrors.ErrInvalidRedirectURI
+		}
+		return client.RedirectURIs[0], nil
+	}
+
+	// URI сравнивается целиком, без нормализации, как требует OAuth 2.1
+	if !slices.Contains(client.RedirectURIs, redirectURI) {
+		return "", apperrors.ErrInvalidRedirectURI
+	}
+
+	return redirectURI, nil
+}
+
+// resolveOAuthScopes разбирает параметр scope. Пустой параметр означает все области действия granted.
+// Запрошенные области действия должны входить в granted и быть разрешены клиенту
+func resolveOAuthScopes(client *models.OAuthClient, scope string, granted []string) ([]string, error) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return sortedUnique(granted), nil
+	}
+
+	for _, s := range requested {
+		if err := validator.ValidateOAuthScope(s); err != nil || !slices.Contains(granted, s) {
+			return nil, apperrors.ErrInvalidScope
+		}
+	}
+	if !client.AllowsScopes(requested) {
+		return nil, apperrors.ErrInvalidScope
+	}
+
+	return sortedUnique(requested), nil
+}
+
+// verifyCodeChallenge проверяет code_verifier по PKCE challenge метода S256
+func verifyCodeChallenge(codeVerifier, codeChallenge string) bool {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// sortedUnique возвращает отсортированную копию без повторов. Результат не nil:
+// в базе списки хранятся в колонках NOT NULL
+func sortedUnique(values []string) []string {
+	sorted := append(make([]string, 0, len(values)), values...)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+)
+
+// testCodeVerifier code_verifier из примера RFC 7636, приложение B
+const testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	sum := sha256.Sum256([]byte(testCodeVerifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		name      string
+		verifier  string
+		challenge string
+		want      bool
+	}{
+		{
+			name:      "challenge из RFC 7636",
+			verifier:  testCodeVerifier,
+			challenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			want:      true,
+		},
+		{
+			name:      "верный verifier",
+			verifier:  testCodeVerifier,
+			challenge: challenge,
+			want:      true,
+		},
+		{
+			name:      "другой verifier",
+			verifier:  testCodeVerifier + "x",
+			challenge: challenge,
+		},
+		{
+			name:      "verifier вместо challenge (метод plain)",
+			verifier:  testCodeVerifier,
+			challenge: testCodeVerifier,
+		},
+		{
+			name:      "challenge с дополнением base64",
+			verifier:  testCodeVerifier,
+			challenge: challenge + "=",
+		},
+		{
+			name:      "пустой verifier",
+			challenge: challenge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyCodeChallenge(tt.verifier, tt.challenge); got != tt.want {
+				t.Errorf("verifyCodeChallenge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRedirectURI(t *testing.T) {
+	single := &models.OAuthClient{RedirectURIs: []string{"https://app.example.com/callback"}}
+	multiple := &models.OAuthClient{RedirectURIs: []string{
+		"https://app.example.com/callback",
+		"http://127.0.0.1:8000/callback",
+	}}
+
+	tests := []struct {
+		name        string
+		client      *models.OAuthClient
+		redirectURI string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:   "пустой URI у клиента с одним адресом",
+			client: single,
+			want:   "https://app.example.com/callback",
+		},
+		{
+			name:    "пустой URI у клиента с несколькими адресами",
+			client:  multiple,
+			wantErr: apperrors.ErrInvalidRedirectURI,
+		},
+		{
+			name:        "зарегистрированный URI",
+			client:      multiple,
+			redirectURI: "http://127.0.0.1:8000/callback",
+			want:        "http://127.0.0.1:8000/callback",
+		},
+		{
+			name:        "незарегистрированный URI",
+			client:      single,
+			redirectURI: "https://evil.example.com/callback",
+			wantErr:     apperrors.ErrInvalidRedirectURI,
+		},
+		{
+			name:        "URI не нормализуется",
+			client:      single,
+			redirectURI: "https://APP.example.com/callback",
+			wantErr:     apperrors.ErrInvalidRedirectURI,
+		},
+		{
+			name:        "URI с дополнительными параметрами",
+			client:      single,
+			redirectURI: "https://app.example.com/callback?next=/admin",
+			wantErr:     apperrors.ErrInvalidRedirectURI,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveRedirectURI(tt.client, tt.redirectURI)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveRedirectURI() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveRedirectURI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveOAuthScopes(t *testing.T) {
+	client := &models.OAuthClient{Scopes: []string{"openid", "email", "profile", "docs:read"}}
+
+	tests := []struct {
+		name    string
+		scope   string
+		granted []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "пустой scope дает все выданные области",
+			granted: []string{"profile", "openid", "openid"},
+			want:    []string{"openid", "profile"},
+		},
+		{
+			name:    "пустой scope без выданных областей",
+			granted: nil,
+			want:    []string{},
+		},
+		{
+			name:    "запрошено подмножество",
+			scope:   "  email openid email ",
+			granted: client.Scopes,
+			want:    []string{"email", "openid"},
+		},
+		{
+			name:    "область не выдана",
+			scope:   "openid docs:read",
+			granted: []string{"openid"},
+			wantErr: apperrors.ErrInvalidScope,
+		},
+		{
+			name:    "область не разрешена клиенту",
+			scope:   "docs:write",
+			granted: []string{"docs:write"},
+			wantErr: apperrors.ErrInvalidScope,
+		},
+		{
+			name:    "недопустимое имя области",
+			scope:   "Docs",
+			granted: []string{"Docs"},
+			wantErr: apperrors.ErrInvalidScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveOAuthScopes(client, tt.scope, tt.granted)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveOAuthScopes() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got == nil || !slices.Equal(got, tt.want)) {
+				t.Errorf("resolveOAuthScopes() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFirstPartyAccessToken(t *testing.T) {
+	subject := uuid.NewString()
+	tenantID := uuid.NewString()
+
+	claims := map[string]*token.Claims{
+		"first-party": {TenantID: tenantID},
+		"oauth-user": {
+			TenantID: tenantID,
+			ClientID: uuid.NewString(),
+			Scope:    "openid",
+		},
+		"client-credentials": {
+			TenantID:      tenantID,
+			ClientID:      uuid.NewString(),
+			PrincipalType: string(models.PrincipalTypeServiceAccount),
+		},
+		"service-account": {
+			TenantID:      tenantID,
+			PrincipalType: string(models.PrincipalTypeServiceAccount),
+		},
+	}
+	for _, c := range claims {
+		c.Subject = subject
+	}
+	s := &authService{tokenManager: &stubTokenManager{claims: claims}}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "токен входа в сервис", token: "first-party"},
+		{name: "токен OAuth клиента от имени пользователя", token: "oauth-user", wantErr: true},
+		{name: "токен client_credentials", token: "client-credentials", wantErr: true},
+		{name: "токен сервисного аккаунта без клиента", token: "service-account", wantErr: true},
+		{name: "неизвестный токен", token: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := s.parseFirstPartyAccessToken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, apperrors.ErrInvalidAccessToken) {
+					t.Errorf("parseFirstPartyAccessToken() error = %v, want %v", err, apperrors.ErrInvalidAccessToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFirstPartyAccessToken() unexpected error: %v", err)
+			}
+			if claims.Subject != subject {
+				t.Errorf("parseFirstPartyAccessToken() subject = %q, want %q", claims.Subject, subject)
+			}
+		})
+	}
+}
+
+func TestRevokeOAuthConsent(t *testing.T) {
+	user := &models.User{UUID: uuid.New(), TenantID: uuid.New()}
+	session := &models.Session{UUID: uuid.NewString(), UserUUID: user.UUID, TenantID: user.TenantID}
+	clientID := uuid.New()
+	otherClientID := uuid.New()
+
+	refreshRepo := newMemoryRefreshTokenRepository()
+	s := newTestAuthService()
+	s.sessionRepo = &stubSessionRepository{sessions: []*models.Session{session}}
+	s.oauthRepo = &stubOAuthRepository{consents: []*models.OAuthConsent{{UserUUID: user.UUID, ClientID: clientID}}}
+	s.refreshTokenRepo = refreshRepo
+
+	// Семейства клиента, другого клиента и входа в сам сервис
+	familyIDs := map[uuid.UUID]uuid.UUID{}
+	for _, id := range []uuid.UUID{clientID, otherClientID, uuid.Nil} {
+		familyIDs[id] = uuid.New()
+		err := refreshRepo.CreateRefreshToken(context.Background(), &models.RefreshToken{
+			TokenHash: uuid.NewString(),
+			UserUUID:  user.UUID,
+			TenantID:  user.TenantID,
+			FamilyID:  familyIDs[id],
+			ClientID:  id,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("CreateRefreshToken() unexpected error: %v", err)
+		}
+	}
+
+	resp, err := s.RevokeOAuthConsent(context.Background(), RevokeOAuthConsentRequest{
+		SessionUUID: session.UUID,
+		ClientID:    clientID.String(),
+	})
+	if err != nil {
+		t.Fatalf("RevokeOAuthConsent() unexpected error: %v", err)
+	}
+	if !resp.Revoked {
+		t.Error("RevokeOAuthConsent() revoked = false, want true")
+	}
+
+	if _, ok := refreshRepo.families[familyIDs[clientID]]; ok {
+		t.Error("refresh token family of the client left after consent revocation")
+	}
+	for _, id := range []uuid.UUID{otherClientID, uuid.Nil} {
+		if _, ok := refreshRepo.families[familyIDs[id]]; !ok {
+			t.Errorf("refresh token family of client %s revoked, want it kept", id)
+		}
+	}
+}
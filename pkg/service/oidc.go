@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+)
+
+// Области действия OpenID Connect. openid включает выдачу ID токена,
+// profile и email открывают соответствующие claims
+const (
+	oidcScopeOpenID  = "openid"
+	oidcScopeProfile = "profile"
+	oidcScopeEmail   = "email"
+)
+
+// UserInfoRequest запрос claims пользователя по access токену OAuth клиента
+type UserInfoRequest struct {
+	AccessToken string
+}
+
+// UserInfoResponse claims пользователя, доступные по областям действия access токена
+type UserInfoResponse struct {
+	Subject  uuid.UUID
+	Identity token.IdentityClaims
+}
+
+// UserInfo возвращает claims пользователя для эндпоинта /userinfo OpenID Connect.
+// Принимает только токены OAuth клиентов, в отличие от WhoAmI. Токен должен быть выдан
+// с областью действия openid, поэтому токены входа в сам сервис и client_credentials не подходят
+func (s *authService) UserInfo(ctx context.Context, req UserInfoRequest) (*UserInfoResponse, error) {
+	// Валидация входных данных
+	if req.AccessToken == "" {
+		return nil, apperrors.ErrInvalidAccessToken
+	}
+
+	claims, err := s.tokenManager.ParseAccessToken(req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := strings.Fields(claims.Scope)
+	if !slices.Contains(scopes, oidcScopeOpenID) || claims.Principal() != models.PrincipalTypeUser {
+		return nil, apperrors.ErrInsufficientScope
+	}
+
+	whoAmI, err := s.whoAmIByClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserInfoResponse{
+		Subject:  whoAmI.UserUUID,
+		Identity: oidcIdentity(whoAmI.Email, whoAmI.Username, whoAmI.EmailVerifiedAt, scopes),
+	}, nil
+}
+
+// issueIDToken выпускает ID токен, если клиент запросил область действия openid. Иначе возвращает пустую строку
+func (s *authService) issueIDToken(
+	client *models.OAuthClient,
+	user *models.User,
+	scopes []string,
+	nonce string,
+	authTime time.Time,
+	now time.Time,
+) (string, error) {
+	if !slices.Contains(scopes, oidcScopeOpenID) {
+		return "", nil
+	}
+
+	idToken, err := s.tokenManager.IssueIDToken(token.IDToken{
+		Subject:  user.UUID,
+		ClientID: client.ID,
+		Identity: oidcIdentity(user.Email, user.Username, user.EmailVerifiedAt, scopes),
+		Nonce:    nonce,
+		AuthTime: authTime,
+	}, now)
+	if err != nil {
+		s.logger.Error("failed to issue id token", "error", err, "client_id", client.ID)
+		return "", fmt.Errorf("failed to issue id token: %w", err)
+	}
+
+	return idToken, nil
+}
+
+// oidcIdentity собирает стандартные claims пользователя, открытые областями действия
+func oidcIdentity(email, username string, emailVerifiedAt *time.Time, scopes []string) token.IdentityClaims {
+	var identity token.IdentityClaims
+
+	if slices.Contains(scopes, oidcScopeEmail) {
+		emailVerified := emailVerifiedAt != nil
+		identity.Email = email
+		identity.EmailVerified = &emailVerified
+	}
+	if slices.Contains(scopes, oidcScopeProfile) {
+		identity.PreferredUsername = username
+	}
+
+	return identity
+}
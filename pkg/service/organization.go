@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// CreateOrganizationRequest запрос на создание организации
+type CreateOrganizationRequest struct {
+	Slug string
+	Name string
+}
+
+// CreateOrganizationResponse ответ на создание организации
+type CreateOrganizationResponse struct {
+	ID        uuid.UUID
+	Slug      string
+	Name      string
+	CreatedAt time.Time
+}
+
+// CreateOrganization создает организацию с уникальным slug, по которому к ней обращаются при входе
+func (s *authService) CreateOrganization(
+	ctx context.Context,
+	req CreateOrganizationRequest,
+) (*CreateOrganizationResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateTenant(req.Slug); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateOrganizationName(req.Name); err != nil {
+		return nil, err
+	}
+
+	organization := &models.Organization{
+		ID:        uuid.New(),
+		Slug:      req.Slug,
+		Name:      strings.TrimSpace(req.Name),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.organizationRepo.CreateOrganization(ctx, organization); err != nil {
+		if errors.Is(err, apperrors.ErrOrganizationAlreadyExists) {
+			return nil, apperrors.ErrOrganizationAlreadyExists
+		}
+		s.logger.Error("failed to create organization", "error", err, "tenant", req.Slug)
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	s.logger.Info("organization created", "tenant", organization.Slug, "tenant_id", organization.ID)
+
+	return &CreateOrganizationResponse{
+		ID:        organization.ID,
+		Slug:      organization.Slug,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+	}, nil
+}
+
+// resolveTenant находит организацию, указанную клиентом в запросе
+func (s *authService) resolveTenant(ctx context.Context, tenant string) (*models.Organization, error) {
+	if err := validator.ValidateTenant(tenant); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.organizationRepo.GetOrganizationBySlug(ctx, tenant)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrOrganizationNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		s.logger.Error("failed to get organization", "error", err, "tenant", tenant)
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+// lookupTenant находит организацию, как resolveTenant, но для неизвестной организации возвращает nil без ошибки.
+// Используется в публичных RPC, где ответ не должен выдавать, какие организации существуют
+func (s *authService) lookupTenant(ctx context.Context, tenant string) (*models.Organization, error) {
+	organization, err := s.resolveTenant(ctx, tenant)
+	if errors.Is(err, apperrors.ErrOrganizationNotFound) {
+		return nil, nil
+	}
+	return organization, err
+}
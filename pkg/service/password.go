@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/notifier"
+	"github.com/olezhek28/auth-service/pkg/password"
+	"github.com/olezhek28/auth-service/pkg/token"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// Имена полей запросов с новым паролем в нарушениях политики паролей
+const (
+	passwordField    = "password"
+	newPasswordField = "new_password"
+)
+
+// ChangePasswordRequest запрос на смену пароля
+type ChangePasswordRequest struct {
+	SessionUUID string
+	OldPassword string
+	NewPassword string
+}
+
+// ChangePasswordResponse ответ на смену пароля
+type ChangePasswordResponse struct {
+	RevokedSessions int
+}
+
+// RequestPasswordResetRequest запрос на сброс пароля
+type RequestPasswordResetRequest struct {
+	// Tenant slug организации пользователя
+	Tenant string
+	Email  string
+}
+
+// RequestPasswordResetResponse ответ на запрос сброса пароля.
+// Одинаков для существующих и несуществующих email
+type RequestPasswordResetResponse struct{}
+
+// ConfirmPasswordResetRequest запрос на установку нового пароля по токену сброса
+type ConfirmPasswordResetRequest struct {
+	Token       string
+	NewPassword string
+}
+
+// ConfirmPasswordResetResponse ответ на установку нового пароля по токену сброса
+type ConfirmPasswordResetResponse struct{}
+
+// ChangePassword меняет пароль пользователя, которому принадлежит текущая сессия.
+// Все остальные сессии и refresh токены пользователя отзываются, текущая сессия остается активной
+func (s *authService) ChangePassword(ctx context.Context, req ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if req.OldPassword == "" {
+		return nil, fmt.Errorf("%w: old_password is required", apperrors.ErrInvalidInput)
+	}
+	if req.OldPassword == req.NewPassword {
+		return nil, fmt.Errorf("%w: new password must differ from the old one", apperrors.ErrInvalidInput)
+	}
+
+	// Получаем сессию
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем пользователя
+	user, err := s.userRepo.GetUserByUUID(ctx, session.TenantID, session.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrSessionNotFound
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", session.UserUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.checkPasswordPolicy(newPasswordField, req.NewPassword, user.Email, user.Username); err != nil {
+		return nil, err
+	}
+
+	// Проверяем старый пароль с той же блокировкой попыток аккаунта, что и при входе,
+	// иначе украденная сессия позволяла бы перебирать пароль без ограничений
+	if err := s.reserveLoginAttempt(ctx, user.TenantID, user.Email, ""); err != nil {
+		return nil, err
+	}
+	valid, err := s.passwordHasher.Verify(req.OldPassword, user.PasswordHash)
+	if err != nil {
+		s.releaseLoginAttempt(ctx, user.TenantID, user.Email, "")
+		s.logger.Error("failed to verify password", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !valid {
+		return nil, apperrors.ErrInvalidCredentials
+	}
+	s.releaseLoginAttempt(ctx, user.TenantID, user.Email, "")
+
+	// Проверяем историю только после старого пароля, чтобы без него она не раскрывалась
+	if err := s.checkPasswordHistory(ctx, user, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	// Хешируем новый пароль
+	passwordHash, err := s.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		s.logger.Error("failed to hash password", "error", err)
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, user.TenantID, user.UUID, passwordHash, s.cfg.PasswordHistorySize); err != nil {
+		s.logger.Error("failed to update password", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Завершаем остальные сессии, чтобы старый пароль перестал давать доступ
+	revoked, err := s.sessionRepo.DeleteOtherUserSessions(ctx, user.UUID, session.UUID)
+	if err != nil {
+		s.logger.Error("failed to delete other user sessions", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to delete other user sessions: %w", err)
+	}
+
+	// Refresh токены не привязаны к сессии, поэтому отзываются все
+	revokedFamilies, err := s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, user.UUID)
+	if err != nil {
+		s.logger.Error("failed to revoke refresh tokens", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	s.logger.Info("password changed successfully",
+		"user_uuid", user.UUID,
+		"session_uuid", session.UUID,
+		"revoked_sessions", revoked,
+		"revoked_token_families", revokedFamilies,
+	)
+
+	return &ChangePasswordResponse{
+		RevokedSessions: revoked,
+	}, nil
+}
+
+// RequestPasswordReset отправляет пользователю одноразовый токен сброса пароля.
+// Ответ не зависит от того, зарегистрирован ли email: токен выдается и доставляется
+// в фоне, чтобы ни ошибки, ни время ответа не выдавали существование пользователя
+func (s *authService) RequestPasswordReset(ctx context.Context, req RequestPasswordResetRequest) (*RequestPasswordResetResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.lookupTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return &RequestPasswordResetResponse{}, nil
+	}
+
+	// Получаем пользователя по email
+	user, err := s.userRepo.GetUserByEmail(ctx, organization.ID, req.Email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return &RequestPasswordResetResponse{}, nil
+		}
+		s.logger.Error("failed to get user", "error", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	s.deliverInBackground(ctx, user, "password reset token", s.sendPasswordResetToken)
+
+	return &RequestPasswordResetResponse{}, nil
+}
+
+// ConfirmPasswordReset устанавливает новый пароль по токену сброса и завершает все сессии пользователя
+func (s *authService) ConfirmPasswordReset(ctx context.Context, req ConfirmPasswordResetRequest) (*ConfirmPasswordResetResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidatePasswordResetToken(req.Token); err != nil {
+		return nil, err
+	}
+
+	tokenHash := token.HashOpaqueToken(req.Token)
+
+	// Находим пользователя токена, не используя его, чтобы проверить новый пароль
+	// и не сжечь токен, если пароль не подойдет
+	user, err := s.getPasswordResetTokenUser(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPasswordPolicy(newPasswordField, req.NewPassword, user.Email, user.Username); err != nil {
+		return nil, err
+	}
+	if err := s.checkPasswordHistory(ctx, user, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	// Хешируем новый пароль до использования токена, чтобы не сжечь его при ошибке
+	passwordHash, err := s.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		s.logger.Error("failed to hash password", "error", err)
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Используем токен
+	resetUser, err := s.passwordResetRepo.ConsumePasswordResetToken(ctx, tokenHash, time.Now())
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidPasswordResetToken) {
+			return nil, apperrors.ErrInvalidPasswordResetToken
+		}
+		s.logger.Error("failed to consume password reset token", "error", err)
+		return nil, fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	userUUID := resetUser.UserUUID
+
+	err = s.userRepo.UpdatePassword(ctx, resetUser.TenantID, userUUID, passwordHash, s.cfg.PasswordHistorySize)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidPasswordResetToken
+		}
+		s.logger.Error("failed to update password", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Завершаем все сессии: пароль мог быть сброшен из-за компрометации
+	revoked, err := s.sessionRepo.DeleteUserSessions(ctx, userUUID)
+	if err != nil {
+		s.logger.Error("failed to delete user sessions", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	revokedFamilies, err := s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, userUUID)
+	if err != nil {
+		s.logger.Error("failed to revoke refresh tokens", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	s.logger.Info("password reset successfully",
+		"user_uuid", userUUID,
+		"revoked_sessions", revoked,
+		"revoked_token_families", revokedFamilies,
+	)
+
+	return &ConfirmPasswordResetResponse{}, nil
+}
+
+// sendPasswordResetToken выдает пользователю новый токен сброса пароля и доставляет его
+func (s *authService) sendPasswordResetToken(ctx context.Context, user *models.User) error {
+	resetToken, err := token.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stored := &models.PasswordResetToken{
+		TokenHash: token.HashOpaqueToken(resetToken),
+		UserUUID:  user.UUID,
+		TenantID:  user.TenantID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.cfg.PasswordResetTTL),
+	}
+	if err := s.passwordResetRepo.CreatePasswordResetToken(ctx, stored); err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	err = s.notifier.Notify(ctx, notifier.Message{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"Use this token to set a new password: %s\nIt expires at %s. If you did not request a password reset, ignore this message.",
+			resetToken,
+			stored.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver password reset token: %w", err)
+	}
+
+	s.logger.Info("password reset token sent", "user_uuid", user.UUID)
+
+	return nil
+}
+
+// checkPasswordPolicy проверяет новый пароль политикой паролей и возвращает
+// все нарушения разом как нарушения поля field. personalInfo - email и имя пользователя
+func (s *authService) checkPasswordPolicy(field, plainPassword string, personalInfo ...string) error {
+	descriptions := s.passwordPolicy.Check(plainPassword, personalInfo...)
+	if len(descriptions) == 0 {
+		return nil
+	}
+
+	violations := make([]apperrors.FieldViolation, 0, len(descriptions))
+	for _, description := range descriptions {
+		violations = append(violations, apperrors.FieldViolation{
+			Field:       field,
+			Description: description,
+		})
+	}
+
+	return apperrors.NewValidationError(violations...)
+}
+
+// checkPasswordHistory возвращает ErrPasswordReused, если новый пароль совпадает с текущим
+// или с одним из PasswordHistorySize прежних паролей пользователя
+func (s *authService) checkPasswordHistory(ctx context.Context, user *models.User, plainPassword string) error {
+	history, err := s.userRepo.GetPasswordHistory(ctx, user.TenantID, user.UUID, s.cfg.PasswordHistorySize)
+	if err != nil {
+		s.logger.Error("failed to get password history", "error", err, "user_uuid", user.UUID)
+		return fmt.Errorf("failed to get password history: %w", err)
+	}
+
+	for _, passwordHash := range append([]string{user.PasswordHash}, history...) {
+		reused, err := s.passwordHasher.Verify(plainPassword, passwordHash)
+		if err != nil {
+			// Хеш неизвестного формата не может совпасть с новым паролем
+			s.logger.Warn("failed to verify password against history", "error", err, "user_uuid", user.UUID)
+			continue
+		}
+		if reused {
+			return apperrors.ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// getPasswordResetTokenUser возвращает пользователя действующего токена сброса пароля, не используя токен
+func (s *authService) getPasswordResetTokenUser(ctx context.Context, tokenHash string) (*models.User, error) {
+	resetUser, err := s.passwordResetRepo.GetPasswordResetTokenUser(ctx, tokenHash, time.Now())
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidPasswordResetToken) {
+			return nil, apperrors.ErrInvalidPasswordResetToken
+		}
+		s.logger.Error("failed to get password reset token", "error", err)
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	user, err := s.userRepo.GetUserByUUID(ctx, resetUser.TenantID, resetUser.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidPasswordResetToken
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", resetUser.UserUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// verifyLoginPassword проверяет пароль при входе. Если пользователь не найден,
+// пароль сравнивается с фиктивным хешем, чтобы вход занимал столько же времени
+func (s *authService) verifyLoginPassword(user *models.User, plainPassword string) (bool, error) {
+	if user == nil {
+		dummyHash, err := s.dummyPasswordHash()
+		if err != nil {
+			s.logger.Error("failed to generate dummy password hash", "error", err)
+			return false, fmt.Errorf("failed to generate dummy password hash: %w", err)
+		}
+		_, _ = s.passwordHasher.Verify(plainPassword, dummyHash)
+		return false, nil
+	}
+
+	valid, err := s.passwordHasher.Verify(plainPassword, user.PasswordHash)
+	if err != nil {
+		s.logger.Error("failed to verify password", "error", err, "user_uuid", user.UUID)
+		return false, fmt.Errorf("failed to verify password: %w", err)
+	}
+
+	return valid, nil
+}
+
+// rehashPasswordIfNeeded пересчитывает хеш пароля после успешного входа, если он получен
+// не предпочтительным алгоритмом или с устаревшими параметрами. Ошибки только логируются,
+// вход от них не зависит, а хеш обновится при следующем входе
+func (s *authService) rehashPasswordIfNeeded(ctx context.Context, user *models.User, plainPassword string) {
+	if !s.passwordHasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(plainPassword)
+	if err != nil {
+		s.logger.Error("failed to rehash password", "error", err, "user_uuid", user.UUID)
+		return
+	}
+
+	updated, err := s.userRepo.RehashPassword(ctx, user.TenantID, user.UUID, user.PasswordHash, passwordHash)
+	if err != nil {
+		s.logger.Error("failed to update rehashed password", "error", err, "user_uuid", user.UUID)
+		return
+	}
+	if !updated {
+		return
+	}
+
+	user.PasswordHash = passwordHash
+	s.logger.Info("password rehashed", "user_uuid", user.UUID)
+}
+
+// newDummyPasswordHash возвращает функцию, которая один раз хеширует случайный пароль
+// предпочтительным алгоритмом. Пользователи со старыми хешами отличаются по времени входа
+// от несуществующих, пока не войдут и не получат хеш с актуальными параметрами
+func newDummyPasswordHash(hasher password.Hasher) func() (string, error) {
+	return sync.OnceValues(func() (string, error) {
+		return hasher.Hash(uuid.NewString())
+	})
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/password"
+)
+
+// passwordResetFixture сервис с одним пользователем, которому можно отправить токен сброса пароля
+type passwordResetFixture struct {
+	s           *authService
+	user        *models.User
+	notifier    *recordingNotifier
+	sessionRepo *stubSessionRepository
+	refreshRepo *memoryRefreshTokenRepository
+}
+
+func newPasswordResetFixture(t *testing.T) *passwordResetFixture {
+	t.Helper()
+
+	organization := newTestOrganization("acme")
+	hasher := newTestPasswordHasher(t)
+	passwordHash, err := hasher.Hash("old-password")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	f := &passwordResetFixture{
+		user: &models.User{
+			UUID:         uuid.New(),
+			TenantID:     organization.ID,
+			Email:        "user@acme.example.com",
+			PasswordHash: passwordHash,
+		},
+		notifier:    &recordingNotifier{},
+		sessionRepo: &stubSessionRepository{},
+		refreshRepo: newMemoryRefreshTokenRepository(),
+	}
+
+	f.s = newTestAuthService()
+	f.s.userRepo = &stubUserRepository{users: []*models.User{f.user}}
+	f.s.organizationRepo = &stubOrganizationRepository{organizations: []*models.Organization{organization}}
+	f.s.passwordResetRepo = newMemoryPasswordResetRepository()
+	f.s.sessionRepo = f.sessionRepo
+	f.s.refreshTokenRepo = f.refreshRepo
+	f.s.notifier = f.notifier
+	f.s.passwordHasher = hasher
+	f.s.passwordPolicy = password.NewPolicy(password.PolicyConfig{MinLength: 8}, nil)
+	f.s.cfg.PasswordResetTTL = time.Hour
+	f.s.cfg.PasswordHistorySize = 3
+
+	return f
+}
+
+// sendToken отправляет пользователю токен сброса пароля и возвращает его из письма
+func (f *passwordResetFixture) sendToken(t *testing.T) string {
+	t.Helper()
+
+	if err := f.s.sendPasswordResetToken(context.Background(), f.user); err != nil {
+		t.Fatalf("sendPasswordResetToken() unexpected error: %v", err)
+	}
+
+	msg := f.notifier.messages[len(f.notifier.messages)-1]
+	if msg.To != f.user.Email {
+		t.Fatalf("password reset token sent to %q, want %q", msg.To, f.user.Email)
+	}
+	_, rest, _ := strings.Cut(msg.Body, "set a new password: ")
+	resetToken, _, _ := strings.Cut(rest, "\n")
+	if resetToken == "" {
+		t.Fatalf("password reset message has no token: %q", msg.Body)
+	}
+	return resetToken
+}
+
+func (f *passwordResetFixture) confirm(resetToken, newPassword string) error {
+	_, err := f.s.ConfirmPasswordReset(context.Background(), ConfirmPasswordResetRequest{
+		Token:       resetToken,
+		NewPassword: newPassword,
+	})
+	return err
+}
+
+func TestConfirmPasswordReset(t *testing.T) {
+	f := newPasswordResetFixture(t)
+	resetToken := f.sendToken(t)
+	f.s.tokenManager = &stubTokenManager{}
+	if _, err := f.s.issueTokens(context.Background(), f.user, time.Now()); err != nil {
+		t.Fatalf("issueTokens() unexpected error: %v", err)
+	}
+
+	if err := f.confirm(resetToken, "new-password"); err != nil {
+		t.Fatalf("ConfirmPasswordReset() unexpected error: %v", err)
+	}
+
+	valid, err := f.s.passwordHasher.Verify("new-password", f.user.PasswordHash)
+	if err != nil || !valid {
+		t.Errorf("new password does not match the stored hash: valid = %v, error = %v", valid, err)
+	}
+	if !slices.Equal(f.sessionRepo.deletedUsers, []uuid.UUID{f.user.UUID}) {
+		t.Errorf("sessions deleted for %v, want %v", f.sessionRepo.deletedUsers, []uuid.UUID{f.user.UUID})
+	}
+	if len(f.refreshRepo.families) != 0 {
+		t.Errorf("%d refresh token families left, want all revoked", len(f.refreshRepo.families))
+	}
+
+	// Токен одноразовый: повторное использование отклоняется даже с другим паролем
+	if err := f.confirm(resetToken, "another-password"); !errors.Is(err, apperrors.ErrInvalidPasswordResetToken) {
+		t.Errorf("second ConfirmPasswordReset() error = %v, want %v", err, apperrors.ErrInvalidPasswordResetToken)
+	}
+}
+
+func TestConfirmPasswordResetRejected(t *testing.T) {
+	sendToken := func(t *testing.T, f *passwordResetFixture) string { return f.sendToken(t) }
+
+	tests := []struct {
+		name string
+		// prepare возвращает предъявляемый токен
+		prepare     func(t *testing.T, f *passwordResetFixture) string
+		newPassword string
+		wantErr     error
+		// wantTokenUsable токен должен остаться действующим после ошибки
+		wantTokenUsable bool
+	}{
+		{
+			name:        "неизвестный токен",
+			prepare:     func(*testing.T, *passwordResetFixture) string { return "unknown" },
+			newPassword: "new-password",
+			wantErr:     apperrors.ErrInvalidPasswordResetToken,
+		},
+		{
+			name: "истекший токен",
+			prepare: func(t *testing.T, f *passwordResetFixture) string {
+				f.s.cfg.PasswordResetTTL = -time.Minute
+				return f.sendToken(t)
+			},
+			newPassword: "new-password",
+			wantErr:     apperrors.ErrInvalidPasswordResetToken,
+		},
+		{
+			name: "токен удаленного пользователя",
+			prepare: func(t *testing.T, f *passwordResetFixture) string {
+				resetToken := f.sendToken(t)
+				f.s.userRepo = &stubUserRepository{}
+				return resetToken
+			},
+			newPassword: "new-password",
+			wantErr:     apperrors.ErrInvalidPasswordResetToken,
+		},
+		{
+			name:            "пароль не проходит политику",
+			prepare:         sendToken,
+			newPassword:     "short",
+			wantErr:         apperrors.ErrInvalidInput,
+			wantTokenUsable: true,
+		},
+		{
+			name:            "пароль совпадает с текущим",
+			prepare:         sendToken,
+			newPassword:     "old-password",
+			wantErr:         apperrors.ErrPasswordReused,
+			wantTokenUsable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPasswordResetFixture(t)
+			resetToken := tt.prepare(t, f)
+
+			if err := f.confirm(resetToken, tt.newPassword); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConfirmPasswordReset() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(f.sessionRepo.deletedUsers) != 0 {
+				t.Errorf("sessions deleted for %v, want none", f.sessionRepo.deletedUsers)
+			}
+
+			err := f.confirm(resetToken, "new-password")
+			if tt.wantTokenUsable && err != nil {
+				t.Errorf("ConfirmPasswordReset() after rejection error = %v, want the token still usable", err)
+			}
+			if !tt.wantTokenUsable && !errors.Is(err, apperrors.ErrInvalidPasswordResetToken) {
+				t.Errorf("ConfirmPasswordReset() after rejection error = %v, want %v", err, apperrors.ErrInvalidPasswordResetToken)
+			}
+		})
+	}
+}
+
+// TestRequestPasswordResetUnknownEmail проверяет, что ответ для незарегистрированного email
+// не отличается от ответа для существующего и письмо не отправляется
+func TestRequestPasswordResetUnknownEmail(t *testing.T) {
+	f := newPasswordResetFixture(t)
+
+	for _, tenant := range []string{"acme", "unknown"} {
+		resp, err := f.s.RequestPasswordReset(context.Background(), RequestPasswordResetRequest{
+			Tenant: tenant,
+			Email:  "missing@acme.example.com",
+		})
+		if err != nil {
+			t.Fatalf("RequestPasswordReset(%s) unexpected error: %v", tenant, err)
+		}
+		if resp == nil {
+			t.Fatalf("RequestPasswordReset(%s) = nil, want an empty response", tenant)
+		}
+	}
+
+	if len(f.notifier.messages) != 0 {
+		t.Errorf("%d messages sent, want none", len(f.notifier.messages))
+	}
+}
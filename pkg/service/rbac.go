@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// CreateRoleRequest запрос на создание роли
+type CreateRoleRequest struct {
+	// Tenant slug организации, в которой создается роль
+	Tenant      string
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// CreateRoleResponse ответ на создание роли
+type CreateRoleResponse struct {
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+}
+
+// GrantRoleRequest запрос на выдачу роли пользователю
+type GrantRoleRequest struct {
+	// Tenant slug организации пользователя и роли
+	Tenant   string
+	UserUUID string
+	Role     string
+	// Resource ресурс, на который выдается роль. Пустой - роль действует глобально
+	Resource string
+}
+
+// GrantRoleResponse ответ на выдачу роли пользователю
+type GrantRoleResponse struct {
+	// Granted роли у пользователя еще не было
+	Granted bool
+}
+
+// RevokeRoleRequest запрос на отзыв роли у пользователя
+type RevokeRoleRequest struct {
+	// Tenant slug организации пользователя и роли
+	Tenant   string
+	UserUUID string
+	Role     string
+	// Resource ресурс, на который была выдана роль. Пустой - глобально выданная роль
+	Resource string
+}
+
+// RevokeRoleResponse ответ на отзыв роли у пользователя
+type RevokeRoleResponse struct {
+	// Revoked роль у пользователя была
+	Revoked bool
+}
+
+// ListUserPermissionsRequest запрос ролей и разрешений пользователя
+type ListUserPermissionsRequest struct {
+	// Tenant slug организации пользователя
+	Tenant   string
+	UserUUID string
+}
+
+// ListUserPermissionsResponse роли пользователя и разрешения, которые они дают
+type ListUserPermissionsResponse struct {
+	// Roles и Permissions глобально выданные роли и их разрешения
+	Roles       []string
+	Permissions []string
+	// Grants все выданные роли, в том числе на конкретные ресурсы
+	Grants []models.RoleGrant
+}
+
+// CreateRole создает роль организации с набором разрешений. Имя роли уникально в пределах организации
+func (s *authService) CreateRole(ctx context.Context, req CreateRoleRequest) (*CreateRoleResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateRoleName(req.Name); err != nil {
+		return nil, err
+	}
+	for _, permission := range req.Permissions {
+		if err := validator.ValidatePermission(permission); err != nil {
+			return nil, err
+		}
+	}
+
+	organization, err := s.resolveTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := slices.Clone(req.Permissions)
+	slices.Sort(permissions)
+
+	role := &models.Role{
+		TenantID:    organization.ID,
+		Name:        req.Name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: slices.Compact(permissions),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.roleRepo.CreateRole(ctx, role); err != nil {
+		if errors.Is(err, apperrors.ErrRoleAlreadyExists) {
+			return nil, apperrors.ErrRoleAlreadyExists
+		}
+		s.logger.Error("failed to create role", "error", err, "role", req.Name)
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	s.logger.Info("role created", "tenant", organization.Slug, "role", role.Name, "permissions", role.Permissions)
+
+	return &CreateRoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		CreatedAt:   role.CreatedAt,
+	}, nil
+}
+
+// GrantRole выдает роль пользователю в организации, на всю организацию или на один ресурс
+func (s *authService) GrantRole(ctx context.Context, req GrantRoleRequest) (*GrantRoleResponse, error) {
+	user, err := s.validateRoleAssignment(ctx, req.Tenant, req.UserUUID, req.Role, req.Resource)
+	if err != nil {
+		return nil, err
+	}
+	userUUID := user.UserUUID
+
+	granted, err := s.roleRepo.GrantRole(ctx, user.TenantID, userUUID, req.Role, req.Resource)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRoleNotFound) {
+			return nil, apperrors.ErrRoleNotFound
+		}
+		s.logger.Error("failed to grant role", "error", err, "user_uuid", userUUID, "role", req.Role)
+		return nil, fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	if err := s.invalidateUserAccess(ctx, userUUID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("role granted",
+		"user_uuid", userUUID,
+		"role", req.Role,
+		"resource", req.Resource,
+		"granted", granted,
+	)
+
+	return &GrantRoleResponse{
+		Granted: granted,
+	}, nil
+}
+
+// RevokeRole отзывает роль у пользователя. Кеш разрешений пользователя сбрасывается
+func (s *authService) RevokeRole(ctx context.Context, req RevokeRoleRequest) (*RevokeRoleResponse, error) {
+	user, err := s.validateRoleAssignment(ctx, req.Tenant, req.UserUUID, req.Role, req.Resource)
+	if err != nil {
+		return nil, err
+	}
+	userUUID := user.UserUUID
+
+	revoked, err := s.roleRepo.RevokeRole(ctx, user.TenantID, userUUID, req.Role, req.Resource)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRoleNotFound) {
+			return nil, apperrors.ErrRoleNotFound
+		}
+		s.logger.Error("failed to revoke role", "error", err, "user_uuid", userUUID, "role", req.Role)
+		return nil, fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	// Кеш сбрасываем и при revoked = false: повтор запроса после ошибки инвалидации должен ее довести
+	if err := s.invalidateUserAccess(ctx, userUUID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("role revoked",
+		"user_uuid", userUUID,
+		"role", req.Role,
+		"resource", req.Resource,
+		"revoked", revoked,
+	)
+
+	return &RevokeRoleResponse{
+		Revoked: revoked,
+	}, nil
+}
+
+// ListUserPermissions возвращает роли пользователя и все разрешения, которые они дают
+func (s *authService) ListUserPermissions(
+	ctx context.Context,
+	req ListUserPermissionsRequest,
+) (*ListUserPermissionsResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateUserUUID(req.UserUUID); err != nil {
+		return nil, err
+	}
+	userUUID := uuid.MustParse(req.UserUUID)
+
+	organization, err := s.resolveTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getUser(ctx, organization.ID, userUUID); err != nil {
+		return nil, err
+	}
+
+	access, err := s.getUserAccess(ctx, organization.ID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUserPermissionsResponse{
+		Roles:       access.Roles,
+		Permissions: access.Permissions,
+		Grants:      access.Grants,
+	}, nil
+}
+
+// validateRoleAssignment проверяет запрос на выдачу или отзыв роли и существование пользователя в организации
+func (s *authService) validateRoleAssignment(
+	ctx context.Context,
+	tenant string,
+	rawUserUUID string,
+	roleName string,
+	resource string,
+) (models.UserRef, error) {
+	if err := validator.ValidateUserUUID(rawUserUUID); err != nil {
+		return models.UserRef{}, err
+	}
+	if err := validator.ValidateRoleName(roleName); err != nil {
+		return models.UserRef{}, err
+	}
+	if err := validator.ValidateResource(resource); err != nil {
+		return models.UserRef{}, err
+	}
+	userUUID := uuid.MustParse(rawUserUUID)
+
+	organization, err := s.resolveTenant(ctx, tenant)
+	if err != nil {
+		return models.UserRef{}, err
+	}
+
+	if _, err := s.getUser(ctx, organization.ID, userUUID); err != nil {
+		return models.UserRef{}, err
+	}
+
+	return models.UserRef{
+		TenantID: organization.ID,
+		UserUUID: userUUID,
+	}, nil
+}
+
+// getUser возвращает пользователя организации по UUID
+func (s *authService) getUser(ctx context.Context, tenantID, userUUID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetUserByUUID(ctx, tenantID, userUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// getUserAccess возвращает роли и разрешения пользователя в организации, по возможности из кеша.
+// Недоступность кеша не мешает ответу, права в этом случае читаются из базы
+func (s *authService) getUserAccess(ctx context.Context, organizationID, userUUID uuid.UUID) (*models.UserAccess, error) {
+	cached, generation, cacheErr := s.accessCacheRepo.GetUserAccess(ctx, organizationID, userUUID)
+	if cacheErr != nil {
+		s.logger.Warn("failed to get cached user access", "error", cacheErr, "user_uuid", userUUID)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	access, err := s.roleRepo.GetUserAccess(ctx, organizationID, userUUID)
+	if err != nil {
+		s.logger.Error("failed to get user access", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to get user access: %w", err)
+	}
+
+	if cacheErr == nil {
+		if err := s.accessCacheRepo.SetUserAccess(ctx, organizationID, userUUID, generation, access, s.cfg.PermissionCacheTTL); err != nil {
+			s.logger.Warn("failed to cache user access", "error", err, "user_uuid", userUUID)
+		}
+	}
+
+	return access, nil
+}
+
+// invalidateUserAccess сбрасывает кеш прав пользователя после изменения его ролей
+func (s *authService) invalidateUserAccess(ctx context.Context, userUUID uuid.UUID) error {
+	if err := s.accessCacheRepo.InvalidateUserAccess(ctx, userUUID); err != nil {
+		s.logger.Error("failed to invalidate user access", "error", err, "user_uuid", userUUID)
+		return fmt.Errorf("failed to invalidate user access: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+func TestRoleAssignmentTenantScope(t *testing.T) {
+	acme := newTestOrganization("acme")
+	globex := newTestOrganization("globex")
+	user := &models.User{UUID: uuid.New(), TenantID: acme.ID, Email: "user@acme.example.com"}
+
+	tests := []struct {
+		name string
+		// run выполняет операцию от имени администратора организации tenant
+		run     func(s *authService, tenant string) error
+		tenant  string
+		wantErr error
+		// wantTenants организации, в которых должна быть выдана роль
+		wantTenants []uuid.UUID
+	}{
+		{
+			name: "выдача роли в организации пользователя",
+			run: func(s *authService, tenant string) error {
+				_, err := s.GrantRole(context.Background(), GrantRoleRequest{
+					Tenant: tenant, UserUUID: user.UUID.String(), Role: "editor",
+				})
+				return err
+			},
+			tenant:      acme.Slug,
+			wantTenants: []uuid.UUID{acme.ID},
+		},
+		{
+			name: "выдача роли пользователю другой организации",
+			run: func(s *authService, tenant string) error {
+				_, err := s.GrantRole(context.Background(), GrantRoleRequest{
+					Tenant: tenant, UserUUID: user.UUID.String(), Role: "editor",
+				})
+				return err
+			},
+			tenant:  globex.Slug,
+			wantErr: apperrors.ErrUserNotFound,
+		},
+		{
+			name: "выдача роли в неизвестной организации",
+			run: func(s *authService, tenant string) error {
+				_, err := s.GrantRole(context.Background(), GrantRoleRequest{
+					Tenant: tenant, UserUUID: user.UUID.String(), Role: "editor",
+				})
+				return err
+			},
+			tenant:  "initech",
+			wantErr: apperrors.ErrOrganizationNotFound,
+		},
+		{
+			name: "разрешения пользователя другой организации",
+			run: func(s *authService, tenant string) error {
+				_, err := s.ListUserPermissions(context.Background(), ListUserPermissionsRequest{
+					Tenant: tenant, UserUUID: user.UUID.String(),
+				})
+				return err
+			},
+			tenant:  globex.Slug,
+			wantErr: apperrors.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := &stubRoleRepository{}
+			s := newTestAuthService()
+			s.userRepo = &stubUserRepository{users: []*models.User{user}}
+			s.organizationRepo = &stubOrganizationRepository{organizations: []*models.Organization{acme, globex}}
+			s.roleRepo = roleRepo
+
+			err := tt.run(s, tt.tenant)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(roleRepo.grantedTenants, tt.wantTenants) {
+				t.Errorf("roles granted in %v, want %v", roleRepo.grantedTenants, tt.wantTenants)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+const (
+	// serviceAccountsManagePermission разрешение на управление всеми сервисными аккаунтами организации.
+	// Без него пользователь управляет только аккаунтами, владельцем которых является
+	serviceAccountsManagePermission = "service_accounts:manage"
+	// maxServiceAccountDescriptionLength максимальная длина описания сервисного аккаунта
+	maxServiceAccountDescriptionLength = 1000
+)
+
+// CreateServiceAccountRequest запрос на создание сервисного аккаунта в активной организации сессии
+type CreateServiceAccountRequest struct {
+	SessionUUID string
+	Name        string
+	Description string
+	// OrganizationOwned аккаунтом владеет организация, а не пользователь сессии.
+	// Требует разрешения service_accounts:manage
+	OrganizationOwned bool
+}
+
+// CreateServiceAccountResponse созданный сервисный аккаунт
+type CreateServiceAccountResponse struct {
+	ServiceAccount *models.ServiceAccount
+}
+
+// ListServiceAccountsRequest запрос сервисных аккаунтов активной организации сессии
+type ListServiceAccountsRequest struct {
+	SessionUUID string
+}
+
+// ListServiceAccountsResponse сервисные аккаунты, которыми может управлять пользователь сессии
+type ListServiceAccountsResponse struct {
+	ServiceAccounts []*models.ServiceAccount
+}
+
+// DeleteServiceAccountRequest запрос на удаление сервисного аккаунта
+type DeleteServiceAccountRequest struct {
+	SessionUUID      string
+	ServiceAccountID string
+}
+
+// DeleteServiceAccountResponse ответ на удаление сервисного аккаунта
+type DeleteServiceAccountResponse struct {
+	// Deleted аккаунт существовал и удален вместе с его API ключами
+	Deleted bool
+}
+
+// CreateServiceAccount создает сервисный аккаунт. У аккаунта нет email и пароля,
+// он аутентифицируется только API ключами
+func (s *authService) CreateServiceAccount(
+	ctx context.Context,
+	req CreateServiceAccountRequest,
+) (*CreateServiceAccountResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateServiceAccountName(req.Name); err != nil {
+		return nil, err
+	}
+	if len(req.Description) > maxServiceAccountDescriptionLength {
+		return nil, fmt.Errorf("%w: description must be at most %d characters",
+			apperrors.ErrInvalidInput, maxServiceAccountDescriptionLength)
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+	// Создавать аккаунты может только участник организации
+	if _, _, err := s.activeMembership(ctx, session); err != nil {
+		return nil, err
+	}
+
+	serviceAccount := &models.ServiceAccount{
+		ID:          uuid.New(),
+		TenantID:    session.ActiveOrganizationID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		CreatedAt:   time.Now(),
+	}
+	if req.OrganizationOwned {
+		canManage, err := s.canManageServiceAccounts(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		if !canManage {
+			return nil, apperrors.ErrPermissionDenied
+		}
+	} else {
+		serviceAccount.OwnerUserUUID = &session.UserUUID
+	}
+
+	if err := s.serviceAccountRepo.CreateServiceAccount(ctx, serviceAccount); err != nil {
+		if errors.Is(err, apperrors.ErrServiceAccountAlreadyExists) {
+			return nil, apperrors.ErrServiceAccountAlreadyExists
+		}
+		s.logger.Error("failed to create service account", "error", err, "tenant_id", serviceAccount.TenantID)
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	s.logger.Info("service account created",
+		"tenant_id", serviceAccount.TenantID,
+		"service_account_id", serviceAccount.ID,
+		"created_by", session.UserUUID,
+		"organization_owned", req.OrganizationOwned,
+	)
+
+	return &CreateServiceAccountResponse{
+		ServiceAccount: serviceAccount,
+	}, nil
+}
+
+// ListServiceAccounts возвращает сервисные аккаунты активной организации сессии.
+// Без разрешения service_accounts:manage возвращаются только аккаунты пользователя сессии
+func (s *authService) ListServiceAccounts(
+	ctx context.Context,
+	req ListServiceAccountsRequest,
+) (*ListServiceAccountsResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := s.activeMembership(ctx, session); err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.canManageServiceAccounts(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts, err := s.serviceAccountRepo.ListServiceAccounts(ctx, session.ActiveOrganizationID)
+	if err != nil {
+		s.logger.Error("failed to list service accounts", "error", err, "tenant_id", session.ActiveOrganizationID)
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	if !canManage {
+		owned := serviceAccounts[:0]
+		for _, serviceAccount := range serviceAccounts {
+			if isServiceAccountOwner(serviceAccount, session.UserUUID) {
+				owned = append(owned, serviceAccount)
+			}
+		}
+		serviceAccounts = owned
+	}
+
+	return &ListServiceAccountsResponse{
+		ServiceAccounts: serviceAccounts,
+	}, nil
+}
+
+// DeleteServiceAccount удаляет сервисный аккаунт вместе с его API ключами
+func (s *authService) DeleteServiceAccount(
+	ctx context.Context,
+	req DeleteServiceAccountRequest,
+) (*DeleteServiceAccountResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateSessionUUID(req.SessionUUID); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateServiceAccountID(req.ServiceAccountID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.getSession(ctx, req.SessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount, err := s.authorizeServiceAccount(ctx, session, uuid.MustParse(req.ServiceAccountID))
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.serviceAccountRepo.DeleteServiceAccount(ctx, serviceAccount.TenantID, serviceAccount.ID)
+	if err != nil {
+		s.logger.Error("failed to delete service account", "error", err, "service_account_id", serviceAccount.ID)
+		return nil, fmt.Errorf("failed to delete service account: %w", err)
+	}
+
+	s.logger.Info("service account deleted",
+		"tenant_id", serviceAccount.TenantID,
+		"service_account_id", serviceAccount.ID,
+		"deleted_by", session.UserUUID,
+		"deleted", deleted,
+	)
+
+	return &DeleteServiceAccountResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+// whoAmIServiceAccount возвращает информацию о сервисном аккаунте.
+// Пользовательских полей у аккаунта нет, организацией считается организация аккаунта
+func (s *authService) whoAmIServiceAccount(
+	ctx context.Context,
+	serviceAccount *models.ServiceAccount,
+) (*WhoAmIResponse, error) {
+	organization, err := s.getOrganization(ctx, serviceAccount.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WhoAmIResponse{
+		PrincipalType:  models.PrincipalTypeServiceAccount,
+		TenantID:       serviceAccount.TenantID,
+		CreatedAt:      serviceAccount.CreatedAt,
+		UpdatedAt:      serviceAccount.CreatedAt,
+		ServiceAccount: serviceAccount,
+		Organization:   *organization,
+	}, nil
+}
+
+// authorizeServiceAccount возвращает сервисный аккаунт активной организации сессии,
+// если пользователь сессии его владелец или может управлять всеми аккаунтами организации
+func (s *authService) authorizeServiceAccount(
+	ctx context.Context,
+	session *models.Session,
+	serviceAccountID uuid.UUID,
+) (*models.ServiceAccount, error) {
+	if _, _, err := s.activeMembership(ctx, session); err != nil {
+		return nil, err
+	}
+
+	serviceAccount, err := s.serviceAccountRepo.GetServiceAccount(ctx, session.ActiveOrganizationID, serviceAccountID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrServiceAccountNotFound) {
+			return nil, apperrors.ErrServiceAccountNotFound
+		}
+		s.logger.Error("failed to get service account", "error", err, "service_account_id", serviceAccountID)
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	if isServiceAccountOwner(serviceAccount, session.UserUUID) {
+		return serviceAccount, nil
+	}
+
+	canManage, err := s.canManageServiceAccounts(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, apperrors.ErrPermissionDenied
+	}
+
+	return serviceAccount, nil
+}
+
+// canManageServiceAccounts проверяет, может ли пользователь сессии управлять всеми
+// сервисными аккаунтами активной организации
+func (s *authService) canManageServiceAccounts(ctx context.Context, session *models.Session) (bool, error) {
+	access, err := s.getUserAccess(ctx, session.ActiveOrganizationID, session.UserUUID)
+	if err != nil {
+		return false, err
+	}
+
+	_, found := access.FindGrant(serviceAccountsManagePermission, "")
+	return found, nil
+}
+
+// isServiceAccountOwner проверяет, что пользователь владеет сервисным аккаунтом
+func isServiceAccountOwner(serviceAccount *models.ServiceAccount, userUUID uuid.UUID) bool {
+	return serviceAccount.OwnerUserUUID != nil && *serviceAccount.OwnerUserUUID == userUUID
+}
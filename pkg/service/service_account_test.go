@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+func TestAuthorizeServiceAccount(t *testing.T) {
+	acme := newTestOrganization("acme")
+	globex := newTestOrganization("globex")
+	owner := uuid.New()
+	member := uuid.New()
+
+	owned := &models.ServiceAccount{ID: uuid.New(), TenantID: acme.ID, Name: "ci", OwnerUserUUID: &owner}
+	organizationOwned := &models.ServiceAccount{ID: uuid.New(), TenantID: acme.ID, Name: "billing"}
+	foreign := &models.ServiceAccount{ID: uuid.New(), TenantID: globex.ID, Name: "ci", OwnerUserUUID: &member}
+
+	manageGrant := models.RoleGrant{Role: "admin", Permissions: []string{serviceAccountsManagePermission}}
+
+	tests := []struct {
+		name           string
+		userUUID       uuid.UUID
+		grants         []models.RoleGrant
+		serviceAccount uuid.UUID
+		wantErr        error
+	}{
+		{
+			name:           "владелец аккаунта",
+			userUUID:       owner,
+			serviceAccount: owned.ID,
+		},
+		{
+			name:           "чужой аккаунт без права управления",
+			userUUID:       member,
+			serviceAccount: owned.ID,
+			wantErr:        apperrors.ErrPermissionDenied,
+		},
+		{
+			name:           "аккаунт организации без права управления",
+			userUUID:       member,
+			serviceAccount: organizationOwned.ID,
+			wantErr:        apperrors.ErrPermissionDenied,
+		},
+		{
+			name:           "чужой аккаунт с правом управления",
+			userUUID:       member,
+			grants:         []models.RoleGrant{manageGrant},
+			serviceAccount: owned.ID,
+		},
+		{
+			name:     "право управления только на ресурс",
+			userUUID: member,
+			grants: []models.RoleGrant{{
+				Role:        "admin",
+				Resource:    owned.ID.String(),
+				Permissions: []string{serviceAccountsManagePermission},
+			}},
+			serviceAccount: owned.ID,
+			wantErr:        apperrors.ErrPermissionDenied,
+		},
+		{
+			name:           "аккаунт другой организации",
+			userUUID:       member,
+			grants:         []models.RoleGrant{manageGrant},
+			serviceAccount: foreign.ID,
+			wantErr:        apperrors.ErrServiceAccountNotFound,
+		},
+		{
+			name:           "несуществующий аккаунт",
+			userUUID:       owner,
+			serviceAccount: uuid.New(),
+			wantErr:        apperrors.ErrServiceAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAuthService()
+			s.organizationRepo = &stubOrganizationRepository{organizations: []*models.Organization{acme, globex}}
+			s.membershipRepo = &stubMembershipRepository{members: []*models.Member{
+				{OrganizationID: acme.ID, UserUUID: owner, TenantID: acme.ID},
+				{OrganizationID: acme.ID, UserUUID: member, TenantID: acme.ID},
+			}}
+			s.serviceAccountRepo = &stubServiceAccountRepository{
+				serviceAccounts: []*models.ServiceAccount{owned, organizationOwned, foreign},
+			}
+			s.roleRepo = &stubRoleRepository{access: map[uuid.UUID]*models.UserAccess{
+				acme.ID: {Grants: tt.grants},
+			}}
+			session := &models.Session{
+				UUID:                 uuid.NewString(),
+				UserUUID:             tt.userUUID,
+				TenantID:             acme.ID,
+				ActiveOrganizationID: acme.ID,
+			}
+
+			got, err := s.authorizeServiceAccount(context.Background(), session, tt.serviceAccount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("authorizeServiceAccount() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.ID != tt.serviceAccount {
+				t.Errorf("authorizeServiceAccount() = %s, want %s", got.ID, tt.serviceAccount)
+			}
+		})
+	}
+}
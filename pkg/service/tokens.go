@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+	"github.com/olezhek28/auth-service/pkg/validator"
+)
+
+// TokenPair пара access и refresh токенов
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// RefreshRequest запрос на обновление пары токенов
+type RefreshRequest struct {
+	RefreshToken string
+}
+
+// RefreshResponse ответ с новой парой токенов
+type RefreshResponse struct {
+	Tokens TokenPair
+}
+
+// Refresh обменивает refresh токен на новую пару токенов.
+// Старый refresh токен становится недействительным, а его повторное
+// использование отзывает все токены, выданные при том же входе.
+// Семейство токенов живет не дольше RefreshTokenMaxLifetime от входа
+func (s *authService) Refresh(ctx context.Context, req RefreshRequest) (*RefreshResponse, error) {
+	// Валидация входных данных
+	if err := validator.ValidateRefreshToken(req.RefreshToken); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	refreshToken, err := token.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("failed to generate refresh token", "error", err)
+		return nil, err
+	}
+
+	// Ротируем refresh токен
+	rotated := &models.RefreshToken{
+		TokenHash: token.HashOpaqueToken(refreshToken),
+		ExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+	}
+	err = s.refreshTokenRepo.RotateRefreshToken(
+		ctx,
+		token.HashOpaqueToken(req.RefreshToken),
+		uuid.Nil,
+		rotated,
+		now,
+		s.cfg.RefreshTokenMaxLifetime,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrRefreshTokenReused):
+			s.logger.Warn("refresh token reuse detected, token family revoked",
+				"user_uuid", rotated.UserUUID,
+				"family_id", rotated.FamilyID,
+			)
+			return nil, apperrors.ErrRefreshTokenReused
+		case errors.Is(err, apperrors.ErrInvalidRefreshToken):
+			return nil, apperrors.ErrInvalidRefreshToken
+		default:
+			s.logger.Error("failed to rotate refresh token", "error", err)
+			return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+		}
+	}
+
+	// Пользователь мог быть удален или исключен из организации уже после входа
+	if _, err := s.getRefreshTokenMember(ctx, rotated, rotated.TenantID); err != nil {
+		if errors.Is(err, apperrors.ErrMemberNotFound) {
+			return nil, apperrors.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	// Выпускаем новый access токен
+	accessToken, accessTokenExpiresAt, err := s.tokenManager.IssueAccessToken(rotated.UserUUID, rotated.TenantID, now)
+	if err != nil {
+		s.logger.Error("failed to issue access token", "error", err, "user_uuid", rotated.UserUUID)
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	return &RefreshResponse{
+		Tokens: TokenPair{
+			AccessToken:           accessToken,
+			AccessTokenExpiresAt:  accessTokenExpiresAt,
+			RefreshToken:          refreshToken,
+			RefreshTokenExpiresAt: rotated.ExpiresAt,
+		},
+	}, nil
+}
+
+// issueTokens выпускает access токен и первый refresh токен нового семейства
+func (s *authService) issueTokens(ctx context.Context, user *models.User, now time.Time) (*TokenPair, error) {
+	accessToken, accessTokenExpiresAt, err := s.tokenManager.IssueAccessToken(user.UUID, user.TenantID, now)
+	if err != nil {
+		s.logger.Error("failed to issue access token", "error", err, "user_uuid", user.UUID)
+		return nil, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	refreshToken, refreshTokenExpiresAt, err := s.createRefreshToken(ctx, user, uuid.Nil, nil, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}, nil
+}
+
+// createRefreshToken выпускает первый refresh токен нового семейства. clientID равен uuid.Nil
+// для токенов самого сервиса и задает OAuth клиента для токенов, выданных через /oauth2/token
+func (s *authService) createRefreshToken(
+	ctx context.Context,
+	user *models.User,
+	clientID uuid.UUID,
+	scopes []string,
+	now time.Time,
+) (string, time.Time, error) {
+	refreshToken, err := token.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("failed to generate refresh token", "error", err)
+		return "", time.Time{}, err
+	}
+
+	stored := &models.RefreshToken{
+		TokenHash:       token.HashOpaqueToken(refreshToken),
+		UserUUID:        user.UUID,
+		TenantID:        user.TenantID,
+		FamilyID:        uuid.New(),
+		FamilyCreatedAt: now,
+		ClientID:        clientID,
+		Scopes:          scopes,
+		ExpiresAt:       now.Add(s.cfg.RefreshTokenTTL),
+	}
+	if err := s.refreshTokenRepo.CreateRefreshToken(ctx, stored); err != nil {
+		s.logger.Error("failed to create refresh token", "error", err, "user_uuid", user.UUID)
+		return "", time.Time{}, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return refreshToken, stored.ExpiresAt, nil
+}
+
+// getRefreshTokenMember возвращает пользователя refresh токена, если он еще существует и состоит
+// в организации organizationID. Иначе возвращает apperrors.ErrMemberNotFound: ротация уже прошла,
+// но выдавать по ней новые токены нельзя
+func (s *authService) getRefreshTokenMember(
+	ctx context.Context,
+	refreshToken *models.RefreshToken,
+	organizationID uuid.UUID,
+) (*models.User, error) {
+	user, err := s.getUser(ctx, refreshToken.TenantID, refreshToken.UserUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrMemberNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.getMember(ctx, organizationID, refreshToken.UserUUID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// whoAmIByAccessToken возвращает информацию о пользователе, определенном по access токену входа в сервис
+func (s *authService) whoAmIByAccessToken(ctx context.Context, accessToken string) (*WhoAmIResponse, error) {
+	claims, err := s.parseFirstPartyAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.whoAmIByClaims(ctx, claims)
+}
+
+// parseFirstPartyAccessToken проверяет access токен, выданный при входе в сам сервис.
+// Токены OAuth клиентов ограничены областями действия, поэтому WhoAmI и проверка разрешений
+// их не принимают: иначе клиент с любым scope получал бы все права пользователя
+func (s *authService) parseFirstPartyAccessToken(accessToken string) (*token.Claims, error) {
+	claims, err := s.tokenManager.ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ClientID != "" || claims.Principal() != models.PrincipalTypeUser {
+		return nil, apperrors.ErrInvalidAccessToken
+	}
+
+	return claims, nil
+}
+
+// whoAmIByClaims возвращает информацию о пользователе, которому выдан проверенный access токен
+func (s *authService) whoAmIByClaims(ctx context.Context, claims *token.Claims) (*WhoAmIResponse, error) {
+	userUUID, err := claims.UserUUID()
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := claims.Tenant()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByUUID(ctx, tenantID, userUUID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			return nil, apperrors.ErrInvalidAccessToken
+		}
+		s.logger.Error("failed to get user", "error", err, "user_uuid", userUUID)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return s.whoAmIInHomeOrganization(ctx, user)
+}
+
+// whoAmIInHomeOrganization возвращает информацию о пользователе без сессии.
+// Без сессии нет активной организации, поэтому действует организация пользователя
+func (s *authService) whoAmIInHomeOrganization(ctx context.Context, user *models.User) (*WhoAmIResponse, error) {
+	organization, err := s.getOrganization(ctx, user.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.getMember(ctx, user.TenantID, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.getUserAccess(ctx, user.TenantID, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WhoAmIResponse{
+		PrincipalType:    models.PrincipalTypeUser,
+		UserUUID:         user.UUID,
+		TenantID:         user.TenantID,
+		Email:            user.Email,
+		Username:         user.Username,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		Organization:     *organization,
+		OrganizationRole: member.Role,
+		Roles:            access.Roles,
+		Permissions:      access.Permissions,
+	}, nil
+}
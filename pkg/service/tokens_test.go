@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+	"github.com/olezhek28/auth-service/pkg/token"
+)
+
+// refreshFixture сервис с одним пользователем, состоящим в своей организации
+type refreshFixture struct {
+	s            *authService
+	user         *models.User
+	users        *stubUserRepository
+	members      *stubMembershipRepository
+	refreshRepo  *memoryRefreshTokenRepository
+	organization *models.Organization
+}
+
+func newRefreshFixture() *refreshFixture {
+	organization := newTestOrganization("acme")
+	user := &models.User{UUID: uuid.New(), TenantID: organization.ID, Email: "user@acme.example.com"}
+
+	f := &refreshFixture{
+		s:            newTestAuthService(),
+		user:         user,
+		users:        &stubUserRepository{users: []*models.User{user}},
+		members:      &stubMembershipRepository{members: []*models.Member{{OrganizationID: organization.ID, UserUUID: user.UUID}}},
+		refreshRepo:  newMemoryRefreshTokenRepository(),
+		organization: organization,
+	}
+	f.s.userRepo = f.users
+	f.s.membershipRepo = f.members
+	f.s.refreshTokenRepo = f.refreshRepo
+	f.s.tokenManager = &stubTokenManager{}
+	f.s.cfg.RefreshTokenTTL = 24 * time.Hour
+	f.s.cfg.RefreshTokenMaxLifetime = 7 * 24 * time.Hour
+
+	return f
+}
+
+// login выдает пару токенов, как при входе
+func (f *refreshFixture) login(t *testing.T, now time.Time) string {
+	t.Helper()
+
+	tokens, err := f.s.issueTokens(context.Background(), f.user, now)
+	if err != nil {
+		t.Fatalf("issueTokens() unexpected error: %v", err)
+	}
+	return tokens.RefreshToken
+}
+
+// refresh ротирует токен и возвращает новый
+func (f *refreshFixture) refresh(t *testing.T, refreshToken string) string {
+	t.Helper()
+
+	resp, err := f.s.Refresh(context.Background(), RefreshRequest{RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	return resp.Tokens.RefreshToken
+}
+
+func TestRefresh(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare готовит состояние и возвращает предъявляемый refresh токен
+		prepare func(t *testing.T, f *refreshFixture) string
+		wantErr error
+	}{
+		{
+			name: "действующий токен",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				return f.login(t, time.Now())
+			},
+		},
+		{
+			name: "токен после ротации",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				return f.refresh(t, f.login(t, time.Now()))
+			},
+		},
+		{
+			name: "неизвестный токен",
+			prepare: func(*testing.T, *refreshFixture) string {
+				return "unknown"
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "повторное использование токена",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				refreshToken := f.login(t, time.Now())
+				f.refresh(t, refreshToken)
+				return refreshToken
+			},
+			wantErr: apperrors.ErrRefreshTokenReused,
+		},
+		{
+			name: "новый токен семейства после повторного использования",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				refreshToken := f.login(t, time.Now())
+				rotated := f.refresh(t, refreshToken)
+				if _, err := f.s.Refresh(context.Background(), RefreshRequest{RefreshToken: refreshToken}); !errors.Is(err, apperrors.ErrRefreshTokenReused) {
+					t.Fatalf("Refresh() error = %v, want %v", err, apperrors.ErrRefreshTokenReused)
+				}
+				return rotated
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "истекший токен",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				return f.login(t, time.Now().Add(-f.s.cfg.RefreshTokenTTL-time.Minute))
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "семейство старше максимального срока",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				refreshToken := f.login(t, time.Now())
+				for _, stored := range f.refreshRepo.tokens {
+					stored.token.FamilyCreatedAt = time.Now().Add(-f.s.cfg.RefreshTokenMaxLifetime)
+				}
+				return refreshToken
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "токены пользователя отозваны",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				refreshToken := f.login(t, time.Now())
+				if _, err := f.refreshRepo.RevokeUserRefreshTokens(context.Background(), f.user.UUID); err != nil {
+					t.Fatalf("RevokeUserRefreshTokens() unexpected error: %v", err)
+				}
+				return refreshToken
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "пользователь удален после входа",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				refreshToken := f.login(t, time.Now())
+				f.users.users = nil
+				return refreshToken
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+		{
+			name: "пользователь исключен из организации после входа",
+			prepare: func(t *testing.T, f *refreshFixture) string {
+				refreshToken := f.login(t, time.Now())
+				f.members.members = nil
+				return refreshToken
+			},
+			wantErr: apperrors.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRefreshFixture()
+			refreshToken := tt.prepare(t, f)
+
+			resp, err := f.s.Refresh(context.Background(), RefreshRequest{RefreshToken: refreshToken})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Refresh() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if resp.Tokens.RefreshToken == refreshToken {
+				t.Error("Refresh() returned the presented refresh token, want a rotated one")
+			}
+			wantAccessToken := "access:" + f.user.UUID.String() + ":" + f.organization.ID.String()
+			if resp.Tokens.AccessToken != wantAccessToken {
+				t.Errorf("Refresh() access token = %q, want %q", resp.Tokens.AccessToken, wantAccessToken)
+			}
+			familyExpiresAt := time.Now().Add(f.s.cfg.RefreshTokenMaxLifetime)
+			if resp.Tokens.RefreshTokenExpiresAt.After(familyExpiresAt) {
+				t.Errorf("Refresh() refresh token expires at %s, after family limit %s",
+					resp.Tokens.RefreshTokenExpiresAt, familyExpiresAt)
+			}
+			if _, ok := f.refreshRepo.tokens[token.HashOpaqueToken(resp.Tokens.RefreshToken)]; !ok {
+				t.Error("Refresh() did not store the rotated refresh token")
+			}
+		})
+	}
+}
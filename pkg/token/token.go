@@ -0,0 +1,338 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+// opaqueTokenSize размер непрозрачного токена в байтах
+const opaqueTokenSize = 32
+
+// apiKeyPrefix начало всех API ключей, по которому их легко узнать, например, в логах или коде
+const apiKeyPrefix = "ak_"
+
+// apiKeyDisplayLength длина начала API ключа, которое хранится и показывается в списке ключей
+const apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+// invitationTokenType тип JWT приглашения в организацию. Отличает приглашения
+// от access токенов, подписанных теми же ключами
+const invitationTokenType = "invitation+jwt"
+
+// Claims набор claims access токена
+type Claims struct {
+	jwt.RegisteredClaims
+	// TenantID организация, к которой относится пользователь
+	TenantID string `json:"tid,omitempty"`
+	// ClientID OAuth клиент, которому выдан токен. Пустой у токенов, выданных при входе в сам сервис
+	ClientID string `json:"client_id,omitempty"`
+	// Scope области действия через пробел. Заполняется только у токенов OAuth клиентов
+	Scope string `json:"scope,omitempty"`
+	// PrincipalType тип субъекта из claim sub. Пустой у токенов пользователей
+	PrincipalType string `json:"pty,omitempty"`
+}
+
+// ClientGrant доступ, выданный OAuth клиенту от имени пользователя или сервисного аккаунта
+type ClientGrant struct {
+	ClientID      uuid.UUID
+	Scopes        []string
+	PrincipalType models.PrincipalType
+}
+
+// IdentityClaims стандартные claims OpenID Connect о пользователе. Пустые поля не передаются:
+// они заполняются только для областей действия, на которые дано согласие
+type IdentityClaims struct {
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+}
+
+// IDTokenClaims набор claims ID токена OpenID Connect
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	IdentityClaims
+	Nonce    string           `json:"nonce,omitempty"`
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+}
+
+// IDToken данные для выпуска ID токена
+type IDToken struct {
+	// Subject UUID пользователя
+	Subject uuid.UUID
+	// ClientID OAuth клиент, которому адресован токен (claim aud)
+	ClientID uuid.UUID
+	Identity IdentityClaims
+	// Nonce из запроса авторизации. Пустой при обновлении токенов
+	Nonce string
+	// AuthTime время входа пользователя. Нулевое, если неизвестно
+	AuthTime time.Time
+}
+
+// UserUUID возвращает UUID пользователя из claim sub
+func (c *Claims) UserUUID() (uuid.UUID, error) {
+	userUUID, err := uuid.Parse(c.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid subject", apperrors.ErrInvalidAccessToken)
+	}
+	return userUUID, nil
+}
+
+// Principal возвращает тип субъекта токена
+func (c *Claims) Principal() models.PrincipalType {
+	if c.PrincipalType == "" {
+		return models.PrincipalTypeUser
+	}
+	return models.PrincipalType(c.PrincipalType)
+}
+
+// Tenant возвращает идентификатор организации пользователя из claim tid
+func (c *Claims) Tenant() (uuid.UUID, error) {
+	tenantID, err := uuid.Parse(c.TenantID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid tenant", apperrors.ErrInvalidAccessToken)
+	}
+	return tenantID, nil
+}
+
+// Manager выпускает и проверяет access токены
+type Manager interface {
+	IssueAccessToken(userUUID, tenantID uuid.UUID, now time.Time) (string, time.Time, error)
+	IssueClientAccessToken(subject, tenantID uuid.UUID, grant ClientGrant, now time.Time) (string, time.Time, error)
+	IssueIDToken(idToken IDToken, now time.Time) (string, error)
+	ParseAccessToken(accessToken string) (*Claims, error)
+	IssueInvitationToken(invitationID uuid.UUID, now, expiresAt time.Time) (string, error)
+	ParseInvitationToken(invitationToken string) (uuid.UUID, error)
+}
+
+// KeyStore источник ключей Ed25519 для подписи и проверки токенов
+type KeyStore interface {
+	SigningKey() (string, ed25519.PrivateKey, error)
+	VerificationKey(kid string) (ed25519.PublicKey, error)
+}
+
+// ed25519Manager реализация Manager с подписью EdDSA (Ed25519)
+type ed25519Manager struct {
+	keys   KeyStore
+	issuer string
+	ttl    time.Duration
+}
+
+// NewEd25519Manager создает менеджер access токенов, подписывающий их ключами Ed25519 из KeyStore
+func NewEd25519Manager(keys KeyStore, issuer string, ttl time.Duration) Manager {
+	return &ed25519Manager{
+		keys:   keys,
+		issuer: issuer,
+		ttl:    ttl,
+	}
+}
+
+// IssueAccessToken выпускает подписанный access токен для пользователя организации tenantID
+func (m *ed25519Manager) IssueAccessToken(userUUID, tenantID uuid.UUID, now time.Time) (string, time.Time, error) {
+	return m.issueAccessToken(userUUID, tenantID, ClientGrant{}, now)
+}
+
+// IssueClientAccessToken выпускает подписанный access токен OAuth клиента. Субъект токена -
+// пользователь или сервисный аккаунт организации tenantID, в зависимости от grant.PrincipalType
+func (m *ed25519Manager) IssueClientAccessToken(
+	subject uuid.UUID,
+	tenantID uuid.UUID,
+	grant ClientGrant,
+	now time.Time,
+) (string, time.Time, error) {
+	return m.issueAccessToken(subject, tenantID, grant, now)
+}
+
+// issueAccessToken выпускает подписанный access токен. Пустой grant - токен выдан при входе в сам сервис
+func (m *ed25519Manager) issueAccessToken(
+	subject uuid.UUID,
+	tenantID uuid.UUID,
+	grant ClientGrant,
+	now time.Time,
+) (string, time.Time, error) {
+	kid, privateKey, err := m.keys.SigningKey()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   subject.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.New().String(),
+		},
+		TenantID: tenantID.String(),
+		Scope:    strings.Join(grant.Scopes, " "),
+	}
+	if grant.ClientID != uuid.Nil {
+		claims.ClientID = grant.ClientID.String()
+	}
+	if grant.PrincipalType != models.PrincipalTypeUser {
+		claims.PrincipalType = string(grant.PrincipalType)
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	t.Header["kid"] = kid
+
+	signed, err := t.SignedString(privateKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+// IssueIDToken выпускает подписанный ID токен OpenID Connect для OAuth клиента.
+// Срок жизни такой же, как у access токена
+func (m *ed25519Manager) IssueIDToken(idToken IDToken, now time.Time) (string, error) {
+	kid, privateKey, err := m.keys.SigningKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	claims := IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   idToken.Subject.String(),
+			Audience:  jwt.ClaimStrings{idToken.ClientID.String()},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.ttl)),
+		},
+		IdentityClaims: idToken.Identity,
+		Nonce:          idToken.Nonce,
+	}
+	if !idToken.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(idToken.AuthTime)
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	t.Header["kid"] = kid
+
+	signed, err := t.SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign id token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// ParseAccessToken проверяет подпись и срок действия access токена и возвращает его claims
+func (m *ed25519Manager) ParseAccessToken(accessToken string) (*Claims, error) {
+	var claims Claims
+
+	parsed, err := m.parse(accessToken, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidAccessToken, err)
+	}
+	if typ, _ := parsed.Header["typ"].(string); typ == invitationTokenType {
+		return nil, fmt.Errorf("%w: unexpected token type %s", apperrors.ErrInvalidAccessToken, typ)
+	}
+	// У access токенов нет claim aud, а ID токен адресован клиенту и не дает доступа к API
+	if len(claims.Audience) > 0 {
+		return nil, fmt.Errorf("%w: id token is not an access token", apperrors.ErrInvalidAccessToken)
+	}
+
+	return &claims, nil
+}
+
+// IssueInvitationToken выпускает подписанный токен приглашения в организацию.
+// Токен несет только идентификатор приглашения, остальное хранится на сервере
+func (m *ed25519Manager) IssueInvitationToken(invitationID uuid.UUID, now, expiresAt time.Time) (string, error) {
+	kid, privateKey, err := m.keys.SigningKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    m.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		ID:        invitationID.String(),
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	t.Header["kid"] = kid
+	t.Header["typ"] = invitationTokenType
+
+	signed, err := t.SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign invitation token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// ParseInvitationToken проверяет подпись и срок действия токена приглашения и возвращает идентификатор приглашения
+func (m *ed25519Manager) ParseInvitationToken(invitationToken string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+
+	parsed, err := m.parse(invitationToken, &claims)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidInvitation, err)
+	}
+	if typ, _ := parsed.Header["typ"].(string); typ != invitationTokenType {
+		return uuid.Nil, fmt.Errorf("%w: unexpected token type %s", apperrors.ErrInvalidInvitation, typ)
+	}
+
+	invitationID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid invitation id", apperrors.ErrInvalidInvitation)
+	}
+
+	return invitationID, nil
+}
+
+// parse проверяет подпись, издателя и срок действия JWT и заполняет claims
+func (m *ed25519Manager) parse(signed string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(signed, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return m.keys.VerificationKey(kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+	)
+}
+
+// GenerateOpaqueToken генерирует непрозрачный токен: refresh токен, токен сброса пароля и т.п.
+func GenerateOpaqueToken() (string, error) {
+	buf := make([]byte, opaqueTokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// GenerateAPIKey генерирует API ключ и возвращает его вместе с началом, по которому ключ узнается в списке.
+// Ключ хранится на сервере так же, как непрозрачные токены, - хешем HashOpaqueToken
+func GenerateAPIKey() (string, string, error) {
+	secret, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	apiKey := apiKeyPrefix + secret
+	return apiKey, apiKey[:apiKeyDisplayLength], nil
+}
+
+// HashOpaqueToken возвращает хеш непрозрачного токена, под которым он хранится на сервере
+func HashOpaqueToken(opaqueToken string) string {
+	sum := sha256.Sum256([]byte(opaqueToken))
+	return hex.EncodeToString(sum[:])
+}
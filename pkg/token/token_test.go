@@ -0,0 +1,154 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+	"github.com/olezhek28/auth-service/pkg/models"
+)
+
+const (
+	testIssuer = "https://auth.example.com"
+	testKID    = "test-key"
+)
+
+// staticKeyStore подписывает и проверяет токены одним ключом
+type staticKeyStore struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (k *staticKeyStore) SigningKey() (string, ed25519.PrivateKey, error) {
+	return testKID, k.privateKey, nil
+}
+
+func (k *staticKeyStore) VerificationKey(kid string) (ed25519.PublicKey, error) {
+	if kid != testKID {
+		return nil, errors.New("unknown key")
+	}
+	return k.privateKey.Public().(ed25519.PublicKey), nil
+}
+
+// newTestKeyStore создает хранилище со случайным ключом
+func newTestKeyStore(t *testing.T) KeyStore {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return &staticKeyStore{privateKey: privateKey}
+}
+
+// withoutExpiry отбрасывает срок действия из результата выпуска access токена
+func withoutExpiry(signed string, _ time.Time, err error) (string, error) {
+	return signed, err
+}
+
+func TestParseAccessToken(t *testing.T) {
+	keys := newTestKeyStore(t)
+	manager := NewEd25519Manager(keys, testIssuer, time.Minute)
+	now := time.Now()
+	userUUID := uuid.New()
+	tenantID := uuid.New()
+	clientID := uuid.New()
+
+	tests := []struct {
+		name    string
+		token   func() (string, error)
+		wantErr bool
+		// wantClientID ожидаемый claim client_id принятого токена
+		wantClientID string
+	}{
+		{
+			name: "access токен входа в сервис",
+			token: func() (string, error) {
+				return withoutExpiry(manager.IssueAccessToken(userUUID, tenantID, now))
+			},
+		},
+		{
+			name: "access токен OAuth клиента",
+			token: func() (string, error) {
+				return withoutExpiry(manager.IssueClientAccessToken(userUUID, tenantID, ClientGrant{
+					ClientID:      clientID,
+					Scopes:        []string{"openid"},
+					PrincipalType: models.PrincipalTypeUser,
+				}, now))
+			},
+			wantClientID: clientID.String(),
+		},
+		{
+			name: "токен приглашения",
+			token: func() (string, error) {
+				return manager.IssueInvitationToken(uuid.New(), now, now.Add(time.Hour))
+			},
+			wantErr: true,
+		},
+		{
+			name: "ID токен",
+			token: func() (string, error) {
+				return manager.IssueIDToken(IDToken{Subject: userUUID, ClientID: clientID}, now)
+			},
+			wantErr: true,
+		},
+		{
+			name: "истекший access токен",
+			token: func() (string, error) {
+				return withoutExpiry(manager.IssueAccessToken(userUUID, tenantID, now.Add(-time.Hour)))
+			},
+			wantErr: true,
+		},
+		{
+			name: "токен, подписанный другим ключом",
+			token: func() (string, error) {
+				other := NewEd25519Manager(newTestKeyStore(t), testIssuer, time.Minute)
+				return withoutExpiry(other.IssueAccessToken(userUUID, tenantID, now))
+			},
+			wantErr: true,
+		},
+		{
+			name: "токен другого издателя",
+			token: func() (string, error) {
+				other := NewEd25519Manager(keys, "https://other.example.com", time.Minute)
+				return withoutExpiry(other.IssueAccessToken(userUUID, tenantID, now))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := tt.token()
+			if err != nil {
+				t.Fatalf("failed to issue token: %v", err)
+			}
+
+			claims, err := manager.ParseAccessToken(signed)
+			if tt.wantErr {
+				if !errors.Is(err, apperrors.ErrInvalidAccessToken) {
+					t.Fatalf("ParseAccessToken() error = %v, want %v", err, apperrors.ErrInvalidAccessToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAccessToken() unexpected error: %v", err)
+			}
+
+			if got, err := claims.UserUUID(); err != nil || got != userUUID {
+				t.Errorf("UserUUID() = %s, %v, want %s", got, err, userUUID)
+			}
+			if got, err := claims.Tenant(); err != nil || got != tenantID {
+				t.Errorf("Tenant() = %s, %v, want %s", got, err, tenantID)
+			}
+			if claims.ClientID != tt.wantClientID {
+				t.Errorf("ClientID = %q, want %q", claims.ClientID, tt.wantClientID)
+			}
+		})
+	}
+}
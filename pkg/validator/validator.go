@@ -3,11 +3,28 @@ package validator
 import (
 	"fmt"
 	"net/mail"
+	"net/url"
+	"regexp"
 	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
 
 	apperrors "github.com/olezhek28/auth-service/pkg/errors"
 )
 
+// accessNamePattern допустимые имена ролей и разрешений, например admin или orders:read
+var accessNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]{0,99}$`)
+
+// codeChallengePattern PKCE challenge метода S256: SHA-256 в base64url без дополнения
+var codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// codeVerifierPattern PKCE code_verifier по RFC 7636
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{43,128}$`)
+
+// tenantPattern допустимые slug организаций, например acme или acme-eu
+var tenantPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+
 // ValidateEmail проверяет корректность email
 func ValidateEmail(email string) error {
 	if email == "" {
@@ -22,16 +39,13 @@ func ValidateEmail(email string) error {
 	return nil
 }
 
-// ValidatePassword проверяет корректность пароля
+// ValidatePassword проверяет наличие пароля при входе. Требования к новым паролям проверяет
+// политика паролей: пароль, заданный до ее изменения, должен по-прежнему подходить для входа
 func ValidatePassword(password string) error {
 	if password == "" {
 		return fmt.Errorf("%w: password is required", apperrors.ErrInvalidInput)
 	}
 
-	if len(password) < 6 {
-		return fmt.Errorf("%w: password must be at least 6 characters", apperrors.ErrInvalidInput)
-	}
-
 	return nil
 }
 
@@ -66,3 +80,322 @@ func ValidateSessionUUID(sessionUUID string) error {
 
 	return nil
 }
+
+// ValidateRefreshToken проверяет наличие refresh токена
+func ValidateRefreshToken(refreshToken string) error {
+	if strings.TrimSpace(refreshToken) == "" {
+		return fmt.Errorf("%w: refresh_token is required", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidatePasswordResetToken проверяет наличие токена сброса пароля
+func ValidatePasswordResetToken(resetToken string) error {
+	if strings.TrimSpace(resetToken) == "" {
+		return fmt.Errorf("%w: token is required", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateEmailVerificationToken проверяет наличие токена подтверждения email
+func ValidateEmailVerificationToken(verificationToken string) error {
+	if strings.TrimSpace(verificationToken) == "" {
+		return fmt.Errorf("%w: token is required", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateInvitationToken проверяет наличие токена приглашения в организацию
+func ValidateInvitationToken(invitationToken string) error {
+	if strings.TrimSpace(invitationToken) == "" {
+		return fmt.Errorf("%w: invitation token is required", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateAPIKey проверяет наличие API ключа
+func ValidateAPIKey(apiKey string) error {
+	if strings.TrimSpace(apiKey) == "" {
+		return fmt.Errorf("%w: api_key is required", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateTOTPCode проверяет формат кода из приложения-аутентификатора
+func ValidateTOTPCode(code string) error {
+	if code == "" {
+		return fmt.Errorf("%w: code is required", apperrors.ErrInvalidInput)
+	}
+
+	if len(code) != 6 || strings.Trim(code, "0123456789") != "" {
+		return fmt.Errorf("%w: code must be 6 digits", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateSecondFactor проверяет, что передан ровно один из кода из приложения и кода восстановления
+func ValidateSecondFactor(code, recoveryCode string) error {
+	if code != "" && recoveryCode != "" {
+		return fmt.Errorf("%w: only one of code and recovery_code must be set", apperrors.ErrInvalidInput)
+	}
+
+	if recoveryCode != "" {
+		return nil
+	}
+
+	return ValidateTOTPCode(code)
+}
+
+// ValidateMFAChallenge проверяет наличие идентификатора MFA challenge
+func ValidateMFAChallenge(challengeID string) error {
+	if strings.TrimSpace(challengeID) == "" {
+		return fmt.Errorf("%w: mfa_challenge is required", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateUserUUID проверяет корректность UUID пользователя
+func ValidateUserUUID(userUUID string) error {
+	if userUUID == "" {
+		return fmt.Errorf("%w: user_uuid is required", apperrors.ErrInvalidInput)
+	}
+
+	if _, err := uuid.Parse(userUUID); err != nil {
+		return fmt.Errorf("%w: invalid user_uuid format", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateRoleName проверяет имя роли
+func ValidateRoleName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: role is required", apperrors.ErrInvalidInput)
+	}
+
+	if !accessNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: role must be 1-100 characters of a-z, 0-9, '_', '-', '.' and ':'", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidatePermission проверяет имя разрешения
+func ValidatePermission(permission string) error {
+	if permission == "" {
+		return fmt.Errorf("%w: permission is required", apperrors.ErrInvalidInput)
+	}
+
+	if !accessNamePattern.MatchString(permission) {
+		return fmt.Errorf("%w: permission %q must be 1-100 characters of a-z, 0-9, '_', '-', '.' and ':'",
+			apperrors.ErrInvalidInput, permission)
+	}
+
+	return nil
+}
+
+// ValidateResource проверяет идентификатор ресурса. Пустой ресурс допустим
+func ValidateResource(resource string) error {
+	if len(resource) > 255 {
+		return fmt.Errorf("%w: resource must be at most 255 characters", apperrors.ErrInvalidInput)
+	}
+
+	if strings.ContainsFunc(resource, unicode.IsSpace) {
+		return fmt.Errorf("%w: resource must not contain whitespace", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateTenant проверяет slug организации, в которой выполняется запрос
+func ValidateTenant(tenant string) error {
+	if tenant == "" {
+		return fmt.Errorf("%w: tenant is required", apperrors.ErrInvalidInput)
+	}
+
+	if !tenantPattern.MatchString(tenant) {
+		return fmt.Errorf("%w: tenant must be 1-63 characters of a-z, 0-9 and '-', not starting or ending with '-'",
+			apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateOrganizationName проверяет название организации
+func ValidateOrganizationName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: organization name is required", apperrors.ErrInvalidInput)
+	}
+
+	if len(name) > 255 {
+		return fmt.Errorf("%w: organization name must be at most 255 characters", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateAPIKeyID проверяет корректность идентификатора API ключа
+func ValidateAPIKeyID(keyID string) error {
+	if keyID == "" {
+		return fmt.Errorf("%w: key_id is required", apperrors.ErrInvalidInput)
+	}
+
+	if _, err := uuid.Parse(keyID); err != nil {
+		return fmt.Errorf("%w: invalid key_id format", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateAPIKeyName проверяет название API ключа
+func ValidateAPIKeyName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: api key name is required", apperrors.ErrInvalidInput)
+	}
+
+	if len(name) > 100 {
+		return fmt.Errorf("%w: api key name must be at most 100 characters", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateServiceAccountID проверяет корректность идентификатора сервисного аккаунта
+func ValidateServiceAccountID(serviceAccountID string) error {
+	if serviceAccountID == "" {
+		return fmt.Errorf("%w: service_account_id is required", apperrors.ErrInvalidInput)
+	}
+
+	if _, err := uuid.Parse(serviceAccountID); err != nil {
+		return fmt.Errorf("%w: invalid service_account_id format", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateServiceAccountName проверяет название сервисного аккаунта
+func ValidateServiceAccountName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: service account name is required", apperrors.ErrInvalidInput)
+	}
+
+	if len(name) > 100 {
+		return fmt.Errorf("%w: service account name must be at most 100 characters", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateOAuthClientID проверяет корректность client_id
+func ValidateOAuthClientID(clientID string) error {
+	if clientID == "" {
+		return fmt.Errorf("%w: client_id is required", apperrors.ErrInvalidInput)
+	}
+
+	if _, err := uuid.Parse(clientID); err != nil {
+		return fmt.Errorf("%w: invalid client_id format", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateOAuthClientName проверяет название OAuth клиента
+func ValidateOAuthClientName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: client name is required", apperrors.ErrInvalidInput)
+	}
+
+	if len(name) > 100 {
+		return fmt.Errorf("%w: client name must be at most 100 characters", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateOAuthScope проверяет имя области действия OAuth
+func ValidateOAuthScope(scope string) error {
+	if !accessNamePattern.MatchString(scope) {
+		return fmt.Errorf("%w: scope %q must be 1-100 characters of a-z, 0-9, '_', '-', '.' and ':'",
+			apperrors.ErrInvalidInput, scope)
+	}
+
+	return nil
+}
+
+// ValidateRedirectURI проверяет redirect URI OAuth клиента: абсолютный https URI без фрагмента.
+// http допускается только для loopback адресов, которые используют нативные приложения
+func ValidateRedirectURI(redirectURI string) error {
+	parsed, err := url.Parse(redirectURI)
+	if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+		return fmt.Errorf("%w: redirect uri %q must be an absolute URI", apperrors.ErrInvalidInput, redirectURI)
+	}
+
+	if parsed.Fragment != "" {
+		return fmt.Errorf("%w: redirect uri %q must not contain a fragment", apperrors.ErrInvalidInput, redirectURI)
+	}
+
+	switch parsed.Scheme {
+	case "https":
+	case "http":
+		if host := parsed.Hostname(); host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			return fmt.Errorf("%w: redirect uri %q must use https", apperrors.ErrInvalidInput, redirectURI)
+		}
+	default:
+		return fmt.Errorf("%w: redirect uri %q must use https", apperrors.ErrInvalidInput, redirectURI)
+	}
+
+	return nil
+}
+
+// ValidateCodeChallenge проверяет PKCE challenge. Поддерживается только метод S256
+func ValidateCodeChallenge(codeChallenge, method string) error {
+	if codeChallenge == "" {
+		return fmt.Errorf("%w: code_challenge is required", apperrors.ErrInvalidInput)
+	}
+
+	if method != "S256" {
+		return fmt.Errorf("%w: code_challenge_method must be S256", apperrors.ErrInvalidInput)
+	}
+
+	if !codeChallengePattern.MatchString(codeChallenge) {
+		return fmt.Errorf("%w: code_challenge must be a base64url encoded SHA-256 hash", apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateCodeVerifier проверяет PKCE code_verifier по RFC 7636
+func ValidateCodeVerifier(codeVerifier string) error {
+	if codeVerifier == "" {
+		return fmt.Errorf("%w: code_verifier is required", apperrors.ErrInvalidInput)
+	}
+
+	if !codeVerifierPattern.MatchString(codeVerifier) {
+		return fmt.Errorf("%w: code_verifier must be 43-128 characters of A-Z, a-z, 0-9, '-', '.', '_' and '~'",
+			apperrors.ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// ValidateNonce проверяет nonce OpenID Connect: необязательная строка из видимых ASCII символов
+func ValidateNonce(nonce string) error {
+	if len(nonce) > 255 {
+		return fmt.Errorf("%w: nonce must be at most 255 characters", apperrors.ErrInvalidInput)
+	}
+
+	for _, r := range nonce {
+		if r < '!' || r > '~' {
+			return fmt.Errorf("%w: nonce must contain only visible ASCII characters", apperrors.ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apperrors "github.com/olezhek28/auth-service/pkg/errors"
+)
+
+func TestValidateRoleName(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr bool
+	}{
+		{name: "простое имя", role: "admin"},
+		{name: "имя с разделителями", role: "billing.read-only_v2:eu"},
+		{name: "максимальная длина", role: strings.Repeat("a", 100)},
+		{name: "пустое имя", role: "", wantErr: true},
+		{name: "слишком длинное имя", role: strings.Repeat("a", 101), wantErr: true},
+		{name: "заглавные буквы", role: "Admin", wantErr: true},
+		{name: "пробел", role: "super admin", wantErr: true},
+		{name: "слеш", role: "docs/editor", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRoleName(tt.role)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRoleName(%q) error = %v, wantErr %v", tt.role, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, apperrors.ErrInvalidInput) {
+				t.Errorf("ValidateRoleName(%q) error = %v, want %v", tt.role, err, apperrors.ErrInvalidInput)
+			}
+		})
+	}
+}
+
+func TestValidatePermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		wantErr    bool
+	}{
+		{name: "ресурс и действие", permission: "docs:read"},
+		{name: "вложенный ресурс", permission: "billing.invoices:write"},
+		{name: "пустое разрешение", permission: "", wantErr: true},
+		{name: "слишком длинное разрешение", permission: strings.Repeat("a", 101), wantErr: true},
+		{name: "заглавные буквы", permission: "Docs:Read", wantErr: true},
+		{name: "звездочка", permission: "docs:*", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePermission(tt.permission)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePermission(%q) error = %v, wantErr %v", tt.permission, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, apperrors.ErrInvalidInput) {
+				t.Errorf("ValidatePermission(%q) error = %v, want %v", tt.permission, err, apperrors.ErrInvalidInput)
+			}
+		})
+	}
+}
+
+func TestValidateResource(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		wantErr  bool
+	}{
+		{name: "пустой ресурс", resource: ""},
+		{name: "путь ресурса", resource: "projects/42/docs/7"},
+		{name: "максимальная длина", resource: strings.Repeat("r", 255)},
+		{name: "слишком длинный ресурс", resource: strings.Repeat("r", 256), wantErr: true},
+		{name: "пробел", resource: "docs/ 7", wantErr: true},
+		{name: "перевод строки", resource: "docs/7\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResource(tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateResource(%q) error = %v, wantErr %v", tt.resource, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, apperrors.ErrInvalidInput) {
+				t.Errorf("ValidateResource(%q) error = %v, want %v", tt.resource, err, apperrors.ErrInvalidInput)
+			}
+		})
+	}
+}